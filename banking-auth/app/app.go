@@ -3,68 +3,70 @@ package app
 import (
 	"banking-auth/domain"
 	"banking-auth/service"
+	"config"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
-	"os"
-	"time"
+	"platform/roles"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
-	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 )
 
-func Start() {
-	// Load env variables
-	err := godotenv.Load()
-	if err != nil {
-		panic("Error loading .env file")
+// Start : Runs the auth server, args are the command line flags
+func Start(args []string) {
+	cfg, _ := loadConfig(flag.NewFlagSet("banking-auth", flag.ExitOnError), args)
+	metrics := NewMetrics()
+	var client *sqlx.DB
+	var authRepository domain.AuthRepository
+	if cfg.Storage == storageMemory {
+		log.Println("Using the in-memory fixture users, for a banking service running with STORAGE=memory")
+		authRepository = domain.NewAuthRepositoryStub()
+	} else {
+		client = getDbClient(cfg.DB)
+		metrics.RegisterDB(client, cfg.DB.Name)
+		authRepository = domain.NewAuthRepository(client)
 	}
-	sanityCheck()
-	router := mux.NewRouter()
-	authRepository := domain.NewAuthRepository(getDbClient())
-	ah := AuthHandler{service.NewLoginService(authRepository, domain.GetRolePermissions())}
+	ah := AuthHandler{service.NewLoginService(authRepository, roles.Default(), []byte(cfg.SigningKey), cfg.TokenTTL), metrics}
+	router := newRouter(ah, HealthHandler{client}, metrics)
 
-	router.HandleFunc("/auth/login", ah.Login).Methods(http.MethodPost)
-	router.HandleFunc("/auth/register", ah.NotImplementedHandler).Methods(http.MethodPost)
-	router.HandleFunc("/auth/verify", ah.Verify).Methods(http.MethodGet)
+	server := &http.Server{Addr: cfg.Server.ListenAddress(), Handler: router}
+	log.Println(fmt.Sprintf("Starting OAuth server on %s ...", server.Addr))
+	if err := serve(server, cfg.Server.ShutdownTimeout); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	if client != nil {
+		client.Close()
+	}
+	log.Println("OAuth server stopped")
+}
 
-	address := os.Getenv("SERVER_ADDRESS")
-	port := os.Getenv("SERVER_PORT")
-	log.Println(fmt.Sprintf("Starting OAuth server on %s:%s ...", address, port))
-	log.Fatal(http.ListenAndServe(fmt.Sprintf("%s:%s", address, port), router))
+// newRouter : Registers the named routes, the route names label the request metrics
+func newRouter(ah AuthHandler, hh HealthHandler, metrics Metrics) *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/auth/login", ah.Login).Methods(http.MethodPost).Name("Login")
+	router.HandleFunc("/auth/register", ah.NotImplementedHandler).Methods(http.MethodPost).Name("Register")
+	router.HandleFunc("/auth/verify", ah.Verify).Methods(http.MethodGet).Name("Verify")
+	router.HandleFunc("/healthz", hh.Healthz).Methods(http.MethodGet).Name("Healthz")
+	router.HandleFunc("/readyz", hh.Readyz).Methods(http.MethodGet).Name("Readyz")
+	router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet).Name("Metrics")
+	router.Use(metrics.Middleware)
+	return router
 }
 
-func getDbClient() *sqlx.DB {
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbAddress := os.Getenv("DB_ADDRESS")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
-	dataSource := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbAddress, dbPort, dbName)
-	client, err := sqlx.Open("mysql", dataSource)
+// getDbClient : Opens the configured database with its pool settings
+func getDbClient(db config.DB) *sqlx.DB {
+	client, err := sqlx.Open(db.DriverName(), db.DataSource())
 	if err != nil {
 		panic(err)
 	}
 	// See "Important settings" section.
-	client.SetConnMaxLifetime(time.Minute * 3)
-	client.SetMaxOpenConns(10)
-	client.SetMaxIdleConns(10)
+	client.SetConnMaxLifetime(db.ConnMaxLifetime)
+	client.SetMaxOpenConns(db.MaxOpenConns)
+	client.SetMaxIdleConns(db.MaxIdleConns)
 	return client
 }
-
-func sanityCheck() {
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbAddress := os.Getenv("DB_ADDRESS")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
-	if dbUser == "" ||
-		dbPassword == "" ||
-		dbAddress == "" ||
-		dbPort == "" ||
-		dbName == "" {
-		log.Fatal("Envoriment variables not defined...")
-	}
-}
@@ -10,6 +10,7 @@ import (
 
 type AuthHandler struct {
 	service service.AuthService
+	metrics Metrics
 }
 
 func (h AuthHandler) NotImplementedHandler(w http.ResponseWriter, r *http.Request) {
@@ -20,12 +21,15 @@ func (h AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var loginRequest dto.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&loginRequest); err != nil {
 		log.Println("Error while decoding login request: " + err.Error())
+		h.metrics.login("failure")
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
 		token, err := h.service.Login(loginRequest)
 		if err != nil {
+			h.metrics.login("failure")
 			writeResponse(w, http.StatusUnauthorized, err.Error())
 		} else {
+			h.metrics.login("success")
 			writeResponse(w, http.StatusOK, *token)
 		}
 	}
@@ -47,15 +51,19 @@ func (h AuthHandler) Verify(w http.ResponseWriter, r *http.Request) {
 
 		isAuthorized, appError := h.service.Verify(urlParams)
 		if appError != nil {
+			h.metrics.verification("deny")
 			writeResponse(w, http.StatusForbidden, notAuthorizedResponse())
 		} else {
 			if isAuthorized {
+				h.metrics.verification("allow")
 				writeResponse(w, http.StatusOK, authorizedResponse())
 			} else {
+				h.metrics.verification("deny")
 				writeResponse(w, http.StatusForbidden, notAuthorizedResponse())
 			}
 		}
 	} else {
+		h.metrics.verification("deny")
 		writeResponse(w, http.StatusForbidden, "missing token")
 	}
 }
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"banking-auth/domain"
+	"banking-auth/service"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"platform/roles"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newAuthServer(t *testing.T) *httptest.Server {
+	metrics := NewMetrics()
+	ah := AuthHandler{service.NewLoginService(domain.NewAuthRepositoryStub(), roles.Default(), []byte("test-signing-key-0123"), time.Hour), metrics}
+	server := httptest.NewServer(newRouter(ah, HealthHandler{}, metrics))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func login(t *testing.T, server *httptest.Server, body string) (int, string) {
+	resp, err := http.Post(server.URL+"/auth/login", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var token string
+	json.NewDecoder(resp.Body).Decode(&token)
+	return resp.StatusCode, token
+}
+
+func verify(t *testing.T, server *httptest.Server, params url.Values) int {
+	resp, err := http.Get(server.URL + "/auth/verify?" + params.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func exported(t *testing.T, server *httptest.Server) string {
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	return string(body)
+}
+
+func Test_should_count_successful_and_failed_logins(t *testing.T) {
+	server := newAuthServer(t)
+
+	code, token := login(t, server, `{"username":"jotaro","password":"abc123"}`)
+	if code != http.StatusOK || token == "" {
+		t.Fatalf("login status = %d, token = %q", code, token)
+	}
+	if code, _ := login(t, server, `{"username":"jotaro","password":"wrong"}`); code != http.StatusUnauthorized {
+		t.Errorf("wrong password status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code, _ := login(t, server, `{"username":`); code != http.StatusBadRequest {
+		t.Errorf("invalid body status = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	body := exported(t, server)
+	for _, expected := range []string{
+		`banking_auth_logins_total{result="success"} 1`,
+		`banking_auth_logins_total{result="failure"} 2`,
+		`banking_auth_http_requests_total{code="401",method="POST",route="Login"} 1`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("%s should be exported", expected)
+		}
+	}
+}
+
+func Test_should_count_allowed_and_denied_verifications(t *testing.T) {
+	server := newAuthServer(t)
+	_, token := login(t, server, `{"username":"jotaro","password":"abc123"}`)
+
+	tests := []struct {
+		name   string
+		params url.Values
+		want   int
+	}{
+		{"own account", url.Values{"token": {token}, "routeName": {"GetAccount"}, "customer_id": {"1001"}, "account_id": {"95470"}}, http.StatusOK},
+		{"another customer", url.Values{"token": {token}, "routeName": {"GetCustomer"}, "customer_id": {"1003"}}, http.StatusForbidden},
+		{"admin route", url.Values{"token": {token}, "routeName": {"FreezeAccount"}, "customer_id": {"1001"}, "account_id": {"95470"}}, http.StatusForbidden},
+		{"forged token", url.Values{"token": {token + "x"}, "routeName": {"GetCustomer"}, "customer_id": {"1001"}}, http.StatusForbidden},
+		{"missing token", url.Values{"routeName": {"GetCustomer"}, "customer_id": {"1001"}}, http.StatusForbidden},
+	}
+	for _, tc := range tests {
+		if got := verify(t, server, tc.params); got != tc.want {
+			t.Errorf("%s: verify status = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+
+	body := exported(t, server)
+	for _, expected := range []string{
+		`banking_auth_verifications_total{result="allow"} 1`,
+		`banking_auth_verifications_total{result="deny"} 4`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("%s should be exported", expected)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"config"
+	"flag"
+	"log"
+	"time"
+)
+
+// minSigningKeyLength : HS256 keys shorter than this are easy to brute force
+const minSigningKeyLength = 16
+
+// Values of STORAGE, the users table is the default and memory knows the fixture users of the banking service
+const (
+	storageDatabase = "database"
+	storageMemory   = "memory"
+)
+
+// Config : Every setting of the auth server, see the config package for the layering
+type Config struct {
+	Server     config.Server `yaml:"server"`
+	DB         config.DB     `yaml:"db"`
+	Storage    string        `yaml:"storage" env:"STORAGE" flag:"storage" usage:"database or memory"`
+	TokenTTL   time.Duration `yaml:"token_ttl" env:"TOKEN_TTL" flag:"token-ttl" usage:"how long an issued token is valid"`
+	SigningKey string        `yaml:"signing_key" env:"TOKEN_SIGNING_KEY" flag:"signing-key" usage:"HS256 key the tokens are signed with"`
+}
+
+// defaultConfig : Listens where the banking service expects it, the signing key has no default
+func defaultConfig() Config {
+	return Config{
+		Server:   config.Server{Address: "localhost", Port: "8081", ShutdownTimeout: 30 * time.Second},
+		DB:       config.DefaultDB(),
+		Storage:  storageDatabase,
+		TokenTTL: time.Hour,
+	}
+}
+
+// Validate : Records every invalid setting, the database is only checked when the storage uses it
+func (c Config) Validate(p *config.Problems) {
+	c.Server.Validate(p)
+	switch c.Storage {
+	case storageDatabase:
+		c.DB.Validate(p)
+	case storageMemory:
+	default:
+		p.Add("storage (STORAGE) should be %s or %s, not %q", storageDatabase, storageMemory, c.Storage)
+	}
+	if c.TokenTTL <= 0 {
+		p.Add("token_ttl (TOKEN_TTL) should be a positive duration like 1h")
+	}
+	if c.SigningKey == "" {
+		p.Add("signing_key (TOKEN_SIGNING_KEY) is required")
+	} else if len(c.SigningKey) < minSigningKeyLength {
+		p.Add("signing_key (TOKEN_SIGNING_KEY) should be at least %d characters, not %d", minSigningKeyLength, len(c.SigningKey))
+	}
+}
+
+// loadConfig : The config of the command and its positional arguments, see config.Load. The process stops when
+// the config is invalid.
+func loadConfig(flags *flag.FlagSet, args []string) (Config, []string) {
+	cfg := defaultConfig()
+	positional, err := config.Load(&cfg, flags, args)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	return cfg, positional
+}
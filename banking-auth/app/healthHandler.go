@@ -0,0 +1,40 @@
+package app
+
+import (
+	"banking-auth/dto"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// readinessTimeout : How long the database may take to answer before the server counts as not ready
+const readinessTimeout = 2 * time.Second
+
+// HealthHandler : Liveness and readiness probes, the banking service calls /healthz from its own readiness probe
+type HealthHandler struct {
+	// client : nil with the in-memory storage, which is always ready
+	client *sqlx.DB
+}
+
+// Healthz : The process is up and serving HTTP
+func (h HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, dto.HealthResponse{Status: "ok"})
+}
+
+// Readyz : The database answers, logins cannot be checked otherwise
+func (h HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if h.client == nil {
+		writeResponse(w, http.StatusOK, dto.HealthResponse{Status: "ready"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := h.client.PingContext(ctx); err != nil {
+		writeResponse(w, http.StatusServiceUnavailable, dto.HealthResponse{Status: "not ready", Checks: map[string]string{"database": err.Error()}})
+		return
+	}
+	writeResponse(w, http.StatusOK, dto.HealthResponse{Status: "ready", Checks: map[string]string{"database": "ok"}})
+}
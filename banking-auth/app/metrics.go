@@ -0,0 +1,45 @@
+package app
+
+import (
+	"platform/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "banking_auth"
+
+// Metrics : The collectors every server exports with the ones of the logins and verifications
+type Metrics struct {
+	metrics.Server
+	logins        *prometheus.CounterVec
+	verifications *prometheus.CounterVec
+}
+
+// login : result is success or failure
+func (m Metrics) login(result string) {
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// verification : result is allow or deny
+func (m Metrics) verification(result string) {
+	m.verifications.WithLabelValues(result).Inc()
+}
+
+// NewMetrics : Registers the login and verify collectors next to the HTTP, Go runtime and process collectors
+func NewMetrics() Metrics {
+	m := Metrics{
+		Server: metrics.NewServer(metricsNamespace),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "logins_total",
+			Help:      "Login attempts by result, success or failure.",
+		}, []string{"result"}),
+		verifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "verifications_total",
+			Help:      "Token verifications by result, allow or deny.",
+		}, []string{"result"}),
+	}
+	m.MustRegister(m.logins, m.verifications)
+	return m
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"banking-auth/migrations"
+	"flag"
+	"fmt"
+	"log"
+	"platform/migrate"
+	"strconv"
+)
+
+// RunMigrate : Command applying or reverting the embedded schema migrations of the configured database
+//
+//	banking-auth migrate up
+//	banking-auth migrate down -steps 1
+//	banking-auth migrate status
+//	banking-auth migrate baseline 1
+func RunMigrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	steps := flags.Int("steps", 1, "number of migrations to revert with down")
+	// flags may also follow the action: banking-auth migrate down -steps 2
+	cfg, positional := loadConfig(flags, args)
+	action := "up"
+	if len(positional) > 0 {
+		action = positional[0]
+	}
+
+	client := getDbClient(cfg.DB)
+	defer client.Close()
+	migrator, err := migrations.NewMigrator(client)
+	if err != nil {
+		log.Fatal("Cannot load migrations: " + err.Error())
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up()
+		logMigrations("Migration applied", applied)
+		if err != nil {
+			log.Fatal("Migration failed: " + err.Error())
+		}
+		log.Println(fmt.Sprintf("Schema is up to date, %d migrations applied", len(applied)))
+	case "down":
+		reverted, err := migrator.Down(*steps)
+		logMigrations("Migration reverted", reverted)
+		if err != nil {
+			log.Fatal("Migration failed: " + err.Error())
+		}
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			log.Fatal("Cannot read the applied migrations: " + err.Error())
+		}
+		for _, s := range status {
+			appliedAt := s.AppliedAt
+			if appliedAt == "" {
+				appliedAt = "pending"
+			}
+			log.Println(fmt.Sprintf("%04d_%s: %s", s.Version, s.Name, appliedAt))
+		}
+	case "baseline":
+		// a database created before the migrations existed: record the versions it already has, then run up
+		version := 0
+		if len(positional) > 1 {
+			version, _ = strconv.Atoi(positional[1])
+		}
+		if version <= 0 {
+			log.Fatal("migrate baseline needs the version the database already has, like: migrate baseline 1")
+		}
+		baselined, err := migrator.Baseline(version)
+		logMigrations("Migration baselined", baselined)
+		if err != nil {
+			log.Fatal("Baseline failed: " + err.Error())
+		}
+	default:
+		log.Fatal("migrate action should be up, down, status or baseline")
+	}
+}
+
+func logMigrations(message string, done []migrate.Migration) {
+	for _, m := range done {
+		log.Println(fmt.Sprintf("%s: %04d_%s", message, m.Version, m.Name))
+	}
+}
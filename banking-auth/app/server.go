@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// serve : Runs the server until SIGINT or SIGTERM, then stops accepting connections and waits up to timeout for the
+// requests in flight to finish
+func serve(server *http.Server, timeout time.Duration) error {
+	failed := make(chan error, 1)
+	go func() {
+		failed <- server.ListenAndServe()
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	select {
+	case err := <-failed:
+		return err
+	case sig := <-stop:
+		log.Printf("Received %s, draining the requests in flight for up to %s ...", sig, timeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"config"
 	"database/sql"
 	"errors"
 	"log"
@@ -18,10 +19,10 @@ type AuthRepositoryDb struct {
 
 func (d AuthRepositoryDb) FindBy(username, password string) (*Login, error) {
 	var login Login
-	sqlVerify := `SELECT username, u.customer_id, role, group_concat(a.account_id) as account_numbers FROM users u
+	sqlVerify := `SELECT username, u.customer_id, role, ` + accountNumbers(d.client.DriverName()) + ` as account_numbers FROM users u
                   LEFT JOIN accounts a ON a.customer_id = u.customer_id
                 WHERE username = ? and password = ? GROUP BY a.customer_id, u.username;`
-	err := d.client.Get(&login, sqlVerify, username, password)
+	err := d.client.Get(&login, d.client.Rebind(sqlVerify), username, password)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("invalid credentials")
@@ -33,6 +34,14 @@ func (d AuthRepositoryDb) FindBy(username, password string) (*Login, error) {
 	return &login, nil
 }
 
+// accountNumbers : Comma separated account ids of the user, PostgreSQL has no group_concat
+func accountNumbers(driver string) string {
+	if driver == config.DriverPostgres {
+		return "string_agg(a.account_id::text, ',')"
+	}
+	return "group_concat(a.account_id)"
+}
+
 func NewAuthRepository(client *sqlx.DB) AuthRepositoryDb {
 	return AuthRepositoryDb{client}
 }
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"platform/roles"
+)
+
+// stubUser : A fixture user of the in-memory adapter, with the accounts the banking service seeds for the customer
+type stubUser struct {
+	password string
+	login    Login
+}
+
+// AuthRepositoryStub : Checks the logins against fixture users instead of the users table, so the auth server runs
+// next to a banking service with STORAGE=memory without a database
+type AuthRepositoryStub struct {
+	users map[string]stubUser
+}
+
+func (s AuthRepositoryStub) FindBy(username string, password string) (*Login, error) {
+	user, ok := s.users[username]
+	if !ok || user.password != password {
+		return nil, errors.New("invalid credentials")
+	}
+	login := user.login
+	return &login, nil
+}
+
+func stubLogin(username string, role string, customerId string, accounts string) Login {
+	login := Login{Username: username, Role: role}
+	if customerId != "" {
+		login.CustomerId = sql.NullString{String: customerId, Valid: true}
+		login.Accounts = sql.NullString{String: accounts, Valid: true}
+	}
+	return login
+}
+
+// NewAuthRepositoryStub : Returns the adapter with the users of the seeded in-memory banking service, the admin
+// and the customers 1001 and 1003
+func NewAuthRepositoryStub() AuthRepositoryStub {
+	return AuthRepositoryStub{map[string]stubUser{
+		"admin":  {"abc123", stubLogin("admin", roles.Admin, "", "")},
+		"jotaro": {"abc123", stubLogin("jotaro", roles.User, "1001", "95470,95471")},
+		"joseph": {"abc123", stubLogin("joseph", roles.User, "1003", "95472")},
+	}}
+}
@@ -9,8 +9,6 @@ import (
 	"time"
 )
 
-const TOKEN_DURATION = time.Hour
-
 type Login struct {
 	Username   string         `db:"username"`
 	CustomerId sql.NullString `db:"customer_id"`
@@ -18,16 +16,18 @@ type Login struct {
 	Role       string         `db:"role"`
 }
 
-func (l Login) GenerateToken() (*string, error) {
+// GenerateToken : Signs the claims of the login with the HS256 key, the token expires after ttl
+func (l Login) GenerateToken(signingKey []byte, ttl time.Duration) (*string, error) {
 	var claims jwt.MapClaims
+	expiry := time.Now().Add(ttl).Unix()
 	if l.Accounts.Valid && l.CustomerId.Valid {
-		claims = l.claimsForUser()
+		claims = l.claimsForUser(expiry)
 	} else {
-		claims = l.claimsForAdmin()
+		claims = l.claimsForAdmin(expiry)
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedTokenAsString, err := token.SignedString([]byte(HMAC_SAMPLE_SECRET))
+	signedTokenAsString, err := token.SignedString(signingKey)
 	if err != nil {
 		log.Println("Failed while signing token: " + err.Error())
 		return nil, errors.New("cannot generate token")
@@ -35,21 +35,21 @@ func (l Login) GenerateToken() (*string, error) {
 	return &signedTokenAsString, nil
 }
 
-func (l Login) claimsForUser() jwt.MapClaims {
+func (l Login) claimsForUser(expiry int64) jwt.MapClaims {
 	accounts := strings.Split(l.Accounts.String, ",")
 	return jwt.MapClaims{
 		"customer_id": l.CustomerId.String,
 		"role":        l.Role,
 		"username":    l.Username,
 		"accounts":    accounts,
-		"exp":         time.Now().Add(TOKEN_DURATION).Unix(),
+		"exp":         expiry,
 	}
 }
 
-func (l Login) claimsForAdmin() jwt.MapClaims {
+func (l Login) claimsForAdmin(expiry int64) jwt.MapClaims {
 	return jwt.MapClaims{
 		"role":     l.Role,
 		"username": l.Username,
-		"exp":      time.Now().Add(TOKEN_DURATION).Unix(),
+		"exp":      expiry,
 	}
 }
@@ -6,8 +6,6 @@ import (
 	"github.com/dgrijalva/jwt-go"
 )
 
-const HMAC_SAMPLE_SECRET = "hmacSampleSecret"
-
 type Claims struct {
 	CustomerId string   `json:"customer_id"`
 	Accounts   []string `json:"accounts"`
@@ -1,12 +1,22 @@
 module banking-auth
 
-go 1.15
+go 1.16
 
 require (
+	config v0.0.0-00010101000000-000000000000
 	github.com/ashishjuyal/banking-auth v0.0.0-20201120071325-45c141521d71 // indirect
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/gorilla/mux v1.8.0
 	github.com/jmoiron/sqlx v1.2.0
-	github.com/joho/godotenv v1.3.0
+	github.com/lib/pq v1.9.0
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/prometheus/client_golang v1.12.2
+	platform v0.0.0-00010101000000-000000000000
+	golang.org/x/sys v0.10.0 // indirect
+	google.golang.org/protobuf v1.27.1 // indirect
 )
+
+replace config => ../config
+
+replace platform => ../platform
@@ -2,9 +2,14 @@ package main
 
 import (
 	"banking-auth/app"
+	"os"
 )
 
 func main() {
-
-	app.Start()
+	// banking-auth migrate up|down|status manages the users table instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app.RunMigrate(os.Args[2:])
+		return
+	}
+	app.Start(os.Args[1:])
 }
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"embed"
+	"platform/migrate"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/**
+ * The users table of the auth server, one directory of numbered files per dialect, see the migrate package.
+ * A new feature adds the next number to all three directories, an applied file is never edited again.
+ */
+
+//go:embed mysql/*.sql postgres/*.sql sqlite3/*.sql
+var files embed.FS
+
+// VersionTable : Where the applied versions are recorded, apart from the banking versions in a shared database
+const VersionTable = "auth_schema_migrations"
+
+// NewMigrator : Returns a migrator with the embedded migrations of the client's driver
+func NewMigrator(client *sqlx.DB) (*migrate.Migrator, error) {
+	return migrate.NewMigrator(client, files, VersionTable)
+}
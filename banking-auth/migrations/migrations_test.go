@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"path/filepath"
+	"platform/migrate"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func Test_should_have_the_same_versions_in_every_dialect(t *testing.T) {
+	mysql, err := migrate.Load(files, "mysql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dialect := range []string{"postgres", "sqlite3"} {
+		migrations, err := migrate.Load(files, dialect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(migrations) != len(mysql) {
+			t.Fatalf("%s has %d migrations, mysql has %d", dialect, len(migrations), len(mysql))
+		}
+		for i := range migrations {
+			if migrations[i].Version != mysql[i].Version || migrations[i].Name != mysql[i].Name {
+				t.Errorf("%s migration %d is %04d_%s, mysql has %04d_%s", dialect, i, migrations[i].Version, migrations[i].Name,
+					mysql[i].Version, mysql[i].Name)
+			}
+		}
+	}
+}
+
+func Test_should_create_and_drop_the_schema_on_sqlite(t *testing.T) {
+	client, err := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "auth.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	migrator, err := NewMigrator(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := client.Get(&count, `SELECT COUNT(*) FROM users`); err != nil {
+		t.Error("The users table should exist: ", err)
+	}
+	if _, err := migrator.Down(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Get(&count, `SELECT COUNT(*) FROM users`); err == nil {
+		t.Error("The users table should be dropped")
+	}
+}
@@ -0,0 +1,8 @@
+CREATE TABLE users (
+  username VARCHAR(20) NOT NULL,
+  password VARCHAR(20) NOT NULL,
+  role VARCHAR(20) NOT NULL,
+  customer_id INT NULL,
+  created_on DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (username)
+) ENGINE=InnoDB;
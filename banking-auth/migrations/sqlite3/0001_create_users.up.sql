@@ -0,0 +1,7 @@
+CREATE TABLE users (
+  username TEXT NOT NULL PRIMARY KEY,
+  password TEXT NOT NULL,
+  role TEXT NOT NULL,
+  customer_id INTEGER NULL,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -5,6 +5,8 @@ import (
 	"banking-auth/dto"
 	"errors"
 	"log"
+	"platform/roles"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
@@ -16,7 +18,9 @@ type AuthService interface {
 
 type DefaultAuthService struct {
 	repo            domain.AuthRepository
-	rolePermissions domain.RolePermissions
+	rolePermissions roles.Permissions
+	signingKey      []byte
+	tokenTTL        time.Duration
 }
 
 func (s DefaultAuthService) Login(req dto.LoginRequest) (*string, error) {
@@ -24,7 +28,7 @@ func (s DefaultAuthService) Login(req dto.LoginRequest) (*string, error) {
 	if err != nil {
 		return nil, err
 	}
-	token, err := login.GenerateToken()
+	token, err := login.GenerateToken(s.signingKey, s.tokenTTL)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +37,7 @@ func (s DefaultAuthService) Login(req dto.LoginRequest) (*string, error) {
 
 func (s DefaultAuthService) Verify(urlParams map[string]string) (bool, error) {
 	// convert the string token to JWT struct
-	if jwtToken, err := jwtTokenFromString(urlParams["token"]); err != nil {
+	if jwtToken, err := jwtTokenFromString(urlParams["token"], s.signingKey); err != nil {
 		return false, err
 	} else {
 		/*
@@ -65,10 +69,10 @@ func (s DefaultAuthService) Verify(urlParams map[string]string) (bool, error) {
 	}
 }
 
-func jwtTokenFromString(tokenString string) (*jwt.Token, error) {
+func jwtTokenFromString(tokenString string, signingKey []byte) (*jwt.Token, error) {
 	// decode the token with the signature key
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(domain.HMAC_SAMPLE_SECRET), nil
+		return signingKey, nil
 	})
 	if err != nil {
 		log.Println("Error while parsing token: " + err.Error())
@@ -77,6 +81,6 @@ func jwtTokenFromString(tokenString string) (*jwt.Token, error) {
 	return token, nil
 }
 
-func NewLoginService(repo domain.AuthRepository, permissions domain.RolePermissions) DefaultAuthService {
-	return DefaultAuthService{repo, permissions}
+func NewLoginService(repo domain.AuthRepository, permissions roles.Permissions, signingKey []byte, tokenTTL time.Duration) DefaultAuthService {
+	return DefaultAuthService{repo, permissions, signingKey, tokenTTL}
 }
@@ -1,9 +1,10 @@
 #!/bin/bash
 SERVER_ADDRESS=localhost \
-SERVER_PORT=8181 \
+SERVER_PORT=8081 \
 DB_USER=root \
-DB_PASSWD=codecamp \
-DB_ADDR=localhost \
+DB_PASSWORD=codecamp \
+DB_ADDRESS=localhost \
 DB_PORT=3306 \
 DB_NAME=banking \
+TOKEN_SIGNING_KEY=hmacSampleSecret \
 go run main.go
@@ -2,15 +2,19 @@ package app
 
 import (
 	"banking/dto"
+	"banking/errs"
+	"banking/money"
 	"banking/service"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 )
 
 type AccountHandler struct {
 	service service.AccountService
+	metrics Metrics
 }
 
 func (h AccountHandler) newAccount(w http.ResponseWriter, r *http.Request) {
@@ -49,21 +53,227 @@ func (h AccountHandler) MakeTransaction(w http.ResponseWriter, r *http.Request)
 	// decode incoming request
 	var request dto.TransactionRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.metrics.transactionRejected(request, &errs.AppError{Code: http.StatusBadRequest})
 		writeResponse(w, http.StatusBadRequest, err.Error())
 	} else {
 
 		//build the request object
 		request.AccountId = accountId
 		request.CustomerId = customerId
+		request.Role = tokenClaims(r).Role
 
 		// make transaction
 		account, appError := h.service.MakeTransaction(request)
 
 		if appError != nil {
+			h.metrics.transactionRejected(request, appError)
 			writeResponse(w, appError.Code, appError.AsMessage())
+		} else if account.IsPending() {
+			// held for review, accepted but not posted yet
+			writeResponse(w, http.StatusAccepted, account)
 		} else {
+			h.metrics.transactionPosted(*account)
 			writeResponse(w, http.StatusOK, account)
 		}
 	}
 
 }
+
+// /customers/2000/account/90720/transfer
+func (h AccountHandler) newTransfer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request dto.TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.metrics.transferRejected(&errs.AppError{Code: http.StatusBadRequest})
+		writeResponse(w, http.StatusBadRequest, err.Error())
+	} else {
+		request.FromAccountId = vars["account_id"]
+		request.CustomerId = vars["customer_id"]
+		request.Role = tokenClaims(r).Role
+
+		transfer, appError := h.service.MakeTransfer(request)
+		if appError != nil {
+			h.metrics.transferRejected(appError)
+			writeResponse(w, appError.Code, appError.AsMessage())
+		} else if transfer.IsPending() {
+			// held for review, accepted but not posted yet
+			writeResponse(w, http.StatusAccepted, transfer)
+		} else {
+			h.metrics.transferPosted(*transfer)
+			writeResponse(w, http.StatusCreated, transfer)
+		}
+	}
+}
+
+// /customers/2000/account/90720/transactions/512/reverse
+func (h AccountHandler) reverseTransaction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request dto.ReversalRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	request.AccountId = vars["account_id"]
+	request.CustomerId = vars["customer_id"]
+	request.TransactionId = vars["transaction_id"]
+	request.Actor = tokenClaims(r).Username
+
+	transaction, appError := h.service.ReverseTransaction(request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusCreated, transaction)
+	}
+}
+
+// /customers/2000/account/90720/statement?from=2021-01-01&to=2021-01-31&format=csv
+func (h AccountHandler) getStatement(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	format, ok := statementFormat(r)
+	if !ok {
+		writeResponse(w, http.StatusBadRequest, "format should be csv, ofx or json")
+		return
+	}
+	request := dto.StatementRequest{
+		AccountId:  vars["account_id"],
+		CustomerId: vars["customer_id"],
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+	}
+	statement, appError := h.service.GetStatement(request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+		return
+	}
+	switch format {
+	case statementFormatCSV:
+		writeStatementCSV(w, *statement)
+	case statementFormatOFX:
+		writeStatementOFX(w, *statement)
+	default:
+		writeResponse(w, http.StatusOK, statement)
+	}
+}
+
+// /customers/2000/account/90720/transactions?from=2021-01-01&to=2021-01-31&type=deposit
+func (h AccountHandler) getTransactions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	request := dto.TransactionHistoryRequest{
+		AccountId:       vars["account_id"],
+		CustomerId:      vars["customer_id"],
+		From:            query.Get("from"),
+		To:              query.Get("to"),
+		TransactionType: query.Get("type"),
+		Cursor:          query.Get("cursor"),
+	}
+
+	// numeric filters are optional, but must be numbers when present
+	var err error
+	if request.MinAmount, err = parseOptionalAmount(query.Get("min_amount")); err != nil {
+		writeResponse(w, http.StatusBadRequest, "min_amount should be a number with at most two decimal places")
+		return
+	}
+	if request.MaxAmount, err = parseOptionalAmount(query.Get("max_amount")); err != nil {
+		writeResponse(w, http.StatusBadRequest, "max_amount should be a number with at most two decimal places")
+		return
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if request.Limit, err = strconv.Atoi(limit); err != nil {
+			writeResponse(w, http.StatusBadRequest, "limit should be a number")
+			return
+		}
+	}
+
+	transactions, appError := h.service.GetTransactions(request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, transactions)
+	}
+}
+
+// parseOptionalAmount : Returns nil for an empty query value
+func parseOptionalAmount(value string) (*money.Amount, error) {
+	if value == "" {
+		return nil, nil
+	}
+	a, err := money.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// /customers/2000/account/90720
+func (h AccountHandler) getAccount(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	account, appError := h.service.GetAccount(vars["account_id"], vars["customer_id"])
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, account)
+	}
+}
+
+// /customers/2000/account/90720/overdraft
+func (h AccountHandler) setOverdraftLimit(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request dto.OverdraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	request.AccountId = vars["account_id"]
+	request.CustomerId = vars["customer_id"]
+
+	account, appError := h.service.SetOverdraftLimit(request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, account)
+	}
+}
+
+// /customers/2000/account/90720/freeze
+func (h AccountHandler) freezeAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.FreezeAccount)
+}
+
+// /customers/2000/account/90720/unfreeze
+func (h AccountHandler) unfreezeAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.UnfreezeAccount)
+}
+
+// /customers/2000/account/90720/close
+func (h AccountHandler) closeAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.CloseAccount)
+}
+
+func (h AccountHandler) changeStatus(w http.ResponseWriter, r *http.Request,
+	change func(dto.AccountStatusRequest) (*dto.AccountResponse, *errs.AppError)) {
+	vars := mux.Vars(r)
+
+	var request dto.AccountStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	request.AccountId = vars["account_id"]
+	request.CustomerId = vars["customer_id"]
+	// the actor recorded in the audit trail is the user the token was issued to
+	request.Actor = tokenClaims(r).Username
+
+	account, appError := change(request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, account)
+	}
+}
@@ -2,53 +2,124 @@ package app
 
 import (
 	"banking/domain"
+	"banking/logger"
 	"banking/service"
-	"fmt"
+	"config"
+	"context"
+	"flag"
 	"log"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
-	"github.com/joho/godotenv"
 )
 
-func checkVariables() {
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbAddress := os.Getenv("DB_ADDRESS")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
-	if dbUser == "" ||
-		dbPassword == "" ||
-		dbAddress == "" ||
-		dbPort == "" ||
-		dbName == "" {
-		log.Fatal("Envoriment variables not defined...")
+// Outbox relay runs when they are not configured, an event failing every run is dead lettered after about 10 minutes
+const (
+	defaultOutboxInterval    = 5 * time.Second
+	defaultOutboxMaxAttempts = 120
+)
+
+// Webhook retries when they are not configured: 30s, 1m, 2m... then dead lettered
+const (
+	defaultWebhookBackoff     = 30 * time.Second
+	defaultWebhookMaxAttempts = 8
+	webhookTimeout            = 10 * time.Second
+)
+
+// defaultIdempotencyTTL : How long a stored Idempotency-Key can be replayed when the TTL is not configured
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// Start : Runs the API server and its background jobs, args are the command line flags
+func Start(args []string) {
+	cfg, _ := loadConfig(flag.NewFlagSet("banking", flag.ExitOnError), args)
+
+	// wiring
+	repos := getRepositories(cfg)
+	metrics := NewMetrics()
+	if repos.db != nil {
+		metrics.RegisterDB(repos.db, cfg.DB.Name)
+	}
+	router := newRouter(repos, getProducts(cfg.ProductsFile), getFraudRules(cfg.FraudRulesFile), cfg.IdempotencyTTL,
+		getHealthChecks(cfg, repos), metrics)
+	ctx, stopWorkers := context.WithCancel(context.Background())
+	workers := startWorkers(ctx, cfg, repos)
+
+	server := &http.Server{Addr: cfg.Server.ListenAddress(), Handler: router}
+	logger.Info("Listening on " + server.Addr)
+	if err := serve(server, cfg.Server.ShutdownTimeout); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	// the workers use the pool until their current run is done
+	stopWorkers()
+	workers.Wait()
+	if repos.db != nil {
+		repos.db.Close()
 	}
+	logger.Info("Server stopped")
 }
 
-func Start() {
-	// Load env variables
-	err := godotenv.Load()
-	if err != nil {
-		panic("Error loading .env file")
+// getHealthChecks : The dependencies /readyz checks, the in-memory storage has no database
+func getHealthChecks(cfg Config, repos repositories) []healthCheck {
+	checks := make([]healthCheck, 0)
+	if repos.db != nil {
+		checks = append(checks, databaseCheck(repos.db))
 	}
+	return append(checks, authServerCheck(&http.Client{Timeout: readinessTimeout}, cfg.AuthURL))
+}
 
-	checkVariables()
+// startWorkers : Background jobs of the server, they run until the context is cancelled and the wait group is done
+// once all of them have returned
+func startWorkers(ctx context.Context, cfg Config, repos repositories) *sync.WaitGroup {
+	publisher := domain.MultiPublisher{getEventPublisher(cfg.EventsFile), service.NewWebhookPublisher(repos.accounts, repos.webhooks)}
+	relay := service.NewOutboxRelay(repos.outbox, publisher, cfg.OutboxMaxAttempts)
+	dispatcher := service.NewWebhookDispatcher(repos.webhooks, service.NewWebhookClient(webhookTimeout), cfg.WebhookBackoff,
+		cfg.WebhookMaxAttempts)
+	workers := []func(){
+		func() { IdempotencyMiddleware{repos.idempotency, 0}.RunCleanup(ctx, time.Hour) },
+		func() { relay.Run(ctx, cfg.OutboxInterval) },
+		func() { dispatcher.Run(ctx, cfg.OutboxInterval) },
+	}
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func(run func()) {
+			defer wg.Done()
+			run()
+		}(worker)
+	}
+	return &wg
+}
 
+// newRouter : Wires the services and handlers on the repositories and registers the named routes
+func newRouter(repos repositories, products domain.Products, rules domain.FraudRules, idempotencyTTL time.Duration,
+	checks []healthCheck, metrics Metrics) *mux.Router {
 	// Create a new gorilla multiplexer
 	router := mux.NewRouter()
 
-	// wiring
-	dbClient := getDBClient()
+	fraudService := service.NewFraudService(repos.accounts, repos.pending, rules, products)
 
-	customerRepositoryDB := domain.NewCustomerRepositoryDb(dbClient)
-	accountRepositoryDB := domain.NewAccountRepositoryDB(dbClient)
+	ch := CustomerHandlers{service.NewCustomerService(repos.customers)}
+	ah := AccountHandler{service.NewAccountService(repos.accounts, repos.fxRates, products, fraudService), metrics}
+	frh := FraudHandler{fraudService, metrics}
+	fh := FxRateHandler{service.NewFxRateService(repos.fxRates)}
+	lh := LedgerHandler{service.NewLedgerService(repos.ledger)}
+	im := IdempotencyMiddleware{repos.idempotency, idempotencyTTL}
+	wh := WebhookHandler{service.NewWebhookService(repos.webhooks)}
+	hh := HealthHandler{checks}
 
-	ch := CustomerHandlers{service.NewCustomerService(customerRepositoryDB)}
-	ah := AccountHandler{service.NewAccountService(accountRepositoryDB)}
+	router.HandleFunc("/healthz", hh.healthz).
+		Methods(http.MethodGet).
+		Name(routeHealthz)
+	router.HandleFunc("/readyz", hh.readyz).
+		Methods(http.MethodGet).
+		Name(routeReadyz)
+	router.Handle("/metrics", metrics.Handler()).
+		Methods(http.MethodGet).
+		Name(routeMetrics)
 
 	router.HandleFunc("/customers", ch.getAllCustomers).
 		Methods(http.MethodGet).
@@ -57,40 +128,163 @@ func Start() {
 		HandleFunc("/customers/{customer_id:[0-9]+}", ch.getCustomer).
 		Methods(http.MethodGet).
 		Name("GetCustomer")
+	router.HandleFunc("/customers", ch.newCustomer).
+		Methods(http.MethodPost).
+		Name("NewCustomer")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}", ch.updateCustomer).
+		Methods(http.MethodPut).
+		Name("UpdateCustomer")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}", ch.patchCustomer).
+		Methods(http.MethodPatch).
+		Name("PatchCustomer")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/deactivate", ch.deactivateCustomer).
+		Methods(http.MethodPost).
+		Name("DeactivateCustomer")
 	router.
-		HandleFunc("/customers/{customer_id:[0-9]+}/account", ah.newAccount).
+		HandleFunc("/customers/{customer_id:[0-9]+}/account", im.handler(ah.newAccount)).
 		Methods(http.MethodPost).
 		Name("NewAccount")
 	router.
-		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}", ah.MakeTransaction).
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}", im.handler(ah.MakeTransaction)).
 		Methods(http.MethodPost).
 		Name("NewTransaction")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}", ah.getAccount).
+		Methods(http.MethodGet).
+		Name("GetAccount")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/overdraft", ah.setOverdraftLimit).
+		Methods(http.MethodPut).
+		Name("SetOverdraftLimit")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/freeze", ah.freezeAccount).
+		Methods(http.MethodPost).
+		Name("FreezeAccount")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/unfreeze", ah.unfreezeAccount).
+		Methods(http.MethodPost).
+		Name("UnfreezeAccount")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/close", ah.closeAccount).
+		Methods(http.MethodPost).
+		Name("CloseAccount")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions", ah.getTransactions).
+		Methods(http.MethodGet).
+		Name("GetTransactions")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions/{transaction_id:[0-9]+}/reverse",
+			im.handler(ah.reverseTransaction)).
+		Methods(http.MethodPost).
+		Name("ReverseTransaction")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/statement", ah.getStatement).
+		Methods(http.MethodGet).
+		Name("GetStatement")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfer", im.handler(ah.newTransfer)).
+		Methods(http.MethodPost).
+		Name("NewTransfer")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/webhooks", wh.newWebhook).
+		Methods(http.MethodPost).
+		Name("NewWebhook")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/webhooks", wh.getWebhooks).
+		Methods(http.MethodGet).
+		Name("GetWebhooks")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/webhooks/{webhook_id:[0-9]+}", wh.deleteWebhook).
+		Methods(http.MethodDelete).
+		Name("DeleteWebhook")
+	router.
+		HandleFunc("/customers/{customer_id:[0-9]+}/webhooks/{webhook_id:[0-9]+}/deliveries", wh.getDeliveries).
+		Methods(http.MethodGet).
+		Name("GetWebhookDeliveries")
+	router.
+		HandleFunc("/fx-rates", fh.loadRates).
+		Methods(http.MethodPost).
+		Name("LoadFxRates")
+	router.
+		HandleFunc("/fx-rates", fh.getRates).
+		Methods(http.MethodGet).
+		Name("GetFxRates")
+	router.
+		HandleFunc("/pending-transactions", frh.getPendingTransactions).
+		Methods(http.MethodGet).
+		Name("GetPendingTransactions")
+	router.
+		HandleFunc("/pending-transactions/{pending_id:[0-9]+}/approve", frh.approvePendingTransaction).
+		Methods(http.MethodPost).
+		Name("ApprovePendingTransaction")
+	router.
+		HandleFunc("/pending-transactions/{pending_id:[0-9]+}/reject", frh.rejectPendingTransaction).
+		Methods(http.MethodPost).
+		Name("RejectPendingTransaction")
+	router.
+		HandleFunc("/ledger/trial-balance", lh.getTrialBalance).
+		Methods(http.MethodGet).
+		Name("GetTrialBalance")
 
-	// middleware
-	am := AuthMiddleware{domain.NewAuthRepository()}
-	router.Use(am.authorizationHandler())
+	// middleware, the metrics come first so the requests the auth server refuses are counted too
+	am := AuthMiddleware{repos.auth}
+	router.Use(metrics.Middleware, am.authorizationHandler())
+	return router
+}
 
-	// Listen on port 8000, we can pass a router as a seccond param but we are using the default one
-	log.Fatal(http.ListenAndServe("localhost:8000", router))
+// getEventPublisher : Appends the events to the file, or writes them to stdout when there is none
+func getEventPublisher(path string) domain.EventPublisher {
+	if path == "" {
+		return domain.NewWriterPublisher(os.Stdout)
+	}
+	publisher, err := domain.NewFilePublisher(path)
+	if err != nil {
+		log.Fatal("Error while opening events file " + path + ": " + err.Error())
+	}
+	return publisher
 }
 
-func getDBClient() *sqlx.DB {
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbAddress := os.Getenv("DB_ADDRESS")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
+// getProducts : Reads the account products from the file, or the defaults when there is none
+func getProducts(path string) domain.Products {
+	if path == "" {
+		return domain.DefaultProducts()
+	}
+	products, err := domain.LoadProducts(path)
+	if err != nil {
+		log.Fatal("Error while loading products from " + path + ": " + err.Error())
+	}
+	return products
+}
 
-	dataSource := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbAddress, dbPort, dbName)
+// getFraudRules : Reads the fraud screening rules from the file, or the defaults when there is none
+func getFraudRules(path string) domain.FraudRules {
+	if path == "" {
+		return domain.DefaultFraudRules()
+	}
+	rules, err := domain.LoadFraudRules(path)
+	if err != nil {
+		log.Fatal("Error while loading fraud rules from " + path + ": " + err.Error())
+	}
+	return rules
+}
 
+// getDBClient : Opens the configured database with its pool settings
+func getDBClient(db config.DB) *sqlx.DB {
 	// Create a database client
-	client, err := sqlx.Open("mysql", dataSource)
+	client, err := sqlx.Open(db.DriverName(), db.DataSource())
 	if err != nil {
 		panic(err)
 	}
 	// See "Important settings" section.
-	client.SetConnMaxLifetime(time.Minute * 3)
-	client.SetMaxOpenConns(10)
-	client.SetMaxIdleConns(10)
+	client.SetConnMaxLifetime(db.ConnMaxLifetime)
+	client.SetMaxOpenConns(db.MaxOpenConns)
+	client.SetMaxIdleConns(db.MaxIdleConns)
+	if db.DriverName() == config.DriverSQLite {
+		// SQLite has a single writer, one connection keeps the transactions from failing with "database is locked"
+		client.SetMaxOpenConns(1)
+	}
 	return client
 }
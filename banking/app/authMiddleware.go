@@ -3,12 +3,24 @@ package app
 import (
 	"banking/domain"
 	"banking/errs"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/mux"
 )
 
+// Routes the load balancer, orchestrator and Prometheus call without a token
+const (
+	routeHealthz = "Healthz"
+	routeReadyz  = "Readyz"
+	routeMetrics = "Metrics"
+)
+
+// publicRoutes : Served without asking the auth server
+var publicRoutes = map[string]bool{routeHealthz: true, routeReadyz: true, routeMetrics: true}
+
 type AuthMiddleware struct {
 	repo domain.AuthRepository
 }
@@ -18,6 +30,10 @@ func (a AuthMiddleware) authorizationHandler() func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Get the current route, this route is named in the router config
 			currentRoute := mux.CurrentRoute(r)
+			if publicRoutes[currentRoute.GetName()] {
+				next.ServeHTTP(w, r)
+				return
+			}
 			// Get all the vars
 			currentRouteVars := mux.Vars(r)
 			// Get the auth bearer auth header
@@ -32,7 +48,7 @@ func (a AuthMiddleware) authorizationHandler() func(http.Handler) http.Handler {
 					// pass the writer and request to the next middleware or router
 					next.ServeHTTP(w, r)
 				} else {
-					appError := errs.AppError{http.StatusForbidden, "Unauthorized"}
+					appError := errs.AppError{Code: http.StatusForbidden, Message: "Unauthorized"}
 					writeResponse(w, appError.Code, appError.AsMessage())
 				}
 			} else {
@@ -54,3 +70,25 @@ func getTokenFromHeader(header string) string {
 	}
 	return ""
 }
+
+// TokenClaims : The part of the token payload the handlers need, the signature is checked by the auth server
+type TokenClaims struct {
+	Username   string `json:"username"`
+	CustomerId string `json:"customer_id"`
+	Role       string `json:"role"`
+}
+
+// tokenClaims : Decodes the payload of the bearer token, empty claims when it cannot be read
+func tokenClaims(r *http.Request) TokenClaims {
+	var claims TokenClaims
+	parts := strings.Split(getTokenFromHeader(r.Header.Get("Authorization")), ".")
+	if len(parts) != 3 {
+		return claims
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims
+	}
+	_ = json.Unmarshal(payload, &claims)
+	return claims
+}
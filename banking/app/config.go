@@ -0,0 +1,85 @@
+package app
+
+import (
+	"config"
+	"flag"
+	"log"
+	"net/url"
+	"time"
+)
+
+// Config : Every setting of the banking service and its batch commands, see the config package for the layering
+type Config struct {
+	Server             config.Server `yaml:"server"`
+	DB                 config.DB     `yaml:"db"`
+	Storage            string        `yaml:"storage" env:"STORAGE" flag:"storage" usage:"database or memory"`
+	AuthURL            string        `yaml:"auth_url" env:"AUTH_URL" flag:"auth-url" usage:"base URL of the banking-auth server"`
+	IdempotencyTTL     time.Duration `yaml:"idempotency_ttl" env:"IDEMPOTENCY_TTL" flag:"idempotency-ttl" usage:"how long a stored Idempotency-Key can be replayed"`
+	ProductsFile       string        `yaml:"products_file" env:"PRODUCTS_FILE" flag:"products-file" usage:"JSON file of the account products, the defaults when empty"`
+	FraudRulesFile     string        `yaml:"fraud_rules_file" env:"FRAUD_RULES_FILE" flag:"fraud-rules-file" usage:"JSON file of the fraud screening rules, the defaults when empty"`
+	EventsFile         string        `yaml:"events_file" env:"EVENTS_FILE" flag:"events-file" usage:"file the domain events are appended to, stdout when empty"`
+	OutboxInterval     time.Duration `yaml:"outbox_interval" env:"OUTBOX_INTERVAL" flag:"outbox-interval" usage:"how often the outbox relay and webhook dispatcher run"`
+	OutboxMaxAttempts  int           `yaml:"outbox_max_attempts" env:"OUTBOX_MAX_ATTEMPTS" flag:"outbox-max-attempts" usage:"attempts before an outbox event is dead lettered"`
+	WebhookBackoff     time.Duration `yaml:"webhook_backoff" env:"WEBHOOK_BACKOFF" flag:"webhook-backoff" usage:"wait before the first retry of a failed webhook delivery"`
+	WebhookMaxAttempts int           `yaml:"webhook_max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" flag:"webhook-max-attempts" usage:"attempts before a webhook delivery is dead lettered"`
+}
+
+// defaultConfig : What the service runs with when nothing is configured
+func defaultConfig() Config {
+	return Config{
+		Server:             config.Server{Address: "localhost", Port: "8000", ShutdownTimeout: 30 * time.Second},
+		DB:                 config.DefaultDB(),
+		Storage:            storageDatabase,
+		AuthURL:            "http://localhost:8081",
+		IdempotencyTTL:     defaultIdempotencyTTL,
+		OutboxInterval:     defaultOutboxInterval,
+		OutboxMaxAttempts:  defaultOutboxMaxAttempts,
+		WebhookBackoff:     defaultWebhookBackoff,
+		WebhookMaxAttempts: defaultWebhookMaxAttempts,
+	}
+}
+
+// usesDatabase : STORAGE selects the database, "mysql" is still accepted from before there was a choice
+func (c Config) usesDatabase() bool {
+	return c.Storage == storageDatabase || c.Storage == storageMySQL
+}
+
+// Validate : Records every invalid setting, the database is only checked when the storage uses it
+func (c Config) Validate(p *config.Problems) {
+	c.Server.Validate(p)
+	switch {
+	case c.usesDatabase():
+		c.DB.Validate(p)
+	case c.Storage != storageMemory:
+		p.Add("storage (STORAGE) should be %s or %s, not %q", storageDatabase, storageMemory, c.Storage)
+	}
+	if u, err := url.Parse(c.AuthURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		p.Add("auth_url (AUTH_URL) should be an http or https URL like http://localhost:8081, not %q", c.AuthURL)
+	}
+	if c.IdempotencyTTL <= 0 {
+		p.Add("idempotency_ttl (IDEMPOTENCY_TTL) should be a positive duration like 24h")
+	}
+	if c.OutboxInterval <= 0 {
+		p.Add("outbox_interval (OUTBOX_INTERVAL) should be a positive duration like 5s")
+	}
+	if c.OutboxMaxAttempts <= 0 {
+		p.Add("outbox_max_attempts (OUTBOX_MAX_ATTEMPTS) should be a positive number")
+	}
+	if c.WebhookBackoff <= 0 {
+		p.Add("webhook_backoff (WEBHOOK_BACKOFF) should be a positive duration like 30s")
+	}
+	if c.WebhookMaxAttempts <= 0 {
+		p.Add("webhook_max_attempts (WEBHOOK_MAX_ATTEMPTS) should be a positive number")
+	}
+}
+
+// loadConfig : The config of the command and its positional arguments, see config.Load. The process stops when
+// the config is invalid.
+func loadConfig(flags *flag.FlagSet, args []string) (Config, []string) {
+	cfg := defaultConfig()
+	positional, err := config.Load(&cfg, flags, args)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	return cfg, positional
+}
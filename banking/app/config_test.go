@@ -0,0 +1,36 @@
+package app
+
+import (
+	"config"
+	"strings"
+	"testing"
+)
+
+func Test_should_accept_the_default_config_with_memory_storage(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Storage = storageMemory
+	var problems config.Problems
+
+	cfg.Validate(&problems)
+
+	if problems.Err() != nil {
+		t.Error("The database settings should not be needed: ", problems)
+	}
+}
+
+func Test_should_name_every_invalid_setting(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Server.Port = "80000"
+	cfg.AuthURL = "localhost:8081"
+	cfg.WebhookMaxAttempts = 0
+	var problems config.Problems
+
+	cfg.Validate(&problems)
+
+	message := problems.Err().Error()
+	for _, expected := range []string{"SERVER_PORT", "AUTH_URL", "WEBHOOK_MAX_ATTEMPTS", "db.user (DB_USER) is required for mysql"} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("%q should be reported in %q", expected, message)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package app
 
 import (
+	"banking/dto"
 	"banking/service"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 )
@@ -12,9 +14,29 @@ type CustomerHandlers struct {
 	service service.CustomerService
 }
 
+// /customers?name=joestar&city=england&born_from=1900-01-01&sort=date_of_birth&order=desc&limit=20
 func (ch *CustomerHandlers) getAllCustomers(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query().Get("status")
-	customers, err := ch.service.GetAllCustomer(query)
+	query := r.URL.Query()
+	request := dto.CustomerSearchRequest{
+		Status:   query.Get("status"),
+		Name:     query.Get("name"),
+		City:     query.Get("city"),
+		Zipcode:  query.Get("zipcode"),
+		BornFrom: query.Get("born_from"),
+		BornTo:   query.Get("born_to"),
+		Sort:     query.Get("sort"),
+		Order:    query.Get("order"),
+		Cursor:   query.Get("cursor"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		if request.Limit, err = strconv.Atoi(limit); err != nil {
+			writeResponse(w, http.StatusBadRequest, "limit should be a number")
+			return
+		}
+	}
+
+	customers, err := ch.service.GetAllCustomer(request)
 	if err != nil {
 		writeResponse(w, err.Code, err.AsMessage())
 	} else {
@@ -35,6 +57,61 @@ func (ch *CustomerHandlers) getCustomer(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// POST /customers
+func (ch *CustomerHandlers) newCustomer(w http.ResponseWriter, r *http.Request) {
+	var request dto.CustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	customer, appError := ch.service.NewCustomer(request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusCreated, customer)
+	}
+}
+
+// PUT /customers/2000
+func (ch *CustomerHandlers) updateCustomer(w http.ResponseWriter, r *http.Request) {
+	var request dto.CustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	customer, appError := ch.service.UpdateCustomer(mux.Vars(r)["customer_id"], request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, customer)
+	}
+}
+
+// PATCH /customers/2000
+func (ch *CustomerHandlers) patchCustomer(w http.ResponseWriter, r *http.Request) {
+	var request dto.CustomerPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	customer, appError := ch.service.PatchCustomer(mux.Vars(r)["customer_id"], request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, customer)
+	}
+}
+
+// POST /customers/2000/deactivate
+func (ch *CustomerHandlers) deactivateCustomer(w http.ResponseWriter, r *http.Request) {
+	customer, appError := ch.service.DeactivateCustomer(mux.Vars(r)["customer_id"])
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, customer)
+	}
+}
+
 // writeResponse : Function for writting a json response
 func writeResponse(w http.ResponseWriter, code int, data interface{}) {
 	w.Header().Add("Content-Type", "application/json")
@@ -6,6 +6,7 @@ import (
 	"banking/mocks/service"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -21,7 +22,8 @@ func setup(t *testing.T) func() {
 	mockService = service.NewMockCustomerService(ctrl)
 	ch = CustomerHandlers{mockService}
 	router = mux.NewRouter()
-	router.HandleFunc("/customers", ch.getAllCustomers)
+	router.HandleFunc("/customers", ch.getAllCustomers).Methods(http.MethodGet)
+	router.HandleFunc("/customers", ch.newCustomer).Methods(http.MethodPost)
 
 	return func() {
 		router = nil
@@ -35,13 +37,13 @@ func Test_should_return_customer_with_status_code_200(t *testing.T) {
 	defer teardown()
 
 	// Insert the getAllCustomers method into the mock implementation
-	dummyCustomers := []dto.CustomerResponse{
+	dummyCustomers := &dto.CustomerSearchResponse{Customers: []dto.CustomerResponse{
 		{ID: "1001", Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205", DateofBirth: "1970-01-01", Status: "1"},
 		{ID: "1002", Name: "Jonathan Joestar", City: "England", Zipcode: "95457", DateofBirth: "1868-04-04", Status: "0"},
 		{ID: "1003", Name: "Joseph Joestar", City: "England", Zipcode: "95825", DateofBirth: "1920-09-27", Status: "1"},
-	}
+	}}
 	// Return the dummy list of customers when the GetAllCustomers method is called
-	mockService.EXPECT().GetAllCustomer("").Return(dummyCustomers, nil)
+	mockService.EXPECT().GetAllCustomer(dto.CustomerSearchRequest{}).Return(dummyCustomers, nil)
 	// Prepare a query
 	request, _ := http.NewRequest(http.MethodGet, "/customers", nil)
 
@@ -60,7 +62,7 @@ func Test_shold_return_status_code_500_with_error_message(t *testing.T) {
 	defer teardown()
 
 	// Return the dummy list of customers when the GetAllCustomers method is called
-	mockService.EXPECT().GetAllCustomer("").Return(nil, errs.NewUnexpectedError("test database error"))
+	mockService.EXPECT().GetAllCustomer(dto.CustomerSearchRequest{}).Return(nil, errs.NewUnexpectedError("test database error"))
 	// Prepare a query
 	request, _ := http.NewRequest(http.MethodGet, "/customers", nil)
 
@@ -73,3 +75,24 @@ func Test_shold_return_status_code_500_with_error_message(t *testing.T) {
 		t.Error("Failed while testing the status code.")
 	}
 }
+
+func Test_should_return_status_code_201_when_a_customer_is_created(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	newCustomer := dto.CustomerRequest{Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205", DateofBirth: "1970-01-01"}
+	created := dto.CustomerResponse{ID: "1004", Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205", DateofBirth: "1970-01-01", Status: "Active"}
+	mockService.EXPECT().NewCustomer(newCustomer).Return(&created, nil)
+	body := `{"full_name": "Jotaro Kujo", "city": "Okinawa", "zipcode": "30205", "date_of_birth": "1970-01-01"}`
+	request, _ := http.NewRequest(http.MethodPost, "/customers", strings.NewReader(body))
+
+	// Act
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	if recorder.Code != http.StatusCreated {
+		t.Error("Failed while testing the status code.")
+	}
+}
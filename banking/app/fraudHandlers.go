@@ -0,0 +1,47 @@
+package app
+
+import (
+	"banking/service"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type FraudHandler struct {
+	service service.FraudService
+	metrics Metrics
+}
+
+// /pending-transactions
+func (h FraudHandler) getPendingTransactions(w http.ResponseWriter, r *http.Request) {
+	pending, appError := h.service.GetPendingTransactions()
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, pending)
+	}
+}
+
+// /pending-transactions/12/approve
+func (h FraudHandler) approvePendingTransaction(w http.ResponseWriter, r *http.Request) {
+	transaction, appError := h.service.ApprovePendingTransaction(mux.Vars(r)["pending_id"], tokenClaims(r).Username)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		// the approval posts the held transaction, for a transfer the withdrawal leg is the one returned
+		h.metrics.pendingDecided("approved")
+		h.metrics.transactionPosted(*transaction)
+		writeResponse(w, http.StatusOK, transaction)
+	}
+}
+
+// /pending-transactions/12/reject
+func (h FraudHandler) rejectPendingTransaction(w http.ResponseWriter, r *http.Request) {
+	pending, appError := h.service.RejectPendingTransaction(mux.Vars(r)["pending_id"], tokenClaims(r).Username)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		h.metrics.pendingDecided("rejected")
+		writeResponse(w, http.StatusOK, pending)
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"banking/dto"
+	"banking/service"
+	"encoding/json"
+	"net/http"
+)
+
+type FxRateHandler struct {
+	service service.FxRateService
+}
+
+// /fx-rates with a list of rates in the body
+func (h FxRateHandler) loadRates(w http.ResponseWriter, r *http.Request) {
+	var request []dto.FxRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+	} else {
+		rates, appError := h.service.LoadRates(request)
+		if appError != nil {
+			writeResponse(w, appError.Code, appError.AsMessage())
+		} else {
+			writeResponse(w, http.StatusCreated, rates)
+		}
+	}
+}
+
+// /fx-rates?base=USD&quote=EUR
+func (h FxRateHandler) getRates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	rates, appError := h.service.GetRates(query.Get("base"), query.Get("quote"))
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, rates)
+	}
+}
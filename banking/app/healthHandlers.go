@@ -0,0 +1,76 @@
+package app
+
+import (
+	"banking/dto"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// readinessTimeout : How long a readiness check may take before the dependency counts as down
+const readinessTimeout = 2 * time.Second
+
+// healthCheck : A dependency the service cannot serve requests without
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// HealthHandler : Liveness and readiness probes, they bypass the auth middleware
+type HealthHandler struct {
+	checks []healthCheck
+}
+
+// healthz : The process is up and serving HTTP
+func (h HealthHandler) healthz(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, dto.HealthResponse{Status: "ok"})
+}
+
+// readyz : Every dependency answers, the load balancer should not send traffic otherwise
+func (h HealthHandler) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	response := dto.HealthResponse{Status: "ready", Checks: make(map[string]string)}
+	code := http.StatusOK
+	for _, c := range h.checks {
+		if err := c.check(ctx); err != nil {
+			response.Checks[c.name] = err.Error()
+			response.Status = "not ready"
+			code = http.StatusServiceUnavailable
+		} else {
+			response.Checks[c.name] = "ok"
+		}
+	}
+	writeResponse(w, code, response)
+}
+
+// databaseCheck : Pings the pool
+func databaseCheck(client *sqlx.DB) healthCheck {
+	return healthCheck{"database", client.PingContext}
+}
+
+// authServerCheck : banking-auth answers its own liveness probe
+func authServerCheck(client *http.Client, authURL string) healthCheck {
+	u := strings.TrimSuffix(authURL, "/") + "/healthz"
+	return healthCheck{"auth", func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return errors.New("auth server answered " + strconv.Itoa(resp.StatusCode))
+		}
+		return nil
+	}}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_should_answer_the_probes_without_a_token(t *testing.T) {
+	server, _ := newMemoryServer(t)
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("%s status = %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func Test_should_not_be_ready_when_a_dependency_is_down(t *testing.T) {
+	repos, _ := newMemoryRepositories(domain.NewSeededMemoryStore())
+	checks := []healthCheck{
+		{"database", func(ctx context.Context) error { return nil }},
+		{"auth", func(ctx context.Context) error { return errors.New("connection refused") }},
+	}
+	server := httptest.NewServer(newRouter(repos, domain.DefaultProducts(), domain.FraudRules{}, time.Hour, checks, NewMetrics()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/readyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body dto.HealthResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Error("Invalid status: ", resp.StatusCode)
+	}
+	if body.Checks["database"] != "ok" || body.Checks["auth"] != "connection refused" {
+		t.Error("Invalid checks: ", body.Checks)
+	}
+}
+
+func Test_should_check_the_auth_server_liveness_probe(t *testing.T) {
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer auth.Close()
+
+	if err := authServerCheck(auth.Client(), auth.URL+"/").check(context.Background()); err != nil {
+		t.Error("The auth server should be reachable: ", err)
+	}
+	if err := authServerCheck(auth.Client(), auth.URL+"/missing").check(context.Background()); err == nil {
+		t.Error("A 404 should fail the check")
+	}
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"banking/domain"
+	"banking/errs"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const idempotencyHeader = "Idempotency-Key"
+const idempotencyTSLayout = "2006-01-02 15:04:05"
+
+// maxIdempotencyKeyLength : Longest key a client may send, the key is stored scoped by the username in a VARCHAR(255)
+const maxIdempotencyKeyLength = 200
+
+// IdempotencyMiddleware : Replays the stored response when a client retries a request with the same Idempotency-Key.
+// Keys are stored per caller, two callers picking the same key never see each other's requests.
+type IdempotencyMiddleware struct {
+	repo domain.IdempotencyRepository
+	ttl  time.Duration
+}
+
+func (m IdempotencyMiddleware) handler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyHeader)
+		// the header is optional, requests without it are processed as usual
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeResponse(w, http.StatusBadRequest, "Idempotency-Key should be at most "+strconv.Itoa(maxIdempotencyKeyLength)+" characters")
+			return
+		}
+
+		// the body has to be read to fingerprint it, so it is put back for the handler
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		// the auth middleware ran before, so the claims are the ones the auth server verified
+		key = scopedIdempotencyKey(tokenClaims(r), key)
+		now := time.Now()
+		record := domain.IdempotencyRecord{
+			Key:         key,
+			Fingerprint: requestFingerprint(r, body),
+			CreatedAt:   now.Format(idempotencyTSLayout),
+			ExpiresAt:   now.Add(m.ttl).Format(idempotencyTSLayout),
+		}
+		existing, appError := m.repo.Reserve(record, record.CreatedAt)
+		if appError != nil {
+			writeResponse(w, appError.Code, appError.AsMessage())
+			return
+		}
+		if existing != nil {
+			m.replay(w, *existing, record.Fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(recorder, r)
+
+		// server errors are not stored, the client should be able to retry them
+		if recorder.code >= http.StatusInternalServerError {
+			m.repo.Release(key)
+		} else {
+			m.repo.Complete(key, recorder.code, recorder.body.Bytes())
+		}
+	}
+}
+
+func (m IdempotencyMiddleware) replay(w http.ResponseWriter, existing domain.IdempotencyRecord, fingerprint string) {
+	if existing.Fingerprint != fingerprint {
+		appError := errs.NewValidationError("Idempotency-Key was already used with a different request")
+		writeResponse(w, appError.Code, appError.AsMessage())
+		return
+	}
+	if !existing.IsCompleted() {
+		writeResponse(w, http.StatusConflict, errs.AppError{Message: "A request with this Idempotency-Key is still being processed"})
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Idempotent-Replayed", "true")
+	w.WriteHeader(existing.StatusCode)
+	w.Write(existing.Response)
+}
+
+// RunCleanup : Deletes expired keys every interval until the context is cancelled
+func (m IdempotencyMiddleware) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.repo.DeleteExpired(time.Now().Format(idempotencyTSLayout))
+		}
+	}
+}
+
+// scopedIdempotencyKey : The stored key, the Idempotency-Key of the client prefixed with who sent it. The username
+// is quoted so no username and key can be put together to look like another pair.
+func scopedIdempotencyKey(claims TokenClaims, key string) string {
+	return strconv.Quote(claims.Username) + " " + key
+}
+
+// requestFingerprint : Hash of the method, path and body, a retry has to match it to be replayed
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder : Writes through to the client while keeping a copy of the status and body
+type responseRecorder struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	realdomain "banking/domain"
+	"banking/mocks/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newIdempotentRequest(body string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, "/customers/2000/account/95470", strings.NewReader(body))
+	request.Header.Set(idempotencyHeader, "retry-1")
+	return request
+}
+
+func Test_should_replay_the_stored_response_when_the_key_is_reused(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockIdempotencyRepository(ctrl)
+	im := IdempotencyMiddleware{repo, time.Hour}
+
+	body := `{"transaction_type": "deposit", "amount": 100}`
+	request := newIdempotentRequest(body)
+	stored := realdomain.IdempotencyRecord{
+		Key:         scopedIdempotencyKey(TokenClaims{}, "retry-1"),
+		Fingerprint: requestFingerprint(request, []byte(body)),
+		StatusCode:  http.StatusOK,
+		Response:    []byte(`{"transaction_id":"1"}`),
+	}
+	repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(&stored, nil)
+	handlerCalled := false
+	handler := im.handler(func(w http.ResponseWriter, r *http.Request) { handlerCalled = true })
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler(recorder, request)
+
+	// Assert
+	if handlerCalled {
+		t.Error("The handler should not run again for a replayed request")
+	}
+	if recorder.Code != http.StatusOK || recorder.Body.String() != string(stored.Response) {
+		t.Error("Failed while replaying the stored response")
+	}
+}
+
+func Test_should_return_422_when_the_key_is_reused_with_a_different_body(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockIdempotencyRepository(ctrl)
+	im := IdempotencyMiddleware{repo, time.Hour}
+
+	request := newIdempotentRequest(`{"transaction_type": "deposit", "amount": 999}`)
+	stored := realdomain.IdempotencyRecord{Key: "retry-1", Fingerprint: "another request", StatusCode: http.StatusOK}
+	repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(&stored, nil)
+	handler := im.handler(func(w http.ResponseWriter, r *http.Request) {})
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler(recorder, request)
+
+	// Assert
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Error("Failed while testing the status code.")
+	}
+}
+
+func Test_should_store_the_response_of_a_new_key(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockIdempotencyRepository(ctrl)
+	im := IdempotencyMiddleware{repo, time.Hour}
+
+	request := newIdempotentRequest(`{"account_type": "savings", "amount": 6000}`)
+	repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().Complete(scopedIdempotencyKey(TokenClaims{}, "retry-1"), http.StatusCreated, gomock.Any()).Return(nil)
+	handler := im.handler(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, http.StatusCreated, map[string]string{"account_id": "95471"})
+	})
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler(recorder, request)
+
+	// Assert
+	if recorder.Code != http.StatusCreated {
+		t.Error("Failed while testing the status code.")
+	}
+}
+
+func Test_should_return_400_when_the_key_is_too_long(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockIdempotencyRepository(ctrl)
+	im := IdempotencyMiddleware{repo, time.Hour}
+
+	request := newIdempotentRequest(`{"transaction_type": "deposit", "amount": 100}`)
+	request.Header.Set(idempotencyHeader, strings.Repeat("k", maxIdempotencyKeyLength+1))
+	handlerCalled := false
+	handler := im.handler(func(w http.ResponseWriter, r *http.Request) { handlerCalled = true })
+
+	// Act
+	recorder := httptest.NewRecorder()
+	handler(recorder, request)
+
+	// Assert
+	if recorder.Code != http.StatusBadRequest {
+		t.Error("Failed while testing the status code.")
+	}
+	if handlerCalled {
+		t.Error("The handler should not run for a rejected key")
+	}
+}
+
+func Test_should_keep_the_same_key_of_two_customers_apart(t *testing.T) {
+	server, auth := newMemoryServer(t)
+	deposit := `{"transaction_type":"deposit","amount":10}`
+
+	for _, c := range []struct{ username, path string }{
+		{"jotaro", "/customers/1001/account/95470"},
+		{"joseph", "/customers/1003/account/95472"},
+	} {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+c.path, strings.NewReader(deposit))
+		req.Header.Set("Authorization", "Bearer "+auth.TokenFor(c.username))
+		req.Header.Set(idempotencyHeader, "1")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated || resp.Header.Get("Idempotent-Replayed") != "" {
+			t.Errorf("%s should get a new transaction, status = %d", c.username, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"banking/logger"
+	"banking/service"
+	"flag"
+	"log"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunInterest : Batch command accruing and posting interest up to an as-of date
+//
+//	banking interest -as-of 2021-01-31
+func RunInterest(args []string) {
+	flags := flag.NewFlagSet("interest", flag.ExitOnError)
+	asOfFlag := flags.String("as-of", time.Now().Format("2006-01-02"), "accrue interest up to this date (YYYY-MM-DD)")
+	cfg, _ := loadConfig(flags, args)
+
+	asOf, err := time.Parse("2006-01-02", *asOfFlag)
+	if err != nil {
+		log.Fatal("-as-of should be a date formatted as YYYY-MM-DD")
+	}
+
+	repos := getRepositories(cfg)
+	interestService := service.NewInterestService(repos.accounts, repos.interest, getProducts(cfg.ProductsFile))
+
+	summary, appError := interestService.Run(asOf)
+	if appError != nil {
+		log.Fatal("Interest run failed: " + appError.Message)
+	}
+	logger.Info("Interest run finished",
+		zap.String("as_of", summary.AsOf),
+		zap.Int("accounts", summary.AccountsProcessed),
+		zap.Int("days_accrued", summary.DaysAccrued),
+		zap.Int("postings", summary.Postings),
+	)
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"banking/service"
+	"net/http"
+)
+
+type LedgerHandler struct {
+	service service.LedgerService
+}
+
+// /ledger/trial-balance
+func (h LedgerHandler) getTrialBalance(w http.ResponseWriter, r *http.Request) {
+	trialBalance, appError := h.service.GetTrialBalance()
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, trialBalance)
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newMemoryServer(t *testing.T) (*httptest.Server, domain.AuthRepositoryStub) {
+	repos, auth := newMemoryRepositories(domain.NewSeededMemoryStore())
+	server := httptest.NewServer(newRouter(repos, domain.DefaultProducts(), domain.FraudRules{}, time.Hour, nil, NewMetrics()))
+	t.Cleanup(server.Close)
+	return server, auth
+}
+
+func call(t *testing.T, server *httptest.Server, token string, method string, path string, body string) *http.Response {
+	req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func Test_should_open_an_account_and_post_transactions_with_in_memory_storage(t *testing.T) {
+	server, auth := newMemoryServer(t)
+	admin, jotaro := auth.TokenFor("admin"), auth.TokenFor("jotaro")
+
+	resp := call(t, server, admin, http.MethodPost, "/customers/1001/account", `{"account_type":"checking","currency":"USD","amount":6000}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("new account status = %d", resp.StatusCode)
+	}
+	var account dto.NewAccountResponse
+	json.NewDecoder(resp.Body).Decode(&account)
+
+	resp = call(t, server, jotaro, http.MethodPost, "/customers/1001/account/"+account.AccountId, `{"transaction_type":"withdrawal","amount":1000}`)
+	var transaction dto.TransactionResponse
+	json.NewDecoder(resp.Body).Decode(&transaction)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK || transaction.NewBalance.String() != "5000.00" {
+		t.Fatalf("withdrawal status = %d, balance = %s", resp.StatusCode, transaction.NewBalance.String())
+	}
+
+	resp = call(t, server, jotaro, http.MethodGet, "/customers/1003/account/95472", "")
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("another customer's account status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+
+	resp = call(t, server, admin, http.MethodGet, "/ledger/trial-balance", "")
+	var trialBalance dto.TrialBalanceResponse
+	json.NewDecoder(resp.Body).Decode(&trialBalance)
+	if resp.StatusCode != http.StatusOK || !trialBalance.Balanced || len(trialBalance.Mismatches) != 0 {
+		t.Errorf("trial balance status = %d, balanced = %v, mismatches = %v", resp.StatusCode, trialBalance.Balanced, trialBalance.Mismatches)
+	}
+}
+
+func Test_should_stop_the_workers_before_the_storage_is_closed(t *testing.T) {
+	repos, _ := newMemoryRepositories(domain.NewSeededMemoryStore())
+	cfg := defaultConfig()
+	cfg.OutboxInterval = time.Millisecond
+	ctx, stopWorkers := context.WithCancel(context.Background())
+	workers := startWorkers(ctx, cfg, repos)
+	time.Sleep(10 * time.Millisecond)
+
+	stopWorkers()
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("The workers should return once they are stopped")
+	}
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"banking/dto"
+	"banking/errs"
+	"net/http"
+	"platform/metrics"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "banking"
+
+// Metrics : The collectors every server exports with the ones of the transactions
+type Metrics struct {
+	metrics.Server
+	transactions         *prometheus.CounterVec
+	transactionAmount    *prometheus.CounterVec
+	rejectedTransactions *prometheus.CounterVec
+	pendingDecisions     *prometheus.CounterVec
+}
+
+// transactionPosted : A deposit or withdrawal was posted, the volume is in the currency of the account
+func (m Metrics) transactionPosted(t dto.TransactionResponse) {
+	m.transactions.WithLabelValues(t.TransactionType, t.Currency).Inc()
+	m.transactionAmount.WithLabelValues(t.TransactionType, t.Currency).Add(t.Amount.Float64())
+}
+
+// transferPosted : Both legs of a transfer are counted, the deposit in the currency of the destination
+func (m Metrics) transferPosted(t dto.TransferResponse) {
+	for _, leg := range t.Transactions {
+		m.transactionPosted(leg)
+	}
+}
+
+// transactionRejected : A deposit or withdrawal was refused, see rejectionReason. A type the request made up is
+// counted as unknown so the labels stay few
+func (m Metrics) transactionRejected(req dto.TransactionRequest, err *errs.AppError) {
+	transactionType := "unknown"
+	if req.IsTransactionTypeDeposit() || req.IsTransactionTypeWithdrawal() {
+		transactionType = req.TransactionType
+	}
+	m.rejectedTransactions.WithLabelValues(transactionType, rejectionReason(err)).Inc()
+}
+
+// transferRejected : A transfer was refused, counted with the type transfer
+func (m Metrics) transferRejected(err *errs.AppError) {
+	m.rejectedTransactions.WithLabelValues("transfer", rejectionReason(err)).Inc()
+}
+
+// pendingDecided : An admin approved or rejected a held transaction
+func (m Metrics) pendingDecided(decision string) {
+	m.pendingDecisions.WithLabelValues(decision).Inc()
+}
+
+// rejectionReason : A label with few values, the stable error code when there is one. The message is never used, it
+// may hold what the client sent
+func rejectionReason(err *errs.AppError) string {
+	switch {
+	case err.ErrorCode != "":
+		return strings.ToLower(err.ErrorCode)
+	case err.Code == http.StatusUnprocessableEntity:
+		return "validation"
+	case err.Code == http.StatusNotFound:
+		return "not_found"
+	case err.Code == http.StatusBadRequest:
+		return "bad_request"
+	}
+	return "error"
+}
+
+// NewMetrics : Registers the business collectors next to the HTTP, Go runtime and process collectors
+func NewMetrics() Metrics {
+	m := Metrics{
+		Server: metrics.NewServer(metricsNamespace),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "transactions_total",
+			Help:      "Posted deposits and withdrawals, with the legs of transfers, by type and account currency.",
+		}, []string{"type", "currency"}),
+		transactionAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "transaction_amount_total",
+			Help:      "Volume of the posted deposits and withdrawals in units of the account currency.",
+		}, []string{"type", "currency"}),
+		rejectedTransactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "transactions_rejected_total",
+			Help:      "Refused deposits, withdrawals and transfers by type and reason.",
+		}, []string{"type", "reason"}),
+		pendingDecisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pending_decisions_total",
+			Help:      "Transactions held by fraud screening that an admin approved or rejected.",
+		}, []string{"decision"}),
+	}
+	m.MustRegister(m.transactions, m.transactionAmount, m.rejectedTransactions, m.pendingDecisions)
+	return m
+}
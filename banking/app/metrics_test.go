@@ -0,0 +1,122 @@
+package app
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_should_export_requests_and_transactions_on_metrics_without_a_token(t *testing.T) {
+	server, auth := newMemoryServer(t)
+	admin := auth.TokenFor("admin")
+
+	call(t, server, admin, http.MethodPost, "/customers/1001/account", `{"account_type":"checking","currency":"USD","amount":6000}`)
+	call(t, server, admin, http.MethodGet, "/customers/1001", "")
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal("Invalid status: ", resp.StatusCode)
+	}
+	for _, expected := range []string{
+		`banking_http_requests_total{code="201",method="POST",route="NewAccount"} 1`,
+		`banking_http_requests_total{code="200",method="GET",route="GetCustomer"} 1`,
+		`banking_http_request_duration_seconds_count{method="GET",route="GetCustomer"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("%s should be exported", expected)
+		}
+	}
+}
+
+func Test_should_count_posted_and_rejected_transactions(t *testing.T) {
+	server, auth := newMemoryServer(t)
+	admin := auth.TokenFor("admin")
+	resp := call(t, server, admin, http.MethodPost, "/customers/1001/account", `{"account_type":"checking","currency":"USD","amount":6000}`)
+	var opened dto.NewAccountResponse
+	json.NewDecoder(resp.Body).Decode(&opened)
+	account := "/customers/1001/account/" + opened.AccountId
+
+	call(t, server, admin, http.MethodPost, account, `{"transaction_type":"deposit","amount":100.5}`)
+	call(t, server, admin, http.MethodPost, account, `{"transaction_type":"withdrawal","amount":7000}`)
+	call(t, server, admin, http.MethodPost, account, `{"transaction_type":"gift","amount":1}`)
+
+	resp, _ = http.Get(server.URL + "/metrics")
+	body, _ := ioutil.ReadAll(resp.Body)
+	for _, expected := range []string{
+		`banking_transactions_total{currency="USD",type="deposit"} 1`,
+		`banking_transaction_amount_total{currency="USD",type="deposit"} 100.5`,
+		`banking_transactions_rejected_total{reason="insufficient_funds",type="withdrawal"} 1`,
+		`banking_transactions_rejected_total{reason="validation",type="unknown"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("%s should be exported", expected)
+		}
+	}
+}
+
+func Test_should_count_transfers_and_malformed_requests(t *testing.T) {
+	server, auth := newMemoryServer(t)
+	admin := auth.TokenFor("admin")
+
+	call(t, server, admin, http.MethodPost, "/customers/1001/account/95470/transfer", `{"to_account_id":"95471","amount":10}`)
+	call(t, server, admin, http.MethodPost, "/customers/1001/account/95470/transfer", `{"to_account_id":"95470","amount":10}`)
+	call(t, server, admin, http.MethodPost, "/customers/1001/account/95470/transfer", `{"to_account_id":`)
+	call(t, server, admin, http.MethodPost, "/customers/1001/account/95470", `{"transaction_type":`)
+
+	resp, _ := http.Get(server.URL + "/metrics")
+	body, _ := ioutil.ReadAll(resp.Body)
+	for _, expected := range []string{
+		`banking_transactions_total{currency="USD",type="withdrawal"} 1`,
+		`banking_transactions_total{currency="USD",type="deposit"} 1`,
+		`banking_transactions_rejected_total{reason="validation",type="transfer"} 1`,
+		`banking_transactions_rejected_total{reason="bad_request",type="transfer"} 1`,
+		`banking_transactions_rejected_total{reason="bad_request",type="unknown"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("%s should be exported", expected)
+		}
+	}
+}
+
+func Test_should_count_the_decisions_on_held_transactions(t *testing.T) {
+	repos, auth := newMemoryRepositories(domain.NewSeededMemoryStore())
+	reviewAll := domain.FraudRules{Rules: []domain.FraudRule{
+		domain.VelocityRule{Window: time.Hour, MaxCount: 0, TransactionType: domain.WITHDRAWAL, Decision: domain.ScreeningReview},
+	}}
+	server := httptest.NewServer(newRouter(repos, domain.DefaultProducts(), reviewAll, time.Hour, nil, NewMetrics()))
+	t.Cleanup(server.Close)
+	admin := auth.TokenFor("admin")
+
+	for _, decision := range []string{"approve", "reject"} {
+		resp := call(t, server, admin, http.MethodPost, "/customers/1001/account/95470", `{"transaction_type":"withdrawal","amount":10}`)
+		var held dto.TransactionResponse
+		json.NewDecoder(resp.Body).Decode(&held)
+		if !held.IsPending() {
+			t.Fatal("The withdrawal should be held for review")
+		}
+		call(t, server, admin, http.MethodPost, "/pending-transactions/"+held.PendingId+"/"+decision, "")
+	}
+
+	resp, _ := http.Get(server.URL + "/metrics")
+	body, _ := ioutil.ReadAll(resp.Body)
+	for _, expected := range []string{
+		`banking_pending_decisions_total{decision="approved"} 1`,
+		`banking_pending_decisions_total{decision="rejected"} 1`,
+		`banking_transactions_total{currency="USD",type="withdrawal"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("%s should be exported", expected)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"banking/domain"
+	"banking/logger"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// Values of STORAGE, the database is the default. DB_DRIVER picks the database, "mysql" is still
+// accepted from before there was a choice
+const (
+	storageDatabase = "database"
+	storageMySQL    = "mysql"
+	storageMemory   = "memory"
+)
+
+// repositories : The secondary adapters the handlers and background jobs are wired with
+type repositories struct {
+	customers   domain.CustomerRepository
+	accounts    domain.AccountRepository
+	fxRates     domain.FxRateRepository
+	pending     domain.PendingTransactionRepository
+	ledger      domain.LedgerRepository
+	idempotency domain.IdempotencyRepository
+	interest    domain.InterestRepository
+	outbox      domain.OutboxRepository
+	webhooks    domain.WebhookRepository
+	users       domain.UserRepository
+	auth        domain.AuthRepository
+	// db : The pool the adapters share, nil with the in-memory storage
+	db *sqlx.DB
+}
+
+// getRepositories : Returns the adapters selected by STORAGE, "memory" runs the service without a database
+func getRepositories(cfg Config) repositories {
+	if cfg.usesDatabase() {
+		return newDBRepositories(getDBClient(cfg.DB), cfg.AuthURL)
+	}
+	repos, _ := newMemoryRepositories(domain.NewSeededMemoryStore())
+	// the tokens come from banking-auth, which knows the fixture users when it runs with STORAGE=memory too
+	repos.auth = domain.NewAuthRepository(cfg.AuthURL)
+	logger.Info("Using in-memory storage, the data is lost when the process stops", zap.String("auth_url", cfg.AuthURL))
+	return repos
+}
+
+func newDBRepositories(dbClient *sqlx.DB, authURL string) repositories {
+	return repositories{
+		customers:   domain.NewCustomerRepositoryDb(dbClient),
+		accounts:    domain.NewAccountRepositoryDB(dbClient),
+		fxRates:     domain.NewFxRateRepositoryDB(dbClient),
+		pending:     domain.NewPendingTransactionRepositoryDB(dbClient),
+		ledger:      domain.NewLedgerRepositoryDB(dbClient),
+		idempotency: domain.NewIdempotencyRepositoryDB(dbClient),
+		interest:    domain.NewInterestRepositoryDB(dbClient),
+		outbox:      domain.NewOutboxRepositoryDB(dbClient),
+		webhooks:    domain.NewWebhookRepositoryDB(dbClient),
+		users:       domain.NewUserRepositoryDB(dbClient),
+		auth:        domain.NewAuthRepository(authURL),
+		db:          dbClient,
+	}
+}
+
+// newMemoryRepositories : Every adapter works on the same store, the auth adapter knows the fixture users and
+// issues their tokens
+func newMemoryRepositories(store *domain.MemoryStore) (repositories, domain.AuthRepositoryStub) {
+	auth := domain.NewAuthRepositoryStub(store, domain.DefaultStubLogins()...)
+	return repositories{
+		customers:   domain.NewCustomerRepositoryStub(store),
+		accounts:    domain.NewAccountRepositoryStub(store),
+		fxRates:     domain.NewFxRateRepositoryStub(store),
+		pending:     domain.NewPendingTransactionRepositoryStub(store),
+		ledger:      domain.NewLedgerRepositoryStub(store),
+		idempotency: domain.NewIdempotencyRepositoryStub(store),
+		interest:    domain.NewInterestRepositoryStub(store),
+		outbox:      domain.NewOutboxRepositoryStub(store),
+		webhooks:    domain.NewWebhookRepositoryStub(store),
+		users:       domain.NewUserRepositoryStub(store),
+		auth:        auth,
+	}, auth
+}
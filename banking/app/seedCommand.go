@@ -0,0 +1,61 @@
+package app
+
+import (
+	"banking/logger"
+	"banking/service"
+	"flag"
+	"log"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunSeed : Batch command filling the storage with synthetic data, the same -seed always generates the same data
+//
+//	banking seed -seed 42 -customers 1000 -savings 1 -checking 1 -transactions 50 -from 2020-01-01 -to 2020-12-31
+func RunSeed(args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	seed := flags.Int64("seed", 1, "seed of the random generator")
+	customers := flags.Int("customers", 10, "number of customers to create")
+	savings := flags.Int("savings", 1, "savings accounts per customer")
+	checking := flags.Int("checking", 1, "checking accounts per customer")
+	transactions := flags.Int("transactions", 20, "transactions per account")
+	fromFlag := flags.String("from", "2020-01-01", "first day of the transaction history (YYYY-MM-DD)")
+	toFlag := flags.String("to", "2020-12-31", "last day of the transaction history (YYYY-MM-DD)")
+	password := flags.String("password", service.DefaultSeedPassword, "password of the generated banking-auth logins")
+	cfg, _ := loadConfig(flags, args)
+
+	from, err := time.Parse("2006-01-02", *fromFlag)
+	if err != nil {
+		log.Fatal("-from should be a date formatted as YYYY-MM-DD")
+	}
+	to, err := time.Parse("2006-01-02", *toFlag)
+	if err != nil {
+		log.Fatal("-to should be a date formatted as YYYY-MM-DD")
+	}
+
+	repos := getRepositories(cfg)
+	seedService := service.NewSeedService(repos.customers, repos.accounts, repos.users, getProducts(cfg.ProductsFile))
+
+	summary, appError := seedService.Run(service.SeedOptions{
+		Seed:         *seed,
+		Customers:    *customers,
+		Savings:      *savings,
+		Checking:     *checking,
+		Transactions: *transactions,
+		From:         from,
+		// the history runs through the end of the last day
+		To:       to.Add(24*time.Hour - time.Second),
+		Password: *password,
+	})
+	if appError != nil {
+		log.Fatal("Seed failed: " + appError.Message)
+	}
+	logger.Info("Seed finished",
+		zap.Int64("seed", summary.Seed),
+		zap.Int("customers", summary.Customers),
+		zap.Int("accounts", summary.Accounts),
+		zap.Int("transactions", summary.Transactions),
+		zap.Int("users", summary.Users),
+	)
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"banking/logger"
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// serve : Runs the server until SIGINT or SIGTERM, then stops accepting connections and waits up to timeout for the
+// requests in flight to finish
+func serve(server *http.Server, timeout time.Duration) error {
+	failed := make(chan error, 1)
+	go func() {
+		failed <- server.ListenAndServe()
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	select {
+	case err := <-failed:
+		return err
+	case sig := <-stop:
+		logger.Info("Shutting down, draining the requests in flight", zap.String("signal", sig.String()),
+			zap.Duration("timeout", timeout))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"banking/dto"
+	"encoding/csv"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	statementFormatJSON = "json"
+	statementFormatCSV  = "csv"
+	statementFormatOFX  = "ofx"
+)
+
+// statementFormat : The format query param wins over the Accept header, JSON is used when neither asks for one
+func statementFormat(r *http.Request) (string, bool) {
+	if format := strings.ToLower(r.URL.Query().Get("format")); format != "" {
+		switch format {
+		case statementFormatJSON, statementFormatCSV, statementFormatOFX:
+			return format, true
+		}
+		return "", false
+	}
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return statementFormatCSV, true
+	case strings.Contains(accept, "application/x-ofx"), strings.Contains(accept, "application/ofx"):
+		return statementFormatOFX, true
+	}
+	return statementFormatJSON, true
+}
+
+// writeStatementCSV : One row per transaction, with the opening and closing balance as the first and last rows
+func writeStatementCSV(w http.ResponseWriter, s dto.StatementResponse) {
+	w.Header().Add("Content-Type", "text/csv")
+	w.Header().Add("Content-Disposition", "attachment; filename=\"statement-"+s.AccountId+"-"+s.From+"-"+s.To+".csv\"")
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"date", "transaction_id", "type", "description", "amount", "balance", "currency"})
+	writer.Write([]string{s.From, "", "", "Opening balance", "", s.OpeningBalance.String(), s.Currency})
+	for _, l := range s.Lines {
+		description := l.TransactionType
+		if l.TransferId != "" {
+			description = "transfer " + l.TransferId
+		}
+		writer.Write([]string{l.TransactionDate, l.TransactionId, l.TransactionType, description,
+			l.Amount.String(), l.RunningBalance.String(), s.Currency})
+	}
+	writer.Write([]string{s.To, "", "", "Closing balance", "", s.ClosingBalance.String(), s.Currency})
+	writer.Flush()
+}
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	SignOn  struct {
+		Status   ofxStatus `xml:"STATUS"`
+		Server   string    `xml:"DTSERVER"`
+		Language string    `xml:"LANGUAGE"`
+	} `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank struct {
+		Response struct {
+			TrnUID    string    `xml:"TRNUID"`
+			Status    ofxStatus `xml:"STATUS"`
+			Statement struct {
+				Currency string `xml:"CURDEF"`
+				Account  struct {
+					BankId    string `xml:"BANKID"`
+					AccountId string `xml:"ACCTID"`
+					Type      string `xml:"ACCTTYPE"`
+				} `xml:"BANKACCTFROM"`
+				TransactionList struct {
+					Start        string           `xml:"DTSTART"`
+					End          string           `xml:"DTEND"`
+					Transactions []ofxTransaction `xml:"STMTTRN"`
+				} `xml:"BANKTRANLIST"`
+				LedgerBalance ofxBalance `xml:"LEDGERBAL"`
+			} `xml:"STMTRS"`
+		} `xml:"STMTTRNRS"`
+	} `xml:"BANKMSGSRSV1"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	Id     string `xml:"FITID"`
+	Name   string `xml:"NAME"`
+	Memo   string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
+
+// writeStatementOFX : Renders the statement as an OFX 2 bank statement response
+func writeStatementOFX(w http.ResponseWriter, s dto.StatementResponse) {
+	var doc ofxDocument
+	doc.SignOn.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	doc.SignOn.Server = time.Now().Format("20060102150405")
+	doc.SignOn.Language = "ENG"
+	rs := &doc.Bank.Response
+	rs.TrnUID = "0"
+	rs.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	rs.Statement.Currency = s.Currency
+	rs.Statement.Account.BankId = "BANKING"
+	rs.Statement.Account.AccountId = s.AccountId
+	rs.Statement.Account.Type = strings.ToUpper(s.AccountType)
+	rs.Statement.TransactionList.Start = ofxDate(s.From)
+	rs.Statement.TransactionList.End = ofxDate(s.To)
+	for _, l := range s.Lines {
+		t := ofxTransaction{Type: "CREDIT", Posted: ofxDate(l.TransactionDate), Amount: l.Amount.String(), Id: l.TransactionId, Name: l.TransactionType}
+		if l.Amount < 0 {
+			t.Type = "DEBIT"
+		}
+		if l.TransferId != "" {
+			t.Memo = "transfer " + l.TransferId
+		}
+		rs.Statement.TransactionList.Transactions = append(rs.Statement.TransactionList.Transactions, t)
+	}
+	rs.Statement.LedgerBalance = ofxBalance{Amount: s.ClosingBalance.String(), AsOf: ofxDate(s.To)}
+
+	w.Header().Add("Content-Type", "application/x-ofx")
+	w.Header().Add("Content-Disposition", "attachment; filename=\"statement-"+s.AccountId+"-"+s.From+"-"+s.To+".ofx\"")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write([]byte(`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"))
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	encoder.Encode(doc)
+}
+
+// ofxDate : OFX dates are written as YYYYMMDDHHMMSS
+func ofxDate(value string) string {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("20060102150405")
+		}
+	}
+	return value
+}
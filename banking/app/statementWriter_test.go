@@ -0,0 +1,48 @@
+package app
+
+import (
+	"banking/dto"
+	"banking/money"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_should_pick_the_statement_format_from_the_query_before_the_accept_header(t *testing.T) {
+	// Arrange
+	request, _ := http.NewRequest(http.MethodGet, "/customers/2000/account/95470/statement?format=ofx", nil)
+	request.Header.Set("Accept", "text/csv")
+	// Act
+	format, ok := statementFormat(request)
+	// Assert
+	if !ok || format != statementFormatOFX {
+		t.Error("Failed while picking the statement format")
+	}
+}
+
+func Test_should_write_opening_and_closing_balance_rows_in_csv_statements(t *testing.T) {
+	// Arrange
+	statement := dto.StatementResponse{
+		AccountId: "95470", Currency: "USD", From: "2021-01-01", To: "2021-01-31",
+		OpeningBalance: money.FromUnits(100), ClosingBalance: money.FromUnits(75),
+		Lines: []dto.StatementLineResponse{
+			{TransactionId: "1", TransactionDate: "2021-01-05 10:00:00", TransactionType: "withdrawal", Amount: money.FromUnits(-25), RunningBalance: money.FromUnits(75)},
+		},
+	}
+	// Act
+	recorder := httptest.NewRecorder()
+	writeStatementCSV(recorder, statement)
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 csv rows, got %d", len(lines))
+	}
+	if lines[2] != "2021-01-05 10:00:00,1,withdrawal,withdrawal,-25.00,75.00,USD" {
+		t.Errorf("Failed while matching the transaction row, got %s", lines[2])
+	}
+	if !strings.Contains(lines[3], "Closing balance,,75.00") {
+		t.Errorf("Failed while matching the closing balance row, got %s", lines[3])
+	}
+}
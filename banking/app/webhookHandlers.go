@@ -0,0 +1,61 @@
+package app
+
+import (
+	"banking/dto"
+	"banking/service"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+}
+
+// /customers/2000/webhooks
+func (h WebhookHandler) newWebhook(w http.ResponseWriter, r *http.Request) {
+	var request dto.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	request.CustomerId = mux.Vars(r)["customer_id"]
+	webhook, appError := h.service.NewWebhook(request)
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusCreated, webhook)
+	}
+}
+
+// /customers/2000/webhooks
+func (h WebhookHandler) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, appError := h.service.GetWebhooks(mux.Vars(r)["customer_id"])
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, webhooks)
+	}
+}
+
+// /customers/2000/webhooks/3
+func (h WebhookHandler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if appError := h.service.DeleteWebhook(vars["customer_id"], vars["webhook_id"]); appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// /customers/2000/webhooks/3/deliveries
+func (h WebhookHandler) getDeliveries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deliveries, appError := h.service.GetDeliveries(vars["customer_id"], vars["webhook_id"])
+	if appError != nil {
+		writeResponse(w, appError.Code, appError.AsMessage())
+	} else {
+		writeResponse(w, http.StatusOK, deliveries)
+	}
+}
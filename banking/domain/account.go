@@ -3,31 +3,94 @@ package domain
 import (
 	"banking/dto"
 	"banking/errs"
+	"banking/money"
+)
+
+// Account status codes, stored in accounts.status
+const (
+	AccountStatusClosed = "0"
+	AccountStatusActive = "1"
+	AccountStatusFrozen = "2"
 )
 
 type Account struct {
-	AccountId   string  `db:"account_id"`
-	CustomerId  string  `db:"customer_id"`
-	OpeningDate string  `db:"opening_date"`
-	AccountType string  `db:"account_type"`
-	Amount      float64 `db:"amount"`
-	Status      string  `db:"status"`
+	AccountId   string       `db:"account_id"`
+	CustomerId  string       `db:"customer_id"`
+	OpeningDate string       `db:"opening_date"`
+	AccountType string       `db:"account_type"`
+	Currency    string       `db:"currency"`
+	Amount      money.Amount `db:"amount"`
+	Status      string       `db:"status"`
+	// OverdraftLimit : How far below zero the balance may go, taken from the product when the account is opened
+	OverdraftLimit money.Amount `db:"overdraft_limit"`
 }
 
 //go:generate mockgen -destination=../mocks/domain/mockAccountRepository.go -package=domain banking/domain AccountRepository
 type AccountRepository interface {
 	Save(Account) (*Account, *errs.AppError)
 	SaveTransaction(transaction Transaction) (*Transaction, *errs.AppError)
+	SaveTransfer(transfer Transfer) (*Transfer, *errs.AppError)
 	FindBy(accountId string) (*Account, *errs.AppError)
+	SaveOverdraftLimit(accountId string, limit money.Amount) *errs.AppError
+	// SaveStatusChange : Changes the account status and records who did it and why, a payout transfer
+	// given for a closing account is saved in the same database transaction
+	SaveStatusChange(change AccountStatusChange, payout *Transfer) *errs.AppError
+	// SaveReversal : Saves the compensating transaction, marks the original as reversed and records the
+	// reversal, refusing a second reversal of the same transaction
+	SaveReversal(reversal TransactionReversal) (*Transaction, *errs.AppError)
+	FindTransaction(transactionId string) (*Transaction, *errs.AppError)
+	FindTransactions(filter TransactionFilter) ([]Transaction, *errs.AppError)
+	FindTransactionsBetween(accountId string, from string, to string) ([]Transaction, *errs.AppError)
 }
 
-func (a Account) CanWithdraw(amount float64) bool {
-	if a.Amount < amount {
+func (a Account) CanWithdraw(amount money.Amount) bool {
+	if a.AvailableBalance() < amount {
 		return false
 	}
 	return true
 }
 
+// AvailableBalance : What can still be withdrawn, the balance plus the unused overdraft
+func (a Account) AvailableBalance() money.Amount {
+	return a.Amount + a.OverdraftLimit
+}
+
+// AsStatusText : Return the status code as text
+func (a Account) AsStatusText() string {
+	switch a.Status {
+	case AccountStatusClosed:
+		return "Closed"
+	case AccountStatusFrozen:
+		return "Frozen"
+	}
+	return "Active"
+}
+
+// CanPost : Closed accounts take no transactions at all, frozen accounts only take money in
+func (a Account) CanPost(isWithdrawal bool) *errs.AppError {
+	if a.Status == AccountStatusClosed {
+		return errs.NewAccountInactiveError("Account is closed")
+	}
+	if a.Status == AccountStatusFrozen && isWithdrawal {
+		return errs.NewAccountInactiveError("Account is frozen")
+	}
+	return nil
+}
+
+func (a Account) ToDto() dto.AccountResponse {
+	return dto.AccountResponse{
+		AccountId:        a.AccountId,
+		CustomerId:       a.CustomerId,
+		OpeningDate:      a.OpeningDate,
+		AccountType:      a.AccountType,
+		Currency:         a.Currency,
+		Balance:          a.Amount,
+		Status:           a.AsStatusText(),
+		AvailableBalance: a.AvailableBalance(),
+		OverdraftLimit:   a.OverdraftLimit,
+	}
+}
+
 // ToNewAccountResponseDTO : Transforms a account object into a dto.account response
 func (a Account) ToNewAccountResponseDTO() dto.NewAccountResponse {
 	return dto.NewAccountResponse{AccountId: a.AccountId}
@@ -3,100 +3,461 @@ package domain
 import (
 	"banking/errs"
 	"banking/logger"
+	"banking/money"
+	"database/sql"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 )
 
+// transactionColumns : Columns read into a Transaction
+const transactionColumns = "transaction_id, account_id, amount, transaction_type, transaction_date, currency, original_amount, original_currency, fx_rate, transfer_id, reversal_of, reversed_by"
+
 type AccountRepositoryDB struct {
 	client *sqlx.DB
 }
 
-// Save : Take a customer and create a new account record
+// Save : Take a customer and create a new account record, the opening deposit is booked in the ledger
 func (d AccountRepositoryDB) Save(a Account) (*Account, *errs.AppError) {
-	sqlInsert := "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status) VALUES(?, ?, ?, ?, ?);"
-	result, err := d.client.Exec(sqlInsert, a.CustomerId, a.OpeningDate, a.AccountType, a.Amount, a.Status)
+	tx, err := d.client.Beginx()
 	if err != nil {
-		logger.Error("Error while creating new account: " + err.Error())
+		logger.Error("Error while starting a new transaction for new account: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected error from database")
 	}
-	// Returns the id
-	id, err := result.LastInsertId()
-
+	// the balance starts at zero and the opening entry moves the deposit in
+	sqlInsert := "INSERT INTO accounts (customer_id, opening_date, account_type, currency, amount, status, overdraft_limit) VALUES(?, ?, ?, ?, 0, ?, ?);"
+	id, err := insert(tx, "account_id", sqlInsert, a.CustomerId, a.OpeningDate, a.AccountType, a.Currency, a.Status, a.OverdraftLimit)
 	if err != nil {
-		logger.Error("Error while retrieving last inserted id: " + err.Error())
+		tx.Rollback()
+		logger.Error("Error while creating new account: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected error from database")
 	}
 	// Save the account id into the domain object
 	a.AccountId = strconv.FormatInt(id, 10)
+	if a.Amount > 0 {
+		entry := NewOpeningEntry(a)
+		if appErr := postJournalEntry(tx, &entry); appErr != nil {
+			tx.Rollback()
+			return nil, appErr
+		}
+	}
+	if appErr := saveEvents(tx, NewAccountOpenedEvent(a)); appErr != nil {
+		tx.Rollback()
+		return nil, appErr
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting new account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected error from database")
+	}
 	return &a, nil
 }
 
 /**
- * transaction = make an entry in the transaction table + update the balance in the accounts table
+ * transaction = make an entry in the transaction table + post the matching journal entry to the ledger,
+ * which updates the balance in the accounts table
  */
 func (d AccountRepositoryDB) SaveTransaction(t Transaction) (*Transaction, *errs.AppError) {
 	// starting the database transaction block
-	tx, err := d.client.Begin()
+	tx, err := d.client.Beginx()
 	if err != nil {
 		logger.Error("Error while starting a new transaction for bank account transaction: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
+	if appErr := saveTransaction(tx, &t); appErr != nil {
+		// in case of error Rollback, and changes from both the tables will be reverted
+		tx.Rollback()
+		return nil, appErr
+	}
+	// commit the transaction when all is good
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transaction for bank account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &t, nil
+}
 
+func saveTransaction(tx *sqlx.Tx, t *Transaction) *errs.AppError {
+	if t.DailyLimits != nil && t.IsWithdrawal() {
+		if err := lockAccount(tx, t.AccountId); err != nil {
+			return err
+		}
+		if err := checkDailyLimits(tx, t.AccountId, t.Amount, t.Currency, *t.DailyLimits); err != nil {
+			return err
+		}
+	}
 	// inserting bank account transaction
-	result, _ := tx.Exec(`INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) 
-											values (?, ?, ?, ?)`, t.AccountId, t.Amount, t.TransactionType, t.TransactionDate)
+	if appErr := insertTransaction(tx, t); appErr != nil {
+		return appErr
+	}
 
-	// updating account balance
-	if t.IsWithdrawal() {
-		_, err = tx.Exec(`UPDATE accounts SET amount = amount - ? where account_id = ?`, t.Amount, t.AccountId)
-	} else {
-		_, err = tx.Exec(`UPDATE accounts SET amount = amount + ? where account_id = ?`, t.Amount, t.AccountId)
+	// the ledger moves the money, withdrawals fail here when the balance does not cover them
+	entry := NewTransactionEntry(*t)
+	if appErr := postJournalEntry(tx, &entry); appErr != nil {
+		return appErr
 	}
 
-	// in case of error Rollback, and changes from both the tables will be reverted
+	// updating the transaction struct with the balance seen by this database transaction
+	if err := get(tx, &t.Balance, `SELECT amount FROM accounts WHERE account_id = ?`, t.AccountId); err != nil {
+		logger.Error("Error while fetching the new account balance: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return saveEvents(tx, NewTransactionPostedEvent(*t))
+}
+
+// insertTransaction : Inserts the transaction and sets its id. The unique key on account_id and reference skips a
+// transaction whose reference the account already has, so concurrent runs of a system posting book it only once.
+func insertTransaction(tx *sqlx.Tx, t *Transaction) *errs.AppError {
+	sqlInsert := `INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, currency, original_amount, original_currency, fx_rate, reference) 
+											values (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	args := []interface{}{t.AccountId, t.Amount, t.TransactionType, t.TransactionDate, t.Currency, t.OriginalAmount,
+		t.OriginalCurrency, t.FxRate, t.Reference}
+	if !t.Reference.Valid {
+		transactionId, err := insert(tx, "transaction_id", sqlInsert, args...)
+		if err != nil {
+			logger.Error("Error while saving transaction: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		t.TransactionId = strconv.FormatInt(transactionId, 10)
+		return nil
+	}
+
+	result, err := exec(tx, insertIgnore(tx.DriverName(), sqlInsert), args...)
+	var inserted int64
+	if err == nil {
+		inserted, err = result.RowsAffected()
+	}
+	if err == nil && inserted == 0 {
+		return errs.NewAlreadyPostedError("Transaction " + t.Reference.String + " was already posted")
+	}
+	if err == nil {
+		err = get(tx, &t.TransactionId, `SELECT transaction_id FROM transactions WHERE account_id = ? AND reference = ?`,
+			t.AccountId, t.Reference)
+	}
 	if err != nil {
-		tx.Rollback()
 		logger.Error("Error while saving transaction: " + err.Error())
-		return nil, errs.NewUnexpectedError("Unexpected database error")
+		return errs.NewUnexpectedError("Unexpected database error")
 	}
-	// commit the transaction when all is good
-	err = tx.Commit()
+	return nil
+}
+
+/**
+ * transfer = lock both accounts + one transaction entry per account + a single journal entry moving the amount,
+ * all inside the same database transaction
+ */
+func (d AccountRepositoryDB) SaveTransfer(t Transfer) (*Transfer, *errs.AppError) {
+	tx, err := d.client.Beginx()
 	if err != nil {
+		logger.Error("Error while starting a new transaction for bank account transfer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	appErr := saveTransferLegs(tx, &t)
+	if appErr != nil {
 		tx.Rollback()
-		logger.Error("Error while commiting transaction for bank account: " + err.Error())
+		return nil, appErr
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting transfer for bank account: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
-	// getting the last transaction ID from the transaction table
-	transactionId, err := result.LastInsertId()
+	return &t, nil
+}
+
+func saveTransferLegs(tx *sqlx.Tx, t *Transfer) *errs.AppError {
+	// locking the rows in the same order for every transfer, so two opposite transfers cannot deadlock
+	locked := make([]string, 0)
+	err := selectAll(tx, &locked, `SELECT account_id FROM accounts WHERE account_id IN (?, ?) ORDER BY account_id`+forUpdate(tx.DriverName()),
+		t.FromAccountId, t.ToAccountId)
 	if err != nil {
-		logger.Error("Error while getting the last transaction id: " + err.Error())
-		return nil, errs.NewUnexpectedError("Unexpected database error")
+		logger.Error("Error while locking accounts for transfer: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if len(locked) != 2 {
+		return errs.NewNotFoundError("Account not found")
+	}
+	if t.DailyLimits != nil {
+		if err := checkDailyLimits(tx, t.FromAccountId, t.Amount, t.Currency, *t.DailyLimits); err != nil {
+			return err
+		}
 	}
 
-	// Getting the latest account information from the accounts table
-	account, appErr := d.FindBy(t.AccountId)
-	if appErr != nil {
+	// the withdrawal is in the source currency, the deposit is converted into the destination currency
+	t.Withdrawal = Transaction{AccountId: t.FromAccountId, Amount: t.Amount, TransactionType: WITHDRAWAL, Currency: t.Currency,
+		OriginalAmount: t.Amount, OriginalCurrency: t.Currency, FxRate: money.OneToOne}
+	t.Deposit = Transaction{AccountId: t.ToAccountId, Amount: t.CreditedAmount, TransactionType: DEPOSIT, Currency: t.CreditedCurrency,
+		OriginalAmount: t.Amount, OriginalCurrency: t.Currency, FxRate: t.FxRate}
+	for _, l := range []*Transaction{&t.Withdrawal, &t.Deposit} {
+		transactionId, err := insert(tx, "transaction_id", `INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, currency, original_amount, original_currency, fx_rate, transfer_id) 
+											values (?, ?, ?, ?, ?, ?, ?, ?, ?)`, l.AccountId, l.Amount, l.TransactionType, t.TransferDate,
+			l.Currency, l.OriginalAmount, l.OriginalCurrency, l.FxRate, t.TransferId)
+		if err != nil {
+			logger.Error("Error while saving transfer transaction: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		l.TransactionId = strconv.FormatInt(transactionId, 10)
+		l.TransactionDate = t.TransferDate
+		l.TransferId = sql.NullString{String: t.TransferId, Valid: true}
+	}
+
+	entry := NewTransferEntry(*t)
+	if appErr := postJournalEntry(tx, &entry); appErr != nil {
+		return appErr
+	}
+	for _, l := range []*Transaction{&t.Withdrawal, &t.Deposit} {
+		if err = get(tx, &l.Balance, `SELECT amount FROM accounts WHERE account_id = ?`, l.AccountId); err != nil {
+			logger.Error("Error while fetching the new account balance: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+	return saveEvents(tx, NewTransactionPostedEvent(t.Withdrawal), NewTransactionPostedEvent(t.Deposit))
+}
+
+// lockAccount : Locks the account row until the end of the database transaction
+func lockAccount(tx *sqlx.Tx, accountId string) *errs.AppError {
+	locked := make([]string, 0)
+	err := selectAll(tx, &locked, `SELECT account_id FROM accounts WHERE account_id = ?`+forUpdate(tx.DriverName()), accountId)
+	if err != nil {
+		logger.Error("Error while locking account: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if len(locked) == 0 {
+		return errs.NewNotFoundError("Account not found")
+	}
+	return nil
+}
+
+// checkDailyLimits : The account must be locked, so no other withdrawal can be posted between the sum and the insert
+func checkDailyLimits(tx *sqlx.Tx, accountId string, amount money.Amount, currency string, check DailyLimitCheck) *errs.AppError {
+	var usage WithdrawalUsage
+	err := get(tx, &usage, `SELECT COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count FROM transactions
+									WHERE account_id = ? AND transaction_type = ? AND transaction_date >= ?
+									AND reversal_of IS NULL AND reversed_by IS NULL`, accountId, WITHDRAWAL, check.Since)
+	if err != nil {
+		logger.Error("Error while summing withdrawals: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return check.Limits.CheckDaily(amount, currency, usage)
+}
+
+/**
+ * status change = optional payout of the balance + status update + audit entry, all inside the same database transaction
+ */
+func (d AccountRepositoryDB) SaveStatusChange(c AccountStatusChange, payout *Transfer) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for account status change: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if appErr := saveStatusChange(tx, c, payout); appErr != nil {
+		tx.Rollback()
+		return appErr
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting account status change: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func saveStatusChange(tx *sqlx.Tx, c AccountStatusChange, payout *Transfer) *errs.AppError {
+	if payout != nil {
+		if appErr := saveTransferLegs(tx, payout); appErr != nil {
+			return appErr
+		}
+	}
+	// the status only changes if nobody changed it meanwhile, and a closing account must be empty by now
+	sqlUpdate := `UPDATE accounts SET status = ? WHERE account_id = ? AND status = ?`
+	if c.ToStatus == AccountStatusClosed {
+		sqlUpdate += ` AND ROUND(amount, 2) = 0`
+	}
+	result, err := exec(tx, sqlUpdate, c.ToStatus, c.AccountId, c.FromStatus)
+	if err != nil {
+		logger.Error("Error while updating account status: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		if c.ToStatus == AccountStatusClosed {
+			return errs.NewValidationError("Account balance should be zero to close it")
+		}
+		return errs.NewValidationError("Account status was changed by another request, try again")
+	}
+	_, err = exec(tx, `INSERT INTO account_status_changes (account_id, from_status, to_status, reason, actor, changed_at)
+							VALUES (?, ?, ?, ?, ?, ?)`, c.AccountId, c.FromStatus, c.ToStatus, c.Reason, c.Actor, c.ChangedAt)
+	if err != nil {
+		logger.Error("Error while saving account status change: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return saveEvents(tx, NewAccountStatusChangedEvent(c))
+}
+
+/**
+ * reversal = compensating transaction with the mirrored journal entry + the original marked as reversed + audit entry,
+ * all inside the same database transaction
+ */
+func (d AccountRepositoryDB) SaveReversal(r TransactionReversal) (*Transaction, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for reversal: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	if appErr := saveReversal(tx, &r); appErr != nil {
+		tx.Rollback()
 		return nil, appErr
 	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting reversal: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &r.Reversal, nil
+}
+
+func saveReversal(tx *sqlx.Tx, r *TransactionReversal) *errs.AppError {
+	t := &r.Reversal
+	transactionId, err := insert(tx, "transaction_id", `INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, currency, original_amount, original_currency, fx_rate, reversal_of) 
+											values (?, ?, ?, ?, ?, ?, ?, ?, ?)`, t.AccountId, t.Amount, t.TransactionType, t.TransactionDate,
+		t.Currency, t.OriginalAmount, t.OriginalCurrency, t.FxRate, t.ReversalOf)
+	if err != nil {
+		logger.Error("Error while saving reversal transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
 	t.TransactionId = strconv.FormatInt(transactionId, 10)
 
-	// updating the transaction struct with the latest balance
-	t.Amount = account.Amount
-	return &t, nil
+	// only the first reversal marks the original, a concurrent second one stops here
+	result, err := exec(tx, `UPDATE transactions SET reversed_by = ? WHERE transaction_id = ? AND reversed_by IS NULL`,
+		t.TransactionId, r.Original.TransactionId)
+	if err != nil {
+		logger.Error("Error while marking transaction as reversed: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return errs.NewValidationError("Transaction is already reversed")
+	}
+
+	entry := r.JournalEntry()
+	if appErr := postJournalEntry(tx, &entry); appErr != nil {
+		if appErr.Code == http.StatusUnprocessableEntity {
+			return errs.NewValidationError("Reversal would overdraw the account, it can only be forced")
+		}
+		return appErr
+	}
+	_, err = exec(tx, `INSERT INTO transaction_reversals (transaction_id, reversal_id, reason, actor, forced, reversed_at)
+							VALUES (?, ?, ?, ?, ?, ?)`, r.Original.TransactionId, t.TransactionId, r.Reason, r.Actor, r.Forced, r.ReversedAt)
+	if err != nil {
+		logger.Error("Error while saving transaction reversal: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if err = get(tx, &t.Balance, `SELECT amount FROM accounts WHERE account_id = ?`, t.AccountId); err != nil {
+		logger.Error("Error while fetching the new account balance: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return saveEvents(tx, NewTransactionPostedEvent(*t))
+}
+
+func (d AccountRepositoryDB) SaveOverdraftLimit(accountId string, limit money.Amount) *errs.AppError {
+	result, err := exec(d.client, `UPDATE accounts SET overdraft_limit = ? WHERE account_id = ?`, limit, accountId)
+	if err != nil {
+		logger.Error("Error while updating overdraft limit: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		// the limit may already have that value, which is not an error
+		if _, appErr := d.FindBy(accountId); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
 }
 
 func (d AccountRepositoryDB) FindBy(accountId string) (*Account, *errs.AppError) {
-	sqlGetAccount := "SELECT account_id, customer_id, opening_date, account_type, amount from accounts where account_id = ?"
+	sqlGetAccount := "SELECT account_id, customer_id, opening_date, account_type, currency, amount, status, overdraft_limit from accounts where account_id = ?"
 	var account Account
-	err := d.client.Get(&account, sqlGetAccount, accountId)
+	err := get(d.client, &account, sqlGetAccount, accountId)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Account not found")
+		}
 		logger.Error("Error while fetching account information: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 	return &account, nil
 }
 
+// FindTransactions : Returns the transactions of an account matching the filter, newest first
+func (d AccountRepositoryDB) FindTransactions(f TransactionFilter) ([]Transaction, *errs.AppError) {
+	conditions := []string{"account_id = ?"}
+	args := []interface{}{f.AccountId}
+
+	if f.From != "" {
+		conditions = append(conditions, "transaction_date >= ?")
+		args = append(args, f.From)
+	}
+	if f.To != "" {
+		conditions = append(conditions, "transaction_date < ?")
+		args = append(args, f.To)
+	}
+	if f.TransactionType != "" {
+		conditions = append(conditions, "transaction_type = ?")
+		args = append(args, f.TransactionType)
+	}
+	if f.MinAmount != nil {
+		conditions = append(conditions, "amount >= ?")
+		args = append(args, *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		conditions = append(conditions, "amount <= ?")
+		args = append(args, *f.MaxAmount)
+	}
+	if f.BeforeId > 0 {
+		conditions = append(conditions, "transaction_id < ?")
+		args = append(args, f.BeforeId)
+	}
+
+	sqlFind := "SELECT " + transactionColumns + " FROM transactions WHERE " +
+		strings.Join(conditions, " AND ") + " ORDER BY transaction_id DESC LIMIT ?"
+	args = append(args, f.Limit)
+
+	transactions := make([]Transaction, 0)
+	if err := selectAll(d.client, &transactions, sqlFind, args...); err != nil {
+		logger.Error("Error while querying transactions table: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return transactions, nil
+}
+
+func (d AccountRepositoryDB) FindTransaction(transactionId string) (*Transaction, *errs.AppError) {
+	var t Transaction
+	err := get(d.client, &t, "SELECT "+transactionColumns+" FROM transactions WHERE transaction_id = ?", transactionId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Transaction not found")
+		}
+		logger.Error("Error while fetching transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &t, nil
+}
+
+// FindTransactionsBetween : Returns the transactions of an account from the start date (inclusive) to the end
+// date (exclusive) oldest first, an empty end date means up to now
+func (d AccountRepositoryDB) FindTransactionsBetween(accountId string, from string, to string) ([]Transaction, *errs.AppError) {
+	sqlFind := `SELECT ` + transactionColumns + `
+					FROM transactions WHERE account_id = ? AND transaction_date >= ? AND (? = '' OR transaction_date < ?)
+					ORDER BY transaction_date, transaction_id`
+	transactions := make([]Transaction, 0)
+	if err := selectAll(d.client, &transactions, sqlFind, accountId, from, to, to); err != nil {
+		logger.Error("Error while querying transactions table: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return transactions, nil
+}
+
 // NewAccountRepositoryDB : Returns the account repository
 func NewAccountRepositoryDB(dbClient *sqlx.DB) AccountRepositoryDB {
 	return AccountRepositoryDB{dbClient}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/money"
+	"database/sql"
+	"net/http"
+	"strconv"
+)
+
+// AccountRepositoryStub : Accounts, transactions and the ledger kept in a MemoryStore, every save is all or nothing
+type AccountRepositoryStub struct {
+	store *MemoryStore
+}
+
+func (s AccountRepositoryStub) Save(a Account) (*Account, *errs.AppError) {
+	a.AccountId = ""
+	err := s.store.write(func(t *memoryTables) *errs.AppError {
+		return t.saveAccount(&a)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s AccountRepositoryStub) SaveTransaction(tr Transaction) (*Transaction, *errs.AppError) {
+	err := s.store.write(func(t *memoryTables) *errs.AppError {
+		return t.saveTransaction(&tr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tr, nil
+}
+
+func (s AccountRepositoryStub) SaveTransfer(tr Transfer) (*Transfer, *errs.AppError) {
+	err := s.store.write(func(t *memoryTables) *errs.AppError {
+		return t.saveTransferLegs(&tr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tr, nil
+}
+
+func (s AccountRepositoryStub) FindBy(accountId string) (*Account, *errs.AppError) {
+	var found *Account
+	s.store.read(func(t *memoryTables) {
+		if a := t.account(accountId); a != nil {
+			copied := *a
+			found = &copied
+		}
+	})
+	if found == nil {
+		return nil, errs.NewNotFoundError("Account not found")
+	}
+	return found, nil
+}
+
+func (s AccountRepositoryStub) SaveOverdraftLimit(accountId string, limit money.Amount) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		a := t.account(accountId)
+		if a == nil {
+			return errs.NewNotFoundError("Account not found")
+		}
+		a.OverdraftLimit = limit
+		return nil
+	})
+}
+
+func (s AccountRepositoryStub) SaveStatusChange(c AccountStatusChange, payout *Transfer) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		if payout != nil {
+			if err := t.saveTransferLegs(payout); err != nil {
+				return err
+			}
+		}
+		a := t.account(c.AccountId)
+		if a == nil || a.Status != c.FromStatus || (c.ToStatus == AccountStatusClosed && a.Amount != 0) {
+			if c.ToStatus == AccountStatusClosed {
+				return errs.NewValidationError("Account balance should be zero to close it")
+			}
+			return errs.NewValidationError("Account status was changed by another request, try again")
+		}
+		a.Status = c.ToStatus
+		t.statusChanges = append(t.statusChanges, c)
+		t.saveEvents(NewAccountStatusChangedEvent(c))
+		return nil
+	})
+}
+
+func (s AccountRepositoryStub) SaveReversal(r TransactionReversal) (*Transaction, *errs.AppError) {
+	err := s.store.write(func(t *memoryTables) *errs.AppError {
+		reversal := &r.Reversal
+		reversal.TransactionId = t.nextId("transactions")
+		original := t.transaction(r.Original.TransactionId)
+		if original == nil || original.ReversedBy.Valid {
+			return errs.NewValidationError("Transaction is already reversed")
+		}
+		original.ReversedBy = sql.NullString{String: reversal.TransactionId, Valid: true}
+		t.transactions = append(t.transactions, *reversal)
+
+		entry := r.JournalEntry()
+		if err := t.postJournalEntry(&entry); err != nil {
+			if err.Code == http.StatusUnprocessableEntity {
+				return errs.NewValidationError("Reversal would overdraw the account, it can only be forced")
+			}
+			return err
+		}
+		t.reversals = append(t.reversals, r)
+		reversal.Balance = t.balance(reversal.AccountId)
+		t.saveEvents(NewTransactionPostedEvent(*reversal))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Reversal, nil
+}
+
+func (s AccountRepositoryStub) FindTransaction(transactionId string) (*Transaction, *errs.AppError) {
+	var found *Transaction
+	s.store.read(func(t *memoryTables) {
+		if tr := t.transaction(transactionId); tr != nil {
+			copied := *tr
+			copied.Balance = nil
+			found = &copied
+		}
+	})
+	if found == nil {
+		return nil, errs.NewNotFoundError("Transaction not found")
+	}
+	return found, nil
+}
+
+// FindTransactions : Returns the transactions of an account matching the filter, newest first
+func (s AccountRepositoryStub) FindTransactions(f TransactionFilter) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	s.store.read(func(t *memoryTables) {
+		// transactions are kept in id order
+		for i := len(t.transactions) - 1; i >= 0 && len(transactions) < f.Limit; i-- {
+			tr := t.transactions[i]
+			id, _ := strconv.ParseInt(tr.TransactionId, 10, 64)
+			switch {
+			case tr.AccountId != f.AccountId,
+				f.From != "" && tr.TransactionDate < f.From,
+				f.To != "" && tr.TransactionDate >= f.To,
+				f.TransactionType != "" && tr.TransactionType != f.TransactionType,
+				f.MinAmount != nil && tr.Amount < *f.MinAmount,
+				f.MaxAmount != nil && tr.Amount > *f.MaxAmount,
+				f.BeforeId > 0 && id >= f.BeforeId:
+				continue
+			}
+			tr.Balance = nil
+			transactions = append(transactions, tr)
+		}
+	})
+	return transactions, nil
+}
+
+// FindTransactionsBetween : Returns the transactions of an account from the start date (inclusive) to the end
+// date (exclusive) oldest first, an empty end date means up to now
+func (s AccountRepositoryStub) FindTransactionsBetween(accountId string, from string, to string) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, tr := range t.transactions {
+			if tr.AccountId == accountId && tr.TransactionDate >= from && (to == "" || tr.TransactionDate < to) {
+				tr.Balance = nil
+				transactions = append(transactions, tr)
+			}
+		}
+	})
+	sortByDate(transactions)
+	return transactions, nil
+}
+
+// NewAccountRepositoryStub : Returns the account repository working on the store
+func NewAccountRepositoryStub(store *MemoryStore) AccountRepositoryStub {
+	return AccountRepositoryStub{store}
+}
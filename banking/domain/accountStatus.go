@@ -0,0 +1,36 @@
+package domain
+
+import "banking/errs"
+
+// AccountStatusChange : Audit record of an account being frozen, unfrozen or closed
+type AccountStatusChange struct {
+	AccountId  string `db:"account_id"`
+	FromStatus string `db:"from_status"`
+	ToStatus   string `db:"to_status"`
+	Reason     string `db:"reason"`
+	Actor      string `db:"actor"`
+	ChangedAt  string `db:"changed_at"`
+}
+
+// NewAccountStatusChange : Checks the transition is allowed from the current account status
+//
+//	active -> frozen, frozen -> active, active or frozen -> closed
+func NewAccountStatusChange(a Account, toStatus string, reason string, actor string, changedAt string) (*AccountStatusChange, *errs.AppError) {
+	if a.Status == AccountStatusClosed {
+		return nil, errs.NewValidationError("Account is closed")
+	}
+	if a.Status == toStatus {
+		return nil, errs.NewValidationError("Account is already " + a.AsStatusText())
+	}
+	if toStatus == AccountStatusActive && a.Status != AccountStatusFrozen {
+		return nil, errs.NewValidationError("Only frozen accounts can be unfrozen")
+	}
+	return &AccountStatusChange{
+		AccountId:  a.AccountId,
+		FromStatus: a.Status,
+		ToStatus:   toStatus,
+		Reason:     reason,
+		Actor:      actor,
+		ChangedAt:  changedAt,
+	}, nil
+}
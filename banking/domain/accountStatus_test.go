@@ -0,0 +1,44 @@
+package domain
+
+import "testing"
+
+func Test_account_status_transitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		allowed bool
+	}{
+		{"freeze an active account", AccountStatusActive, AccountStatusFrozen, true},
+		{"unfreeze a frozen account", AccountStatusFrozen, AccountStatusActive, true},
+		{"close an active account", AccountStatusActive, AccountStatusClosed, true},
+		{"close a frozen account", AccountStatusFrozen, AccountStatusClosed, true},
+		{"freeze a frozen account", AccountStatusFrozen, AccountStatusFrozen, false},
+		{"unfreeze an active account", AccountStatusActive, AccountStatusActive, false},
+		{"reopen a closed account", AccountStatusClosed, AccountStatusActive, false},
+		{"freeze a closed account", AccountStatusClosed, AccountStatusFrozen, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := Account{AccountId: "2000", Status: tc.from}
+			_, err := NewAccountStatusChange(a, tc.to, "reason", "admin", "2021-01-01 00:00:00")
+			if (err == nil) != tc.allowed {
+				t.Errorf("allowed = %v, want %v", err == nil, tc.allowed)
+			}
+		})
+	}
+}
+
+func Test_frozen_account_only_takes_deposits(t *testing.T) {
+	a := Account{Status: AccountStatusFrozen}
+	if a.CanPost(false) != nil {
+		t.Error("frozen account should take deposits")
+	}
+	if a.CanPost(true) == nil {
+		t.Error("frozen account should not allow withdrawals")
+	}
+	closed := Account{Status: AccountStatusClosed}
+	if closed.CanPost(false) == nil {
+		t.Error("closed account should not take deposits")
+	}
+}
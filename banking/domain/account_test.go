@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"banking/money"
+	"testing"
+)
+
+func Test_should_allow_withdrawals_down_to_the_overdraft_limit(t *testing.T) {
+	// Arrange
+	a := Account{Amount: money.FromUnits(100), OverdraftLimit: money.FromUnits(500)}
+	// Act & Assert
+	if a.AvailableBalance() != money.FromUnits(600) {
+		t.Errorf("Failed while matching the available balance, got %v", a.AvailableBalance())
+	}
+	if !a.CanWithdraw(money.FromUnits(600)) {
+		t.Error("A withdrawal of the whole available balance should be allowed")
+	}
+	if a.CanWithdraw(money.FromUnits(600) + 1) {
+		t.Error("A withdrawal over the overdraft limit should not be allowed")
+	}
+}
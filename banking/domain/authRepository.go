@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type AuthRepository interface {
@@ -13,11 +14,13 @@ type AuthRepository interface {
 }
 
 type RemoteAuthRepository struct {
+	// baseURL : Where banking-auth listens, like http://localhost:8081
+	baseURL string
 }
 
 func (r RemoteAuthRepository) IsAuthorized(token string, routeName string, vars map[string]string) bool {
 
-	u := buildVerifyURL(token, routeName, vars)
+	u := buildVerifyURL(r.baseURL, token, routeName, vars)
 
 	if response, err := http.Get(u); err != nil {
 		fmt.Println("Error while sending..." + err.Error())
@@ -41,9 +44,13 @@ func (r RemoteAuthRepository) IsAuthorized(token string, routeName string, vars
               &account_id={account id from current route if available}
   Sample: /auth/verify?token=aaaa.bbbb.cccc&routeName=MakeTransaction&customer_id=2000&account_id=95470
 */
-func buildVerifyURL(token string, routeName string, vars map[string]string) string {
-	// hardcoded auth server url
-	u := url.URL{Host: "localhost:8081", Path: "/auth/verify", Scheme: "http"}
+func buildVerifyURL(baseURL string, token string, routeName string, vars map[string]string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		// the config validates the url, this only guards other callers
+		u = &url.URL{Scheme: "http", Host: "localhost:8081"}
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/auth/verify"
 	q := u.Query()
 	// add the token and route name params
 	q.Add("token", token)
@@ -57,6 +64,6 @@ func buildVerifyURL(token string, routeName string, vars map[string]string) stri
 	return u.String()
 }
 
-func NewAuthRepository() RemoteAuthRepository {
-	return RemoteAuthRepository{}
+func NewAuthRepository(baseURL string) RemoteAuthRepository {
+	return RemoteAuthRepository{baseURL}
 }
@@ -0,0 +1,11 @@
+package domain
+
+import "testing"
+
+func Test_should_build_the_verify_url_on_the_configured_auth_server(t *testing.T) {
+	u := buildVerifyURL("https://auth.example.com/api/", "aaaa.bbbb.cccc", "GetCustomer", map[string]string{"customer_id": "2000"})
+
+	if u != "https://auth.example.com/api/auth/verify?customer_id=2000&routeName=GetCustomer&token=aaaa.bbbb.cccc" {
+		t.Error("Invalid verify url: " + u)
+	}
+}
@@ -5,6 +5,12 @@ import (
 	"banking/errs"
 )
 
+// Customer status codes, stored in customers.status
+const (
+	CustomerStatusInactive = "0"
+	CustomerStatusActive   = "1"
+)
+
 // Customer : Bussiness Object
 type Customer struct {
 	ID          string `db:"customer_id"`
@@ -30,14 +36,20 @@ func (c Customer) ToDTO() dto.CustomerResponse {
 // AsStatusText : Return the number for status as a new string
 func (c Customer) AsStatusText() string {
 	statusText := "Active"
-	if c.Status == "0" {
+	if c.Status == CustomerStatusInactive {
 		statusText = "Inactive"
 	}
 	return statusText
 }
 
+//go:generate mockgen -destination=../mocks/domain/mockCustomerRepository.go -package=domain banking/domain CustomerRepository
+
 // CustomerRepository : Secondary Port, boundary of the domain
 type CustomerRepository interface {
-	FindAll(status string) ([]Customer, *errs.AppError)
+	FindAll(filter CustomerFilter) ([]Customer, *errs.AppError)
 	ById(string) (*Customer, *errs.AppError)
+	Save(Customer) (*Customer, *errs.AppError)
+	Update(Customer) *errs.AppError
+	// Deactivate : Marks an active customer as inactive, NotFound when there is no active customer with the id
+	Deactivate(id string) *errs.AppError
 }
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// CustomerFilter : Search, sorting and keyset pagination for the customer list
+type CustomerFilter struct {
+	Status string
+	// Name matches anywhere in the name, City the whole city and Zipcode its beginning
+	Name     string
+	City     string
+	Zipcode  string
+	BornFrom string
+	BornTo   string
+	// SortBy is one of the customers columns in dto.CustomerSortFields, the customer id breaks ties
+	SortBy     string
+	Descending bool
+	After      *CustomerCursor
+	Limit      int
+}
+
+// CustomerCursor : Position of the last customer of a page for the sort it was read with
+type CustomerCursor struct {
+	SortBy string `json:"s"`
+	Value  string `json:"v"`
+	Id     int64  `json:"id"`
+}
+
+// SortValue : Returns the value of the customer field the list is sorted by
+func (c Customer) SortValue(sortBy string) string {
+	switch sortBy {
+	case "name":
+		return c.Name
+	case "city":
+		return c.City
+	case "zipcode":
+		return c.Zipcode
+	case "date_of_birth":
+		return c.DateofBirth
+	}
+	return c.ID
+}
+
+// Cursor : Returns the opaque pagination cursor pointing right after this customer
+func (c Customer) Cursor(sortBy string) string {
+	id, _ := strconv.ParseInt(c.ID, 10, 64)
+	raw, _ := json.Marshal(CustomerCursor{SortBy: sortBy, Value: c.SortValue(sortBy), Id: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// ParseCustomerCursor : Returns the position encoded into a pagination cursor, which must have been
+// created for the same sort field
+func ParseCustomerCursor(cursor string, sortBy string) (*CustomerCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var c CustomerCursor
+	if err = json.Unmarshal(raw, &c); err != nil || c.Id <= 0 {
+		return nil, errors.New("invalid cursor")
+	}
+	if c.SortBy != sortBy {
+		return nil, errors.New("cursor was created for another sort")
+	}
+	return &c, nil
+}
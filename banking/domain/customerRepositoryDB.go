@@ -4,9 +4,13 @@ import (
 	"banking/errs"
 	"banking/logger"
 	"database/sql"
+	"strconv"
+	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 // CustomerRepositoryDb : Repository for querying the database
@@ -14,34 +18,81 @@ type CustomerRepositoryDb struct {
 	client *sqlx.DB
 }
 
-// FindAll : Queries the database for the list of customers.
-func (d CustomerRepositoryDb) FindAll(status string) ([]Customer, *errs.AppError) {
-	var err error
-	var findAllSQL string
-	// Create a Customers slice
-	customers := make([]Customer, 0)
-	if status != "" {
-		findAllSQL = "SELECT customer_id, name, city, zipcode, date_of_birth, status FROM customers WHERE status=?;"
-		err = d.client.Select(&customers, findAllSQL, status)
+// FindAll : Queries the database for a page of customers matching the filter.
+func (d CustomerRepositoryDb) FindAll(f CustomerFilter) ([]Customer, *errs.AppError) {
+	conditions := []string{"1 = 1"}
+	args := []interface{}{}
 
-	} else {
+	if f.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, f.Status)
+	}
+	if f.Name != "" {
+		conditions = append(conditions, "name LIKE ? ESCAPE '!'")
+		args = append(args, "%"+escapeLike(f.Name)+"%")
+	}
+	if f.City != "" {
+		conditions = append(conditions, "LOWER(city) = LOWER(?)")
+		args = append(args, f.City)
+	}
+	if f.Zipcode != "" {
+		conditions = append(conditions, "zipcode LIKE ? ESCAPE '!'")
+		args = append(args, escapeLike(f.Zipcode)+"%")
+	}
+	if f.BornFrom != "" {
+		conditions = append(conditions, "date_of_birth >= ?")
+		args = append(args, f.BornFrom)
+	}
+	if f.BornTo != "" {
+		conditions = append(conditions, "date_of_birth <= ?")
+		args = append(args, f.BornTo)
+	}
 
-		findAllSQL = "SELECT customer_id, name, city, zipcode, date_of_birth, status FROM customers;"
-		err = d.client.Select(&customers, findAllSQL)
+	// the sort column comes from a fixed list, so it is safe to put it in the query
+	sortBy, direction, compare := "customer_id", "ASC", ">"
+	if f.SortBy != "" {
+		sortBy = f.SortBy
 	}
-	if err != nil {
+	if f.Descending {
+		direction, compare = "DESC", "<"
+	}
+	if f.After != nil {
+		if sortBy == "customer_id" {
+			conditions = append(conditions, "customer_id "+compare+" ?")
+			args = append(args, f.After.Id)
+		} else {
+			conditions = append(conditions, "("+sortBy+" "+compare+" ? OR ("+sortBy+" = ? AND customer_id "+compare+" ?))")
+			args = append(args, f.After.Value, f.After.Value, f.After.Id)
+		}
+	}
+	orderBy := "customer_id " + direction
+	if sortBy != "customer_id" {
+		orderBy = sortBy + " " + direction + ", " + orderBy
+	}
+
+	findAllSQL := "SELECT customer_id, name, city, zipcode, date_of_birth, status FROM customers WHERE " +
+		strings.Join(conditions, " AND ") + " ORDER BY " + orderBy + " LIMIT ?"
+	args = append(args, f.Limit)
+
+	customers := make([]Customer, 0)
+	if err := selectAll(d.client, &customers, findAllSQL, args...); err != nil {
 		logger.Error("Error while querying customer table " + err.Error())
-		return nil, errs.NewUnexpectedError("Error while querying customer table " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 	return customers, nil
 }
 
+// escapeLike : Makes the LIKE wildcards in the search text match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
 // ById : Returns a single customer by his id.
 func (d CustomerRepositoryDb) ById(id string) (*Customer, *errs.AppError) {
 	// Create the query
 	customerSQL := "SELECT customer_id, name, city, zipcode, date_of_birth, status FROM customers WHERE customer_id = ?;"
 	var c Customer
-	err := d.client.Get(&c, customerSQL, id)
+	err := get(d.client, &c, customerSQL, id)
 	if err != nil {
 		// If no customer is found at all
 		if err == sql.ErrNoRows {
@@ -54,6 +105,42 @@ func (d CustomerRepositoryDb) ById(id string) (*Customer, *errs.AppError) {
 	return &c, nil
 }
 
+// Save : Inserts a new customer and returns it with the generated id
+func (d CustomerRepositoryDb) Save(c Customer) (*Customer, *errs.AppError) {
+	sqlInsert := "INSERT INTO customers (name, city, zipcode, date_of_birth, status) VALUES (?, ?, ?, ?, ?)"
+	id, err := insert(d.client, "customer_id", sqlInsert, c.Name, c.City, c.Zipcode, c.DateofBirth, c.Status)
+	if err != nil {
+		logger.Error("Error while creating new customer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected error from database")
+	}
+	c.ID = strconv.FormatInt(id, 10)
+	return &c, nil
+}
+
+// Update : Replaces the customer details, the status is changed through Deactivate
+func (d CustomerRepositoryDb) Update(c Customer) *errs.AppError {
+	sqlUpdate := "UPDATE customers SET name = ?, city = ?, zipcode = ?, date_of_birth = ? WHERE customer_id = ?"
+	_, err := exec(d.client, sqlUpdate, c.Name, c.City, c.Zipcode, c.DateofBirth, c.ID)
+	if err != nil {
+		logger.Error("Error while updating customer: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected error from database")
+	}
+	return nil
+}
+
+func (d CustomerRepositoryDb) Deactivate(id string) *errs.AppError {
+	result, err := exec(d.client, "UPDATE customers SET status = ? WHERE customer_id = ? AND status = ?",
+		CustomerStatusInactive, id, CustomerStatusActive)
+	if err != nil {
+		logger.Error("Error while deactivating customer: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected error from database")
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return errs.NewNotFoundError("Active customer not found")
+	}
+	return nil
+}
+
 // NewCustomerRepositoryDb : Creates a sql client and returns the CustomerRepositoryDB
 func NewCustomerRepositoryDb(dbClient *sqlx.DB) CustomerRepositoryDb {
 	return CustomerRepositoryDb{dbClient}
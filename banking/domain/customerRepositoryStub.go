@@ -1,23 +1,141 @@
 package domain
 
-// Stub = Mock Adapter
+import (
+	"banking/errs"
+	"sort"
+	"strconv"
+	"strings"
+)
 
-// CustomerRepositoryStub : Contains a dummy list of Customers
+// Stub = in-memory adapter
+
+// CustomerRepositoryStub : Customers kept in a MemoryStore
 type CustomerRepositoryStub struct {
-	customers []Customer
+	store *MemoryStore
+}
+
+// FindAll : Same search, sort and keyset pagination as the database repository. Text columns compare
+// case-insensitively like the default MySQL collation.
+func (s CustomerRepositoryStub) FindAll(f CustomerFilter) ([]Customer, *errs.AppError) {
+	matches := make([]Customer, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, c := range t.customers {
+			if f.matches(c) {
+				matches = append(matches, c)
+			}
+		}
+	})
+	sortBy := f.SortBy
+	if sortBy == "" {
+		sortBy = "customer_id"
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return compareCustomers(matches[i].SortValue(sortBy), matches[i].ID, matches[j].SortValue(sortBy), matches[j].ID, sortBy) < 0
+	})
+	if f.Descending {
+		for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+			matches[i], matches[j] = matches[j], matches[i]
+		}
+	}
+	page := make([]Customer, 0, f.Limit)
+	for _, c := range matches {
+		if f.After != nil {
+			cmp := compareCustomers(c.SortValue(sortBy), c.ID, f.After.Value, strconv.FormatInt(f.After.Id, 10), sortBy)
+			if (!f.Descending && cmp <= 0) || (f.Descending && cmp >= 0) {
+				continue
+			}
+		}
+		if len(page) == f.Limit {
+			break
+		}
+		page = append(page, c)
+	}
+	return page, nil
 }
 
-// FindAll : Returns the customers slice.
-func (s CustomerRepositoryStub) FindAll() ([]Customer, error) {
-	return s.customers, nil
+func (f CustomerFilter) matches(c Customer) bool {
+	switch {
+	case f.Status != "" && c.Status != f.Status,
+		f.Name != "" && !strings.Contains(strings.ToLower(c.Name), strings.ToLower(f.Name)),
+		f.City != "" && !strings.EqualFold(c.City, f.City),
+		f.Zipcode != "" && !strings.HasPrefix(c.Zipcode, f.Zipcode),
+		f.BornFrom != "" && c.DateofBirth < f.BornFrom,
+		f.BornTo != "" && c.DateofBirth > f.BornTo:
+		return false
+	}
+	return true
 }
 
-// NewCustomerRepositoryStub : Helper function for creating a new list of customers and returns the customer repository stub
-func NewCustomerRepositoryStub() CustomerRepositoryStub {
-	customers := []Customer{
-		{ID: "1001", Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205", DateofBirth: "1970-01-01", Status: "1"},
-		{ID: "1002", Name: "Jonathan Joestar", City: "England", Zipcode: "95457", DateofBirth: "1868-04-04", Status: "0"},
-		{ID: "1003", Name: "Joseph Joestar", City: "England", Zipcode: "95825", DateofBirth: "1920-09-27", Status: "1"},
+// compareCustomers : Orders by the sort value and then by id, ids compare as numbers
+func compareCustomers(value string, id string, otherValue string, otherId string, sortBy string) int {
+	if sortBy != "customer_id" {
+		if cmp := strings.Compare(strings.ToLower(value), strings.ToLower(otherValue)); cmp != 0 {
+			return cmp
+		}
+	}
+	a, _ := strconv.ParseInt(id, 10, 64)
+	b, _ := strconv.ParseInt(otherId, 10, 64)
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
 	}
-	return CustomerRepositoryStub{customers}
+	return 0
+}
+
+// ById : Returns a single customer by its id.
+func (s CustomerRepositoryStub) ById(id string) (*Customer, *errs.AppError) {
+	var found *Customer
+	s.store.read(func(t *memoryTables) {
+		for _, c := range t.customers {
+			if c.ID == id {
+				found = &c
+				return
+			}
+		}
+	})
+	if found == nil {
+		return nil, errs.NewNotFoundError("Customer not found")
+	}
+	return found, nil
+}
+
+func (s CustomerRepositoryStub) Save(c Customer) (*Customer, *errs.AppError) {
+	s.store.write(func(t *memoryTables) *errs.AppError {
+		c.ID = t.nextId("customers")
+		t.customers = append(t.customers, c)
+		return nil
+	})
+	return &c, nil
+}
+
+// Update : Replaces the customer details, the status is changed through Deactivate
+func (s CustomerRepositoryStub) Update(c Customer) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for i := range t.customers {
+			if t.customers[i].ID == c.ID {
+				c.Status = t.customers[i].Status
+				t.customers[i] = c
+			}
+		}
+		return nil
+	})
+}
+
+func (s CustomerRepositoryStub) Deactivate(id string) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for i := range t.customers {
+			if t.customers[i].ID == id && t.customers[i].Status == CustomerStatusActive {
+				t.customers[i].Status = CustomerStatusInactive
+				return nil
+			}
+		}
+		return errs.NewNotFoundError("Active customer not found")
+	})
+}
+
+// NewCustomerRepositoryStub : Returns the customer repository working on the store
+func NewCustomerRepositoryStub(store *MemoryStore) CustomerRepositoryStub {
+	return CustomerRepositoryStub{store}
 }
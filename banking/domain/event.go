@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"banking/dto"
+	"banking/errs"
+	"database/sql"
+	"encoding/json"
+)
+
+// Domain event types written to the outbox
+const (
+	EventAccountOpened        = "AccountOpened"
+	EventTransactionPosted    = "TransactionPosted"
+	EventAccountStatusChanged = "AccountStatusChanged"
+)
+
+// Event : A change to an account, stored in the outbox by the same database transaction as the change itself,
+// so an event exists if and only if the change was committed
+type Event struct {
+	EventId     string          `db:"event_id"`
+	EventType   string          `db:"event_type"`
+	AggregateId string          `db:"aggregate_id"`
+	Payload     json.RawMessage `db:"payload"`
+	OccurredAt  string          `db:"occurred_at"`
+	PublishedAt sql.NullString  `db:"published_at"`
+	Attempts    int             `db:"attempts"`
+	LastError   sql.NullString  `db:"last_error"`
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockOutboxRepository.go -package=domain banking/domain OutboxRepository
+type OutboxRepository interface {
+	// FindUnpublished : Events not delivered yet and tried fewer than maxAttempts times, in the order they were written.
+	// The others stay in the outbox as dead letters with their last error.
+	FindUnpublished(limit int, maxAttempts int) ([]Event, *errs.AppError)
+	MarkPublished(eventId string, publishedAt string) *errs.AppError
+	// RecordFailure : Counts a failed delivery, the event stays in the outbox and is retried by the next run
+	RecordFailure(eventId string, reason string) *errs.AppError
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockEventPublisher.go -package=domain banking/domain EventPublisher
+type EventPublisher interface {
+	// Publish : Delivers one event, consumers get each event at least once and should dedupe on the event id
+	Publish(e Event) error
+}
+
+func newEvent(eventType string, aggregateId string, occurredAt string, data interface{}) Event {
+	// the payloads are plain structs of strings and numbers, encoding them cannot fail
+	payload, _ := json.Marshal(data)
+	return Event{EventType: eventType, AggregateId: aggregateId, Payload: payload, OccurredAt: occurredAt}
+}
+
+// NewAccountOpenedEvent : The account as it was opened, with the opening deposit as its balance
+func NewAccountOpenedEvent(a Account) Event {
+	return newEvent(EventAccountOpened, a.AccountId, a.OpeningDate, a.ToDto())
+}
+
+// NewTransactionPostedEvent : A deposit, withdrawal, transfer leg or reversal posted to the account
+func NewTransactionPostedEvent(t Transaction) Event {
+	return newEvent(EventTransactionPosted, t.AccountId, t.TransactionDate, t.ToDto())
+}
+
+// NewAccountStatusChangedEvent : An account frozen, unfrozen or closed
+func NewAccountStatusChangedEvent(c AccountStatusChange) Event {
+	return newEvent(EventAccountStatusChanged, c.AccountId, c.ChangedAt, dto.AccountStatusChangedEvent{
+		AccountId:  c.AccountId,
+		FromStatus: statusText(c.FromStatus),
+		ToStatus:   statusText(c.ToStatus),
+		Reason:     c.Reason,
+		Actor:      c.Actor,
+		ChangedAt:  c.ChangedAt,
+	})
+}
+
+func statusText(status string) string {
+	return Account{Status: status}.AsStatusText()
+}
+
+// ToDto : The message handed to subscribers
+func (e Event) ToDto() dto.EventMessage {
+	return dto.EventMessage{
+		EventId:     e.EventId,
+		EventType:   e.EventType,
+		AggregateId: e.AggregateId,
+		OccurredAt:  e.OccurredAt,
+		Payload:     e.Payload,
+	}
+}
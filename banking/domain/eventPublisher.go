@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// InMemoryPublisher : Keeps the published events in memory, for tests
+type InMemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (p *InMemoryPublisher) Publish(e Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return nil
+}
+
+// Events : Copy of the events published so far, in order
+func (p *InMemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	events := make([]Event, len(p.events))
+	copy(events, p.events)
+	return events
+}
+
+// WriterPublisher : Writes every event as one JSON line, to stdout or a file for local use
+type WriterPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (p *WriterPublisher) Publish(e Event) error {
+	line, err := json.Marshal(e.ToDto())
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.w.Write(append(line, '\n'))
+	return err
+}
+
+// NewWriterPublisher : Returns a publisher writing to w
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// NewFilePublisher : Returns a publisher appending to the file at path, the file is created when missing
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterPublisher(f), nil
+}
+
+// MultiPublisher : Publishes every event to each publisher in turn. When one fails the event is retried on all of
+// them, so each publisher may see it more than once.
+type MultiPublisher []EventPublisher
+
+func (m MultiPublisher) Publish(e Event) error {
+	for _, p := range m {
+		if err := p.Publish(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func Test_should_write_each_event_as_one_json_line(t *testing.T) {
+	var out bytes.Buffer
+	publisher := NewWriterPublisher(&out)
+	change := AccountStatusChange{AccountId: "2000", FromStatus: AccountStatusActive, ToStatus: AccountStatusFrozen,
+		Reason: "lost card", Actor: "admin", ChangedAt: "2021-01-31 10:00:00"}
+	e := NewAccountStatusChangedEvent(change)
+	e.EventId = "42"
+
+	if err := publisher.Publish(e); err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSuffix(out.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("lines = %d, want 1", len(lines))
+	}
+	var message struct {
+		EventId   string `json:"event_id"`
+		EventType string `json:"event_type"`
+		Payload   struct {
+			ToStatus string `json:"to_status"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(lines[0], &message); err != nil {
+		t.Fatal(err)
+	}
+	if message.EventId != "42" || message.EventType != EventAccountStatusChanged || message.Payload.ToStatus != "Frozen" {
+		t.Errorf("unexpected message %s", lines[0])
+	}
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"banking/money"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Screening decisions, from the least to the most severe
+const (
+	ScreeningAllow  = "allow"
+	ScreeningReview = "review"
+	ScreeningDeny   = "deny"
+)
+
+// defaultFraudLookback : How much account history the rules get when the config does not say
+const defaultFraudLookback = 30 * 24 * time.Hour
+
+const fraudTSLayout = "2006-01-02 15:04:05"
+
+// ScreeningContext : What a rule can look at, History holds the account transactions of the lookback window
+type ScreeningContext struct {
+	Account     Account
+	Transaction Transaction
+	History     []Transaction
+	Now         time.Time
+}
+
+// ScreeningResult : The most severe decision of all the rules, with the reasons of the rules that did not allow
+type ScreeningResult struct {
+	Decision string
+	Reasons  []string
+}
+
+// FraudRule : One screening check, returns the decision and why when it is not allow
+type FraudRule interface {
+	Evaluate(ctx ScreeningContext) (decision string, reason string)
+}
+
+// FraudRules : Rules run on every transaction, and how much history they need
+type FraudRules struct {
+	Lookback time.Duration
+	Rules    []FraudRule
+}
+
+// Screen : Runs every rule, deny wins over review and review over allow
+func (f FraudRules) Screen(ctx ScreeningContext) ScreeningResult {
+	result := ScreeningResult{Decision: ScreeningAllow, Reasons: make([]string, 0)}
+	for _, rule := range f.Rules {
+		decision, reason := rule.Evaluate(ctx)
+		if decision == ScreeningAllow {
+			continue
+		}
+		result.Reasons = append(result.Reasons, reason)
+		if severity(decision) > severity(result.Decision) {
+			result.Decision = decision
+		}
+	}
+	return result
+}
+
+func severity(decision string) int {
+	switch decision {
+	case ScreeningDeny:
+		return 2
+	case ScreeningReview:
+		return 1
+	}
+	return 0
+}
+
+// VelocityRule : Too many transactions in a short window
+type VelocityRule struct {
+	Window          time.Duration
+	MaxCount        int
+	TransactionType string
+	Decision        string
+}
+
+func (r VelocityRule) Evaluate(ctx ScreeningContext) (string, string) {
+	if !appliesTo(r.TransactionType, ctx.Transaction) {
+		return ScreeningAllow, ""
+	}
+	since := ctx.Now.Add(-r.Window).Format(fraudTSLayout)
+	count := 1
+	for _, t := range ctx.History {
+		if t.TransactionDate >= since && appliesTo(r.TransactionType, t) {
+			count++
+		}
+	}
+	if count > r.MaxCount {
+		return r.Decision, "More than " + strconv.Itoa(r.MaxCount) + " transactions in " + r.Window.String()
+	}
+	return ScreeningAllow, ""
+}
+
+// UnusualAmountRule : An amount many times the average of the account history
+type UnusualAmountRule struct {
+	Multiplier      float64
+	MinHistory      int
+	TransactionType string
+	Decision        string
+}
+
+func (r UnusualAmountRule) Evaluate(ctx ScreeningContext) (string, string) {
+	if !appliesTo(r.TransactionType, ctx.Transaction) {
+		return ScreeningAllow, ""
+	}
+	var sum money.Amount
+	count := 0
+	for _, t := range ctx.History {
+		if t.TransactionType == ctx.Transaction.TransactionType {
+			sum += t.Amount
+			count++
+		}
+	}
+	// without enough history every amount is unusual, the new account rule covers that case
+	if count == 0 || count < r.MinHistory {
+		return ScreeningAllow, ""
+	}
+	average := float64(sum) / float64(count)
+	if float64(ctx.Transaction.Amount) > average*r.Multiplier {
+		return r.Decision, "Amount is more than " + strconv.FormatFloat(r.Multiplier, 'f', -1, 64) + " times the account average"
+	}
+	return ScreeningAllow, ""
+}
+
+// NewAccountRule : Money moving right after the account was opened
+type NewAccountRule struct {
+	Within          time.Duration
+	MinAmount       money.Amount
+	TransactionType string
+	Decision        string
+}
+
+func (r NewAccountRule) Evaluate(ctx ScreeningContext) (string, string) {
+	if !appliesTo(r.TransactionType, ctx.Transaction) || ctx.Transaction.Amount < r.MinAmount {
+		return ScreeningAllow, ""
+	}
+	opened, err := time.ParseInLocation(fraudTSLayout, strings.Replace(prefix(ctx.Account.OpeningDate, len(fraudTSLayout)), "T", " ", 1), ctx.Now.Location())
+	if err != nil {
+		return ScreeningAllow, ""
+	}
+	if ctx.Now.Sub(opened) < r.Within {
+		return r.Decision, "Account was opened less than " + r.Within.String() + " ago"
+	}
+	return ScreeningAllow, ""
+}
+
+func appliesTo(transactionType string, t Transaction) bool {
+	return transactionType == "" || transactionType == t.TransactionType
+}
+
+func prefix(s string, n int) string {
+	if len(s) < n {
+		return s
+	}
+	return s[:n]
+}
+
+// fraudRuleConfig : One entry of the rules file, the fields used depend on the rule
+type fraudRuleConfig struct {
+	Rule            string       `json:"rule"`
+	Decision        string       `json:"decision"`
+	TransactionType string       `json:"transaction_type"`
+	Window          string       `json:"window"`
+	MaxCount        int          `json:"max_count"`
+	Multiplier      float64      `json:"multiplier"`
+	MinHistory      int          `json:"min_history"`
+	MinAmount       money.Amount `json:"min_amount"`
+}
+
+type fraudRulesConfig struct {
+	Lookback string            `json:"lookback"`
+	Rules    []fraudRuleConfig `json:"rules"`
+}
+
+// fraudRuleBuilders : Rules that can be used in the rules file, by name
+var fraudRuleBuilders = map[string]func(c fraudRuleConfig) (FraudRule, error){
+	"velocity": func(c fraudRuleConfig) (FraudRule, error) {
+		window, err := time.ParseDuration(c.Window)
+		if err != nil || window <= 0 || c.MaxCount <= 0 {
+			return nil, errors.New("velocity needs a window and a max_count")
+		}
+		return VelocityRule{Window: window, MaxCount: c.MaxCount, TransactionType: c.TransactionType, Decision: c.Decision}, nil
+	},
+	"unusual_amount": func(c fraudRuleConfig) (FraudRule, error) {
+		if c.Multiplier <= 1 {
+			return nil, errors.New("unusual_amount needs a multiplier greater than 1")
+		}
+		return UnusualAmountRule{Multiplier: c.Multiplier, MinHistory: c.MinHistory, TransactionType: c.TransactionType, Decision: c.Decision}, nil
+	},
+	"new_account": func(c fraudRuleConfig) (FraudRule, error) {
+		within, err := time.ParseDuration(c.Window)
+		if err != nil || within <= 0 {
+			return nil, errors.New("new_account needs a window")
+		}
+		return NewAccountRule{Within: within, MinAmount: c.MinAmount, TransactionType: c.TransactionType, Decision: c.Decision}, nil
+	},
+}
+
+// DefaultFraudRules : Rules used when no rules file is configured
+func DefaultFraudRules() FraudRules {
+	return FraudRules{
+		Lookback: defaultFraudLookback,
+		Rules: []FraudRule{
+			VelocityRule{Window: time.Hour, MaxCount: 10, Decision: ScreeningReview},
+			VelocityRule{Window: time.Minute, MaxCount: 5, Decision: ScreeningDeny},
+			UnusualAmountRule{Multiplier: 10, MinHistory: 5, TransactionType: WITHDRAWAL, Decision: ScreeningReview},
+			NewAccountRule{Within: 24 * time.Hour, MinAmount: money.FromUnits(1000), TransactionType: WITHDRAWAL, Decision: ScreeningReview},
+		},
+	}
+}
+
+// LoadFraudRules : Reads the rules from a JSON file like
+//
+//	{"lookback": "720h", "rules": [{"rule": "velocity", "window": "1h", "max_count": 10, "decision": "review"}]}
+func LoadFraudRules(path string) (FraudRules, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return FraudRules{}, err
+	}
+	var config fraudRulesConfig
+	if err = json.Unmarshal(data, &config); err != nil {
+		return FraudRules{}, err
+	}
+	rules := FraudRules{Lookback: defaultFraudLookback, Rules: make([]FraudRule, 0, len(config.Rules))}
+	if config.Lookback != "" {
+		if rules.Lookback, err = time.ParseDuration(config.Lookback); err != nil || rules.Lookback <= 0 {
+			return FraudRules{}, errors.New("lookback should be a positive duration like 720h")
+		}
+	}
+	for i, c := range config.Rules {
+		build, ok := fraudRuleBuilders[c.Rule]
+		if !ok {
+			return FraudRules{}, errors.New("unknown fraud rule " + c.Rule)
+		}
+		if c.Decision != ScreeningReview && c.Decision != ScreeningDeny {
+			return FraudRules{}, errors.New("rule " + strconv.Itoa(i+1) + " decision should be review or deny")
+		}
+		rule, err := build(c)
+		if err != nil {
+			return FraudRules{}, errors.New("rule " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		rules.Rules = append(rules.Rules, rule)
+	}
+	return rules, nil
+}
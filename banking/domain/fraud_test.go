@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"banking/money"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_should_screen_a_transaction_with_the_most_severe_decision(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	recent := func(minutes int) Transaction {
+		return Transaction{TransactionType: WITHDRAWAL, Amount: money.FromUnits(10),
+			TransactionDate: now.Add(-time.Duration(minutes) * time.Minute).Format(fraudTSLayout)}
+	}
+	account := Account{AccountId: "2000", OpeningDate: "2025-01-01 10:00:00"}
+	rules := DefaultFraudRules()
+	tests := []struct {
+		name     string
+		account  Account
+		amount   money.Amount
+		history  []Transaction
+		decision string
+	}{
+		{"nothing unusual", account, money.FromUnits(20),
+			[]Transaction{recent(120), recent(180), recent(240), recent(300), recent(360)}, ScreeningAllow},
+		{"too many in an hour", account, money.FromUnits(20),
+			[]Transaction{recent(10), recent(15), recent(20), recent(25), recent(30), recent(35), recent(40), recent(45), recent(50), recent(55)}, ScreeningReview},
+		{"too many in a minute wins over the hourly review", account, money.FromUnits(20),
+			[]Transaction{recent(0), recent(0), recent(0), recent(0), recent(0), recent(30), recent(35), recent(40), recent(45), recent(50)}, ScreeningDeny},
+		{"amount far over the average", account, money.FromUnits(500),
+			[]Transaction{recent(120), recent(180), recent(240), recent(300), recent(360)}, ScreeningReview},
+		{"large withdrawal from a new account", Account{AccountId: "2000", OpeningDate: "2026-03-10 08:00:00"}, money.FromUnits(1500),
+			nil, ScreeningReview},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transaction := Transaction{AccountId: "2000", TransactionType: WITHDRAWAL, Amount: tc.amount}
+			result := rules.Screen(ScreeningContext{Account: tc.account, Transaction: transaction, History: tc.history, Now: now})
+			if result.Decision != tc.decision {
+				t.Errorf("decision = %s, want %s (reasons %v)", result.Decision, tc.decision, result.Reasons)
+			}
+			if tc.decision != ScreeningAllow && len(result.Reasons) == 0 {
+				t.Error("a held or denied transaction should say why")
+			}
+		})
+	}
+}
+
+func Test_should_reject_a_rules_file_with_an_unknown_rule(t *testing.T) {
+	file, err := ioutil.TempFile("", "fraud-rules-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(`{"rules": [{"rule": "velocity", "window": "1h", "max_count": 3, "decision": "review"},
+		{"rule": "geo_ip", "decision": "deny"}]}`)
+	file.Close()
+
+	if _, err := LoadFraudRules(file.Name()); err == nil {
+		t.Error("Test failed while loading a rules file with an unknown rule")
+	}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"banking/dto"
+	"banking/errs"
+	"banking/money"
+)
+
+// FxRate : Exchange rate from BaseCurrency to QuoteCurrency, valid from EffectiveDate until a newer one is loaded
+type FxRate struct {
+	BaseCurrency  string     `db:"base_currency"`
+	QuoteCurrency string     `db:"quote_currency"`
+	Rate          money.Rate `db:"rate"`
+	EffectiveDate string     `db:"effective_date"`
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockFxRateRepository.go -package=domain banking/domain FxRateRepository
+type FxRateRepository interface {
+	Save(rates []FxRate) *errs.AppError
+	FindAll(base string, quote string) ([]FxRate, *errs.AppError)
+	// FindEffective : Returns the latest rate for the pair effective on the date, nil when there is none
+	FindEffective(base string, quote string, date string) (*FxRate, *errs.AppError)
+}
+
+func (r FxRate) ToDto() dto.FxRateResponse {
+	return dto.FxRateResponse{
+		BaseCurrency:  r.BaseCurrency,
+		QuoteCurrency: r.QuoteCurrency,
+		Rate:          r.Rate,
+		EffectiveDate: r.EffectiveDate,
+	}
+}
+
+// FindConversionRate : Returns the rate to convert from one currency to another on the date,
+// falling back to the inverse of the opposite pair when only that one was loaded
+func FindConversionRate(repo FxRateRepository, from string, to string, date string) (money.Rate, *errs.AppError) {
+	if from == to {
+		return money.OneToOne, nil
+	}
+	rate, err := repo.FindEffective(from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	if rate != nil {
+		return rate.Rate, nil
+	}
+	inverse, err := repo.FindEffective(to, from, date)
+	if err != nil {
+		return 0, err
+	}
+	if inverse != nil {
+		return inverse.Rate.Inverse(), nil
+	}
+	return 0, errs.NewValidationError("No exchange rate available from " + from + " to " + to)
+}
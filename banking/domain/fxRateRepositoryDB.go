@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type FxRateRepositoryDB struct {
+	client *sqlx.DB
+}
+
+// Save : Stores the rates in a single transaction, loading a rate again for the same pair and date replaces it
+func (d FxRateRepositoryDB) Save(rates []FxRate) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for fx rates: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	sqlInsert := upsert(d.client.DriverName(), `INSERT INTO fx_rates (base_currency, quote_currency, rate, effective_date) VALUES (?, ?, ?, ?)`,
+		[]string{"base_currency", "quote_currency", "effective_date"}, "rate")
+	for _, r := range rates {
+		_, err = exec(tx, sqlInsert, r.BaseCurrency, r.QuoteCurrency, r.Rate, r.EffectiveDate)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while saving fx rate: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting fx rates: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// FindAll : Returns the loaded rates, optionally only for one base and/or quote currency
+func (d FxRateRepositoryDB) FindAll(base string, quote string) ([]FxRate, *errs.AppError) {
+	rates := make([]FxRate, 0)
+	sqlFind := `SELECT base_currency, quote_currency, rate, effective_date FROM fx_rates
+					WHERE (? = '' OR base_currency = ?) AND (? = '' OR quote_currency = ?)
+					ORDER BY base_currency, quote_currency, effective_date DESC`
+	if err := selectAll(d.client, &rates, sqlFind, base, base, quote, quote); err != nil {
+		logger.Error("Error while querying fx_rates table: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return rates, nil
+}
+
+func (d FxRateRepositoryDB) FindEffective(base string, quote string, date string) (*FxRate, *errs.AppError) {
+	var rate FxRate
+	sqlFind := `SELECT base_currency, quote_currency, rate, effective_date FROM fx_rates
+					WHERE base_currency = ? AND quote_currency = ? AND effective_date <= ?
+					ORDER BY effective_date DESC LIMIT 1`
+	if err := get(d.client, &rate, sqlFind, base, quote, date); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		logger.Error("Error while fetching fx rate: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &rate, nil
+}
+
+// NewFxRateRepositoryDB : Returns the fx rate repository
+func NewFxRateRepositoryDB(dbClient *sqlx.DB) FxRateRepositoryDB {
+	return FxRateRepositoryDB{dbClient}
+}
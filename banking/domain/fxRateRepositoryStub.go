@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"banking/errs"
+	"sort"
+)
+
+// FxRateRepositoryStub : Exchange rates kept in a MemoryStore
+type FxRateRepositoryStub struct {
+	store *MemoryStore
+}
+
+// Save : Loading a rate again for the same pair and date replaces it
+func (s FxRateRepositoryStub) Save(rates []FxRate) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for _, r := range rates {
+			replaced := false
+			for i, existing := range t.fxRates {
+				if existing.BaseCurrency == r.BaseCurrency && existing.QuoteCurrency == r.QuoteCurrency && existing.EffectiveDate == r.EffectiveDate {
+					t.fxRates[i].Rate = r.Rate
+					replaced = true
+				}
+			}
+			if !replaced {
+				t.fxRates = append(t.fxRates, r)
+			}
+		}
+		return nil
+	})
+}
+
+// FindAll : Returns the loaded rates, optionally only for one base and/or quote currency
+func (s FxRateRepositoryStub) FindAll(base string, quote string) ([]FxRate, *errs.AppError) {
+	rates := make([]FxRate, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, r := range t.fxRates {
+			if (base == "" || r.BaseCurrency == base) && (quote == "" || r.QuoteCurrency == quote) {
+				rates = append(rates, r)
+			}
+		}
+	})
+	sort.Slice(rates, func(i, j int) bool {
+		a, b := rates[i], rates[j]
+		if a.BaseCurrency != b.BaseCurrency {
+			return a.BaseCurrency < b.BaseCurrency
+		}
+		if a.QuoteCurrency != b.QuoteCurrency {
+			return a.QuoteCurrency < b.QuoteCurrency
+		}
+		return a.EffectiveDate > b.EffectiveDate
+	})
+	return rates, nil
+}
+
+func (s FxRateRepositoryStub) FindEffective(base string, quote string, date string) (*FxRate, *errs.AppError) {
+	var found *FxRate
+	s.store.read(func(t *memoryTables) {
+		for _, r := range t.fxRates {
+			if r.BaseCurrency == base && r.QuoteCurrency == quote && r.EffectiveDate <= date &&
+				(found == nil || r.EffectiveDate > found.EffectiveDate) {
+				copied := r
+				found = &copied
+			}
+		}
+	})
+	return found, nil
+}
+
+// NewFxRateRepositoryStub : Returns the fx rate repository working on the store
+func NewFxRateRepositoryStub(store *MemoryStore) FxRateRepositoryStub {
+	return FxRateRepositoryStub{store}
+}
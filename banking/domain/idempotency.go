@@ -0,0 +1,27 @@
+package domain
+
+import "banking/errs"
+
+// IdempotencyRecord : A request already processed under an Idempotency-Key, StatusCode stays 0 while it is in flight
+type IdempotencyRecord struct {
+	Key         string `db:"idempotency_key"`
+	Fingerprint string `db:"fingerprint"`
+	StatusCode  int    `db:"status_code"`
+	Response    []byte `db:"response"`
+	CreatedAt   string `db:"created_at"`
+	ExpiresAt   string `db:"expires_at"`
+}
+
+// IsCompleted : Returns true once the response of the original request was stored
+func (r IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockIdempotencyRepository.go -package=domain banking/domain IdempotencyRepository
+type IdempotencyRepository interface {
+	// Reserve : Stores a new in-flight record, when the key is already taken the existing record is returned instead
+	Reserve(record IdempotencyRecord, now string) (*IdempotencyRecord, *errs.AppError)
+	Complete(key string, statusCode int, response []byte) *errs.AppError
+	Release(key string) *errs.AppError
+	DeleteExpired(now string) *errs.AppError
+}
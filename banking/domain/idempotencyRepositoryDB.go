@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type IdempotencyRepositoryDB struct {
+	client *sqlx.DB
+}
+
+// Reserve : Inserts the key, the primary key on idempotency_key makes sure only one request wins it
+func (d IdempotencyRepositoryDB) Reserve(r IdempotencyRecord, now string) (*IdempotencyRecord, *errs.AppError) {
+	// an expired key can be used again
+	if _, err := exec(d.client, `DELETE FROM idempotency_keys WHERE idempotency_key = ? AND expires_at <= ?`, r.Key, now); err != nil {
+		logger.Error("Error while deleting expired idempotency key: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	_, insertErr := exec(d.client, `INSERT INTO idempotency_keys (idempotency_key, fingerprint, status_code, response, created_at, expires_at)
+											values (?, ?, 0, NULL, ?, ?)`, r.Key, r.Fingerprint, r.CreatedAt, r.ExpiresAt)
+	if insertErr == nil {
+		return nil, nil
+	}
+
+	// the insert failed, most likely because the key is taken, so look the existing record up
+	var existing IdempotencyRecord
+	err := get(d.client, &existing, `SELECT idempotency_key, fingerprint, status_code, response, created_at, expires_at
+											FROM idempotency_keys WHERE idempotency_key = ?`, r.Key)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.Error("Error while fetching idempotency key: " + err.Error())
+		} else {
+			logger.Error("Error while saving idempotency key: " + insertErr.Error())
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &existing, nil
+}
+
+// Complete : Stores the response sent for the key so it can be replayed
+func (d IdempotencyRepositoryDB) Complete(key string, statusCode int, response []byte) *errs.AppError {
+	_, err := exec(d.client, `UPDATE idempotency_keys SET status_code = ?, response = ? WHERE idempotency_key = ?`, statusCode, response, key)
+	if err != nil {
+		logger.Error("Error while storing idempotent response: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// Release : Removes an in-flight key so the client can retry it, used when the request failed
+func (d IdempotencyRepositoryDB) Release(key string) *errs.AppError {
+	_, err := exec(d.client, `DELETE FROM idempotency_keys WHERE idempotency_key = ? AND status_code = 0`, key)
+	if err != nil {
+		logger.Error("Error while releasing idempotency key: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// DeleteExpired : Removes every key past its expiry date
+func (d IdempotencyRepositoryDB) DeleteExpired(now string) *errs.AppError {
+	_, err := exec(d.client, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, now)
+	if err != nil {
+		logger.Error("Error while deleting expired idempotency keys: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// NewIdempotencyRepositoryDB : Returns the idempotency key repository
+func NewIdempotencyRepositoryDB(dbClient *sqlx.DB) IdempotencyRepositoryDB {
+	return IdempotencyRepositoryDB{dbClient}
+}
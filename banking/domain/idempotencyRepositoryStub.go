@@ -0,0 +1,61 @@
+package domain
+
+import "banking/errs"
+
+// IdempotencyRepositoryStub : Idempotency keys kept in a MemoryStore
+type IdempotencyRepositoryStub struct {
+	store *MemoryStore
+}
+
+// Reserve : Only one request wins a key, the others get the existing record back
+func (s IdempotencyRepositoryStub) Reserve(r IdempotencyRecord, now string) (*IdempotencyRecord, *errs.AppError) {
+	var existing *IdempotencyRecord
+	s.store.write(func(t *memoryTables) *errs.AppError {
+		if record, ok := t.idempotency[r.Key]; ok && record.ExpiresAt > now {
+			existing = &record
+			return nil
+		}
+		r.StatusCode, r.Response = 0, nil
+		t.idempotency[r.Key] = r
+		return nil
+	})
+	return existing, nil
+}
+
+// Complete : Stores the response sent for the key so it can be replayed
+func (s IdempotencyRepositoryStub) Complete(key string, statusCode int, response []byte) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		if record, ok := t.idempotency[key]; ok {
+			record.StatusCode, record.Response = statusCode, append([]byte(nil), response...)
+			t.idempotency[key] = record
+		}
+		return nil
+	})
+}
+
+// Release : Removes an in-flight key so the client can retry it
+func (s IdempotencyRepositoryStub) Release(key string) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		if record, ok := t.idempotency[key]; ok && !record.IsCompleted() {
+			delete(t.idempotency, key)
+		}
+		return nil
+	})
+}
+
+// DeleteExpired : Removes every key past its expiry date
+func (s IdempotencyRepositoryStub) DeleteExpired(now string) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for key, record := range t.idempotency {
+			if record.ExpiresAt <= now {
+				delete(t.idempotency, key)
+			}
+		}
+		return nil
+	})
+}
+
+// NewIdempotencyRepositoryStub : Returns the idempotency key repository working on the store
+func NewIdempotencyRepositoryStub(store *MemoryStore) IdempotencyRepositoryStub {
+	return IdempotencyRepositoryStub{store}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/money"
+	"errors"
+	"math/big"
+	"time"
+)
+
+const INTEREST = "interest"
+
+// Day count conventions, they decide which fraction of the annual rate a single day earns
+const (
+	DayCountActual365 = "ACT/365"
+	DayCountActual360 = "ACT/360"
+	DayCountActualAct = "ACT/ACT"
+	DayCount30360     = "30/360"
+)
+
+// microsPerMinorUnit : Accruals are kept in millionths of a unit so daily rounding does not lose interest
+const microsPerMinorUnit = 10000
+
+// InterestAccrual : Interest earned by an account on a single day, in millionths of the account currency
+type InterestAccrual struct {
+	AccountId     string       `db:"account_id"`
+	AccrualDate   string       `db:"accrual_date"`
+	Period        string       `db:"period"`
+	Balance       money.Amount `db:"balance"`
+	Rate          money.Rate   `db:"rate"`
+	DayCount      string       `db:"day_count"`
+	AccruedMicros int64        `db:"accrued_micros"`
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockInterestRepository.go -package=domain banking/domain InterestRepository
+type InterestRepository interface {
+	FindAccountsByType(accountTypes []string) ([]Account, *errs.AppError)
+	// LastAccrualDate : Returns the last day interest was accrued for the account, empty when never
+	LastAccrualDate(accountId string) (string, *errs.AppError)
+	// SaveAccruals : Stores the accruals, days already accrued are left untouched
+	SaveAccruals(accruals []InterestAccrual) *errs.AppError
+	// UnpostedPeriods : Returns the months (YYYY-MM) up to the given one with accruals but no interest transaction
+	UnpostedPeriods(accountId string, throughPeriod string) ([]string, *errs.AppError)
+	SumAccruals(accountId string, period string) (int64, *errs.AppError)
+}
+
+func IsDayCountConvention(convention string) bool {
+	switch convention {
+	case DayCountActual365, DayCountActual360, DayCountActualAct, DayCount30360:
+		return true
+	}
+	return false
+}
+
+func errUnknownDayCount(convention string) error {
+	return errors.New("unknown day count convention " + convention)
+}
+
+// DayFraction : Returns the fraction of a year the day counts for as numerator and denominator
+func DayFraction(convention string, day time.Time) (int64, int64) {
+	switch convention {
+	case DayCountActual360:
+		return 1, 360
+	case DayCountActualAct:
+		return 1, int64(daysInYear(day.Year()))
+	case DayCount30360:
+		// every month counts as 30 days: the 31st earns nothing and the end of February makes up the missing days
+		last := daysInMonth(day)
+		switch {
+		case day.Day() == 31:
+			return 0, 360
+		case day.Month() == time.February && day.Day() == last:
+			return int64(30 - last + 1), 360
+		}
+		return 1, 360
+	}
+	return 1, 365
+}
+
+// DailyInterestMicros : Interest earned in a day on the balance, in millionths of a unit rounded half up
+func DailyInterestMicros(balance money.Amount, rate money.Rate, convention string, day time.Time) int64 {
+	if balance <= 0 || rate <= 0 {
+		return 0
+	}
+	numerator, denominator := DayFraction(convention, day)
+	n := new(big.Int).Mul(big.NewInt(balance.MinorUnits()*microsPerMinorUnit), big.NewInt(int64(rate)))
+	n.Mul(n, big.NewInt(numerator))
+	d := big.NewInt(denominator * int64(money.OneToOne))
+	n.Add(n, new(big.Int).Quo(d, big.NewInt(2)))
+	return n.Quo(n, d).Int64()
+}
+
+// MicrosToAmount : Rounds accrued millionths to the closest minor unit
+func MicrosToAmount(micros int64) money.Amount {
+	return money.FromMinorUnits((micros + microsPerMinorUnit/2) / microsPerMinorUnit)
+}
+
+// InterestReference : Reference of the interest transaction for a month, it makes posting idempotent
+func InterestReference(period string) string {
+	return "interest:" + period
+}
+
+func daysInMonth(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type InterestRepositoryDB struct {
+	client *sqlx.DB
+}
+
+// FindAccountsByType : Returns the open (active or frozen) accounts of the given types
+func (d InterestRepositoryDB) FindAccountsByType(accountTypes []string) ([]Account, *errs.AppError) {
+	accounts := make([]Account, 0)
+	if len(accountTypes) == 0 {
+		return accounts, nil
+	}
+	sqlFind, args, err := sqlx.In(`SELECT account_id, customer_id, opening_date, account_type, currency, amount, status
+										FROM accounts WHERE LOWER(account_type) IN (?) AND status <> '0' ORDER BY account_id`, accountTypes)
+	if err == nil {
+		err = selectAll(d.client, &accounts, sqlFind, args...)
+	}
+	if err != nil {
+		logger.Error("Error while querying accounts for interest: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return accounts, nil
+}
+
+func (d InterestRepositoryDB) LastAccrualDate(accountId string) (string, *errs.AppError) {
+	var last string
+	err := get(d.client, &last, `SELECT COALESCE(MAX(accrual_date), '') FROM interest_accruals WHERE account_id = ?`, accountId)
+	if err != nil {
+		logger.Error("Error while fetching last interest accrual: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected database error")
+	}
+	return last, nil
+}
+
+// SaveAccruals : The primary key on account_id and accrual_date keeps re-runs from accruing a day twice
+func (d InterestRepositoryDB) SaveAccruals(accruals []InterestAccrual) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for interest accruals: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	sqlInsert := insertIgnore(d.client.DriverName(), `INSERT INTO interest_accruals (account_id, accrual_date, period, balance, rate, day_count, accrued_micros)
+								VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	for _, a := range accruals {
+		_, err = exec(tx, sqlInsert, a.AccountId, a.AccrualDate, a.Period, a.Balance, a.Rate, a.DayCount, a.AccruedMicros)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while saving interest accrual: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting interest accruals: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func (d InterestRepositoryDB) UnpostedPeriods(accountId string, throughPeriod string) ([]string, *errs.AppError) {
+	periods := make([]string, 0)
+	err := selectAll(d.client, &periods, `SELECT DISTINCT period FROM interest_accruals WHERE account_id = ? AND period <= ? ORDER BY period`,
+		accountId, throughPeriod)
+	if err != nil {
+		logger.Error("Error while querying interest periods: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	posted := make([]string, 0)
+	err = selectAll(d.client, &posted, `SELECT reference FROM transactions WHERE account_id = ? AND transaction_type = ? AND reference IS NOT NULL`,
+		accountId, INTEREST)
+	if err != nil {
+		logger.Error("Error while querying interest transactions: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	isPosted := make(map[string]bool)
+	for _, reference := range posted {
+		isPosted[reference] = true
+	}
+	unposted := make([]string, 0)
+	for _, p := range periods {
+		if !isPosted[InterestReference(p)] {
+			unposted = append(unposted, p)
+		}
+	}
+	return unposted, nil
+}
+
+func (d InterestRepositoryDB) SumAccruals(accountId string, period string) (int64, *errs.AppError) {
+	var sum int64
+	err := get(d.client, &sum, `SELECT COALESCE(SUM(accrued_micros), 0) FROM interest_accruals WHERE account_id = ? AND period = ?`,
+		accountId, period)
+	if err != nil {
+		logger.Error("Error while summing interest accruals: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return sum, nil
+}
+
+// NewInterestRepositoryDB : Returns the interest repository
+func NewInterestRepositoryDB(dbClient *sqlx.DB) InterestRepositoryDB {
+	return InterestRepositoryDB{dbClient}
+}
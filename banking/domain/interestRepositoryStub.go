@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"banking/errs"
+	"sort"
+	"strings"
+)
+
+// InterestRepositoryStub : Interest accruals kept in a MemoryStore
+type InterestRepositoryStub struct {
+	store *MemoryStore
+}
+
+// FindAccountsByType : Returns the open (active or frozen) accounts of the given types
+func (s InterestRepositoryStub) FindAccountsByType(accountTypes []string) ([]Account, *errs.AppError) {
+	accounts := make([]Account, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, a := range t.accounts {
+			if a.Status == AccountStatusClosed {
+				continue
+			}
+			for _, accountType := range accountTypes {
+				if strings.ToLower(a.AccountType) == accountType {
+					accounts = append(accounts, a)
+					break
+				}
+			}
+		}
+	})
+	sort.Slice(accounts, func(i, j int) bool {
+		return compareCustomers("", accounts[i].AccountId, "", accounts[j].AccountId, "customer_id") < 0
+	})
+	return accounts, nil
+}
+
+func (s InterestRepositoryStub) LastAccrualDate(accountId string) (string, *errs.AppError) {
+	last := ""
+	s.store.read(func(t *memoryTables) {
+		for _, a := range t.accruals {
+			if a.AccountId == accountId && a.AccrualDate > last {
+				last = a.AccrualDate
+			}
+		}
+	})
+	return last, nil
+}
+
+// SaveAccruals : Days already accrued are left untouched
+func (s InterestRepositoryStub) SaveAccruals(accruals []InterestAccrual) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for _, a := range accruals {
+			accrued := false
+			for _, existing := range t.accruals {
+				if existing.AccountId == a.AccountId && existing.AccrualDate == a.AccrualDate {
+					accrued = true
+					break
+				}
+			}
+			if !accrued {
+				t.accruals = append(t.accruals, a)
+			}
+		}
+		return nil
+	})
+}
+
+func (s InterestRepositoryStub) UnpostedPeriods(accountId string, throughPeriod string) ([]string, *errs.AppError) {
+	unposted := make([]string, 0)
+	s.store.read(func(t *memoryTables) {
+		isPosted := make(map[string]bool)
+		for _, tr := range t.transactions {
+			if tr.AccountId == accountId && tr.TransactionType == INTEREST && tr.Reference.Valid {
+				isPosted[tr.Reference.String] = true
+			}
+		}
+		seen := make(map[string]bool)
+		for _, a := range t.accruals {
+			if a.AccountId == accountId && a.Period <= throughPeriod && !seen[a.Period] && !isPosted[InterestReference(a.Period)] {
+				seen[a.Period] = true
+				unposted = append(unposted, a.Period)
+			}
+		}
+	})
+	sort.Strings(unposted)
+	return unposted, nil
+}
+
+func (s InterestRepositoryStub) SumAccruals(accountId string, period string) (int64, *errs.AppError) {
+	var sum int64
+	s.store.read(func(t *memoryTables) {
+		for _, a := range t.accruals {
+			if a.AccountId == accountId && a.Period == period {
+				sum += a.AccruedMicros
+			}
+		}
+	})
+	return sum, nil
+}
+
+// NewInterestRepositoryStub : Returns the interest repository working on the store
+func NewInterestRepositoryStub(store *MemoryStore) InterestRepositoryStub {
+	return InterestRepositoryStub{store}
+}
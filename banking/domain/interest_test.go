@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"banking/money"
+	"testing"
+	"time"
+)
+
+func Test_should_count_every_month_as_thirty_days_with_30_360(t *testing.T) {
+	// Arrange
+	months := []time.Time{
+		time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, first := range months {
+		// Act
+		var days int64
+		for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+			numerator, _ := DayFraction(DayCount30360, day)
+			days += numerator
+		}
+		// Assert
+		if days != 30 {
+			t.Errorf("Expected 30 days for %s, got %d", first.Format("2006-01"), days)
+		}
+	}
+}
+
+func Test_should_accrue_daily_interest_without_rounding_to_cents(t *testing.T) {
+	// Arrange
+	day := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	rate, _ := money.ParseRate("0.02")
+	// Act
+	micros := DailyInterestMicros(money.FromUnits(1000), rate, DayCountActual365, day)
+	// Assert, 1000 * 2% / 365 = 0.054794...
+	if micros != 54795 {
+		t.Errorf("Failed while accruing daily interest, got %d", micros)
+	}
+	if MicrosToAmount(micros*31) != money.MustParse("1.70") {
+		t.Errorf("Failed while rounding a month of interest, got %v", MicrosToAmount(micros*31))
+	}
+	if DailyInterestMicros(money.FromUnits(-1000), rate, DayCountActual365, day) != 0 {
+		t.Error("Negative balances should not accrue interest")
+	}
+}
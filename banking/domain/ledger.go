@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/money"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// System ledger accounts, the other side of every customer posting
+const (
+	LedgerCash            = "system:cash"
+	LedgerInterestExpense = "system:interest_expense"
+	LedgerFees            = "system:fees"
+	// LedgerFxPosition : Bridges postings in two currencies, so every entry balances per currency
+	LedgerFxPosition = "system:fx_position"
+)
+
+// Journal entry types
+const (
+	EntryOpening    = "opening"
+	EntryDeposit    = "deposit"
+	EntryWithdrawal = "withdrawal"
+	EntryInterest   = "interest"
+	EntryTransfer   = "transfer"
+)
+
+// customerLedgerPrefix : Customer accounts are liabilities of the bank, credits increase their balance
+const customerLedgerPrefix = "customer:"
+
+// JournalEntry : A balanced set of debit and credit lines, the only way money moves between accounts
+type JournalEntry struct {
+	EntryId       string         `db:"entry_id"`
+	EntryType     string         `db:"entry_type"`
+	TransactionId sql.NullString `db:"transaction_id"`
+	TransferId    sql.NullString `db:"transfer_id"`
+	PostedAt      string         `db:"posted_at"`
+	Lines         []JournalLine  `db:"-"`
+	// AllowOverdraw : Skips the balance check on customer accounts, only for audited overrides
+	AllowOverdraw bool `db:"-"`
+	// AllowFrozen : Lets money leave a frozen account, for reversals and closing payouts. Closed accounts take no
+	// posting at all.
+	AllowFrozen bool `db:"-"`
+}
+
+// JournalLine : One side of a journal entry, exactly one of Debit and Credit is set
+type JournalLine struct {
+	EntryId       string       `db:"entry_id"`
+	LedgerAccount string       `db:"ledger_account"`
+	Currency      string       `db:"currency"`
+	Debit         money.Amount `db:"debit"`
+	Credit        money.Amount `db:"credit"`
+}
+
+// LedgerBalance : Totals of a ledger account in one currency
+type LedgerBalance struct {
+	LedgerAccount string       `db:"ledger_account"`
+	Currency      string       `db:"currency"`
+	Debits        money.Amount `db:"debits"`
+	Credits       money.Amount `db:"credits"`
+}
+
+// BalanceMismatch : A customer account whose stored balance differs from the one derived from the ledger
+type BalanceMismatch struct {
+	AccountId     string       `db:"account_id"`
+	StoredBalance money.Amount `db:"stored_balance"`
+	LedgerBalance money.Amount `db:"ledger_balance"`
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockLedgerRepository.go -package=domain banking/domain LedgerRepository
+type LedgerRepository interface {
+	// TrialBalance : Debit and credit totals of every ledger account and currency
+	TrialBalance() ([]LedgerBalance, *errs.AppError)
+	// FindMismatches : Customer accounts whose balance does not match the sum of their journal lines
+	FindMismatches() ([]BalanceMismatch, *errs.AppError)
+}
+
+// CustomerLedgerAccount : Ledger account of a customer account
+func CustomerLedgerAccount(accountId string) string {
+	return customerLedgerPrefix + accountId
+}
+
+// CustomerAccountId : Returns the customer account id of a ledger account, or false for system accounts
+func CustomerAccountId(ledgerAccount string) (string, bool) {
+	if !strings.HasPrefix(ledgerAccount, customerLedgerPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(ledgerAccount, customerLedgerPrefix), true
+}
+
+// Balance : Credits minus debits, the balance of a customer ledger account
+func (b LedgerBalance) Balance() money.Amount {
+	return b.Credits - b.Debits
+}
+
+// BalanceChange : Change the line makes to the balance of a customer account
+func (l JournalLine) BalanceChange() money.Amount {
+	return l.Credit - l.Debit
+}
+
+// Validate : Every line has one positive side, and debits equal credits in each currency
+func (e JournalEntry) Validate() error {
+	if len(e.Lines) < 2 {
+		return errors.New("journal entry needs at least two lines")
+	}
+	totals := make(map[string]money.Amount)
+	for _, l := range e.Lines {
+		if l.Debit < 0 || l.Credit < 0 || (l.Debit == 0) == (l.Credit == 0) {
+			return errors.New("journal line should have either a debit or a credit")
+		}
+		totals[l.Currency] += l.Debit - l.Credit
+	}
+	for currency, total := range totals {
+		if total != 0 {
+			return errors.New("journal entry is not balanced in " + currency)
+		}
+	}
+	return nil
+}
+
+// NewTransactionEntry : Books a deposit, withdrawal or interest posting against the matching system account.
+// Converted amounts pass through the fx position, cash moves in the currency the client used.
+func NewTransactionEntry(t Transaction) JournalEntry {
+	customer := CustomerLedgerAccount(t.AccountId)
+	e := JournalEntry{EntryType: EntryDeposit, PostedAt: t.TransactionDate}
+	counterpart := LedgerCash
+	switch {
+	case t.IsWithdrawal():
+		e.EntryType = EntryWithdrawal
+	case t.TransactionType == INTEREST:
+		e.EntryType = EntryInterest
+		counterpart = LedgerInterestExpense
+	}
+	if t.TransactionId != "" {
+		e.TransactionId = sql.NullString{String: t.TransactionId, Valid: true}
+	}
+
+	if t.IsWithdrawal() {
+		e.Lines = movement(customer, t.Amount, t.Currency, counterpart, originalAmount(t), originalCurrency(t))
+	} else {
+		e.Lines = movement(counterpart, originalAmount(t), originalCurrency(t), customer, t.Amount, t.Currency)
+	}
+	return e
+}
+
+// NewOpeningEntry : Books the deposit an account is opened with
+func NewOpeningEntry(a Account) JournalEntry {
+	return JournalEntry{
+		EntryType: EntryOpening,
+		PostedAt:  a.OpeningDate,
+		Lines:     movement(LedgerCash, a.Amount, a.Currency, CustomerLedgerAccount(a.AccountId), a.Amount, a.Currency),
+	}
+}
+
+// NewTransferEntry : Debits the source account and credits the destination, through the fx position
+// when the accounts have different currencies
+func NewTransferEntry(t Transfer) JournalEntry {
+	return JournalEntry{
+		EntryType:  EntryTransfer,
+		TransferId: sql.NullString{String: t.TransferId, Valid: true},
+		PostedAt:   t.TransferDate,
+		Lines: movement(CustomerLedgerAccount(t.FromAccountId), t.Amount, t.Currency,
+			CustomerLedgerAccount(t.ToAccountId), t.CreditedAmount, t.CreditedCurrency),
+		AllowFrozen: t.ClosingPayout,
+	}
+}
+
+// movement : Lines debiting one ledger account and crediting another, split through the fx position when
+// the two sides are in different currencies
+func movement(debit string, debitAmount money.Amount, debitCurrency string, credit string, creditAmount money.Amount, creditCurrency string) []JournalLine {
+	if debitCurrency == creditCurrency {
+		return []JournalLine{
+			{LedgerAccount: debit, Currency: debitCurrency, Debit: debitAmount},
+			{LedgerAccount: credit, Currency: creditCurrency, Credit: creditAmount},
+		}
+	}
+	return []JournalLine{
+		{LedgerAccount: debit, Currency: debitCurrency, Debit: debitAmount},
+		{LedgerAccount: LedgerFxPosition, Currency: debitCurrency, Credit: debitAmount},
+		{LedgerAccount: LedgerFxPosition, Currency: creditCurrency, Debit: creditAmount},
+		{LedgerAccount: credit, Currency: creditCurrency, Credit: creditAmount},
+	}
+}
+
+func originalAmount(t Transaction) money.Amount {
+	if t.OriginalCurrency == "" {
+		return t.Amount
+	}
+	return t.OriginalAmount
+}
+
+func originalCurrency(t Transaction) string {
+	if t.OriginalCurrency == "" {
+		return t.Currency
+	}
+	return t.OriginalCurrency
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type LedgerRepositoryDB struct {
+	client *sqlx.DB
+}
+
+func (d LedgerRepositoryDB) TrialBalance() ([]LedgerBalance, *errs.AppError) {
+	balances := make([]LedgerBalance, 0)
+	err := selectAll(d.client, &balances, `SELECT ledger_account, currency, SUM(debit) AS debits, SUM(credit) AS credits
+											FROM journal_lines GROUP BY ledger_account, currency ORDER BY ledger_account, currency`)
+	if err != nil {
+		logger.Error("Error while querying the trial balance: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return balances, nil
+}
+
+func (d LedgerRepositoryDB) FindMismatches() ([]BalanceMismatch, *errs.AppError) {
+	mismatches := make([]BalanceMismatch, 0)
+	err := selectAll(d.client, &mismatches, `SELECT a.account_id, a.amount AS stored_balance, COALESCE(SUM(l.credit - l.debit), 0) AS ledger_balance
+											FROM accounts a LEFT JOIN journal_lines l ON l.ledger_account = `+concat(d.client.DriverName(), "?", "a.account_id")+`
+											GROUP BY a.account_id, a.amount
+											HAVING ROUND(a.amount, 2) <> ROUND(COALESCE(SUM(l.credit - l.debit), 0), 2)
+											ORDER BY a.account_id`, customerLedgerPrefix)
+	if err != nil {
+		logger.Error("Error while reconciling account balances: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return mismatches, nil
+}
+
+/**
+ * posting = apply the lines to the customer balances + store the entry and its lines, inside the caller's database
+ * transaction. accounts.amount is kept as the running total of the customer lines, money only leaves an account
+ * when the balance and the overdraft limit cover it, so the check and the update are a single statement
+ */
+func postJournalEntry(tx *sqlx.Tx, e *JournalEntry) *errs.AppError {
+	if err := e.Validate(); err != nil {
+		logger.Error("Refusing to post journal entry: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected error while posting to the ledger")
+	}
+	for _, l := range e.Lines {
+		accountId, ok := CustomerAccountId(l.LedgerAccount)
+		if !ok {
+			continue
+		}
+		if appErr := applyBalanceChange(tx, accountId, l, *e); appErr != nil {
+			return appErr
+		}
+	}
+
+	entryId, err := insert(tx, "entry_id", `INSERT INTO journal_entries (entry_type, transaction_id, transfer_id, posted_at) VALUES (?, ?, ?, ?)`,
+		e.EntryType, e.TransactionId, e.TransferId, e.PostedAt)
+	if err != nil {
+		logger.Error("Error while saving journal entry: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	e.EntryId = strconv.FormatInt(entryId, 10)
+	for i := range e.Lines {
+		l := &e.Lines[i]
+		l.EntryId = e.EntryId
+		_, err = exec(tx, `INSERT INTO journal_lines (entry_id, ledger_account, currency, debit, credit) VALUES (?, ?, ?, ?, ?)`,
+			l.EntryId, l.LedgerAccount, l.Currency, l.Debit, l.Credit)
+		if err != nil {
+			logger.Error("Error while saving journal line: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+	return nil
+}
+
+// applyBalanceChange : The status is checked by the same statement, so an account frozen or closed after the caller
+// read it takes no posting it should not
+func applyBalanceChange(tx *sqlx.Tx, accountId string, l JournalLine, e JournalEntry) *errs.AppError {
+	change := l.BalanceChange()
+	// SQLite keeps NUMERIC columns as floating point, the rounding keeps cents exact there and changes nothing elsewhere
+	sqlUpdate := `UPDATE accounts SET amount = ROUND(amount + ?, 2) WHERE account_id = ?`
+	args := []interface{}{change, accountId}
+	if change < 0 && !e.AllowFrozen {
+		sqlUpdate += ` AND status = ?`
+		args = append(args, AccountStatusActive)
+	} else {
+		sqlUpdate += ` AND status <> ?`
+		args = append(args, AccountStatusClosed)
+	}
+	if change < 0 && !e.AllowOverdraw {
+		sqlUpdate += ` AND ROUND(amount + ?, 2) >= -overdraft_limit`
+		args = append(args, change)
+	}
+	result, err := exec(tx, sqlUpdate, args...)
+	if err != nil {
+		logger.Error("Error while updating account balance: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		// nothing was updated, the account does not exist, is not open for the posting or the balance and overdraft
+		// are not enough
+		statuses := make([]string, 0)
+		if err := selectAll(tx, &statuses, `SELECT status FROM accounts WHERE account_id = ?`, accountId); err != nil {
+			logger.Error("Error while fetching account information: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+		if len(statuses) == 0 {
+			return errs.NewNotFoundError("Account not found")
+		}
+		if appErr := (Account{Status: statuses[0]}).CanPost(change < 0 && !e.AllowFrozen); appErr != nil {
+			return appErr
+		}
+		return errs.NewInsufficientFundsError("Insufficient balance in the account")
+	}
+	return nil
+}
+
+// NewLedgerRepositoryDB : Returns the ledger repository
+func NewLedgerRepositoryDB(dbClient *sqlx.DB) LedgerRepositoryDB {
+	return LedgerRepositoryDB{dbClient}
+}
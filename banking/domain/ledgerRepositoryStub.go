@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/money"
+	"sort"
+)
+
+// LedgerRepositoryStub : Reads the journal kept in a MemoryStore
+type LedgerRepositoryStub struct {
+	store *MemoryStore
+}
+
+func (s LedgerRepositoryStub) TrialBalance() ([]LedgerBalance, *errs.AppError) {
+	totals := make(map[[2]string]*LedgerBalance)
+	s.store.read(func(t *memoryTables) {
+		for _, e := range t.entries {
+			for _, l := range e.Lines {
+				key := [2]string{l.LedgerAccount, l.Currency}
+				if totals[key] == nil {
+					totals[key] = &LedgerBalance{LedgerAccount: l.LedgerAccount, Currency: l.Currency}
+				}
+				totals[key].Debits += l.Debit
+				totals[key].Credits += l.Credit
+			}
+		}
+	})
+	balances := make([]LedgerBalance, 0, len(totals))
+	for _, b := range totals {
+		balances = append(balances, *b)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].LedgerAccount != balances[j].LedgerAccount {
+			return balances[i].LedgerAccount < balances[j].LedgerAccount
+		}
+		return balances[i].Currency < balances[j].Currency
+	})
+	return balances, nil
+}
+
+func (s LedgerRepositoryStub) FindMismatches() ([]BalanceMismatch, *errs.AppError) {
+	mismatches := make([]BalanceMismatch, 0)
+	s.store.read(func(t *memoryTables) {
+		ledger := make(map[string]money.Amount)
+		for _, e := range t.entries {
+			for _, l := range e.Lines {
+				ledger[l.LedgerAccount] += l.Credit - l.Debit
+			}
+		}
+		for _, a := range t.accounts {
+			if balance := ledger[CustomerLedgerAccount(a.AccountId)]; balance != a.Amount {
+				mismatches = append(mismatches, BalanceMismatch{AccountId: a.AccountId, StoredBalance: a.Amount, LedgerBalance: balance})
+			}
+		}
+	})
+	return mismatches, nil
+}
+
+// NewLedgerRepositoryStub : Returns the ledger repository working on the store
+func NewLedgerRepositoryStub(store *MemoryStore) LedgerRepositoryStub {
+	return LedgerRepositoryStub{store}
+}
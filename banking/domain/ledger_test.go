@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"banking/money"
+	"testing"
+)
+
+func Test_should_balance_every_posting_per_currency(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry JournalEntry
+		lines int
+	}{
+		{"deposit", NewTransactionEntry(Transaction{AccountId: "95470", Amount: money.FromUnits(100), TransactionType: DEPOSIT, Currency: "USD"}), 2},
+		{"withdrawal", NewTransactionEntry(Transaction{AccountId: "95470", Amount: money.FromUnits(100), TransactionType: WITHDRAWAL, Currency: "USD",
+			OriginalAmount: money.FromUnits(100), OriginalCurrency: "USD"}), 2},
+		{"interest", NewTransactionEntry(Transaction{AccountId: "95470", Amount: money.FromMinorUnits(37), TransactionType: INTEREST, Currency: "USD"}), 2},
+		{"converted deposit", NewTransactionEntry(Transaction{AccountId: "95470", Amount: money.FromUnits(110), TransactionType: DEPOSIT, Currency: "USD",
+			OriginalAmount: money.FromUnits(100), OriginalCurrency: "EUR"}), 4},
+		{"opening", NewOpeningEntry(Account{AccountId: "95470", Amount: money.FromUnits(5000), Currency: "USD"}), 2},
+		{"transfer", NewTransferEntry(Transfer{FromAccountId: "95470", ToAccountId: "95471", Amount: money.FromUnits(100), Currency: "USD",
+			CreditedAmount: money.FromUnits(100), CreditedCurrency: "USD"}), 2},
+		{"converted transfer", NewTransferEntry(Transfer{FromAccountId: "95470", ToAccountId: "95471", Amount: money.FromUnits(100), Currency: "USD",
+			CreditedAmount: money.FromUnits(92), CreditedCurrency: "EUR"}), 4},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.entry.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if len(tc.entry.Lines) != tc.lines {
+				t.Errorf("got %d lines, want %d", len(tc.entry.Lines), tc.lines)
+			}
+		})
+	}
+}
+
+func Test_should_move_the_customer_balance_by_the_account_currency_amount(t *testing.T) {
+	// Arrange
+	entry := NewTransactionEntry(Transaction{AccountId: "95470", Amount: money.FromUnits(110), TransactionType: WITHDRAWAL, Currency: "USD",
+		OriginalAmount: money.FromUnits(100), OriginalCurrency: "EUR"})
+	// Act
+	var change money.Amount
+	for _, l := range entry.Lines {
+		if id, ok := CustomerAccountId(l.LedgerAccount); ok && id == "95470" {
+			change += l.BalanceChange()
+		}
+	}
+	// Assert
+	if change != -money.FromUnits(110) {
+		t.Errorf("Failed while matching the balance change, got %v", change)
+	}
+	if entry.EntryType != EntryWithdrawal {
+		t.Errorf("Failed while matching the entry type, got %v", entry.EntryType)
+	}
+}
+
+func Test_should_reject_an_unbalanced_entry(t *testing.T) {
+	entry := JournalEntry{Lines: []JournalLine{
+		{LedgerAccount: LedgerCash, Currency: "USD", Debit: money.FromUnits(100)},
+		{LedgerAccount: CustomerLedgerAccount("95470"), Currency: "USD", Credit: money.FromUnits(90)},
+	}}
+	if entry.Validate() == nil {
+		t.Error("An entry whose debits and credits differ should not validate")
+	}
+	entry.Lines[1] = JournalLine{LedgerAccount: CustomerLedgerAccount("95470"), Currency: "EUR", Credit: money.FromUnits(100)}
+	if entry.Validate() == nil {
+		t.Error("An entry should balance in each currency")
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/money"
+	"strconv"
+)
+
+// WithdrawalLimits : Caps on money leaving an account, in the account currency. A zero value means no limit.
+type WithdrawalLimits struct {
+	PerTransaction money.Amount `json:"per_transaction"`
+	// Daily and DailyCount : Totals over the last 24 hours, not per calendar day
+	Daily      money.Amount `json:"daily"`
+	DailyCount int          `json:"daily_count"`
+}
+
+// WithdrawalUsage : Withdrawals made from an account over the last 24 hours
+type WithdrawalUsage struct {
+	Total money.Amount `db:"total"`
+	Count int          `db:"count"`
+}
+
+// DailyLimitCheck : 24 hour limits a withdrawal is held to. The repository sums the usage in the database
+// transaction that posts the withdrawal, with the account locked, so parallel withdrawals cannot all see the same
+// usage and all pass.
+type DailyLimitCheck struct {
+	Limits WithdrawalLimits
+	// Since : Withdrawals and outgoing transfers from this time on count, reversals and reversed withdrawals do not
+	Since string
+}
+
+// LimitsFor : Limits of a product for the role making the withdrawal, roles without an override get the
+// product limits
+func (p Product) LimitsFor(role string) WithdrawalLimits {
+	if limits, ok := p.RoleLimits[role]; ok {
+		return limits
+	}
+	return p.Limits
+}
+
+// CheckPerTransaction : Checked before anything is read, a single withdrawal over the maximum never goes further
+func (l WithdrawalLimits) CheckPerTransaction(amount money.Amount, currency string) *errs.AppError {
+	if l.PerTransaction > 0 && amount > l.PerTransaction {
+		return errs.NewLimitExceededError("Withdrawal exceeds the per transaction limit of " + l.PerTransaction.String() + " " + currency)
+	}
+	return nil
+}
+
+// CheckDaily : The withdrawal together with the last 24 hours of withdrawals must stay within the daily limits
+func (l WithdrawalLimits) CheckDaily(amount money.Amount, currency string, usage WithdrawalUsage) *errs.AppError {
+	if l.DailyCount > 0 && usage.Count+1 > l.DailyCount {
+		return errs.NewLimitExceededError("Withdrawal exceeds the limit of " + strconv.Itoa(l.DailyCount) + " withdrawals in 24 hours")
+	}
+	if l.Daily > 0 && usage.Total+amount > l.Daily {
+		return errs.NewLimitExceededError("Withdrawal exceeds the 24 hour limit of " + l.Daily.String() + " " + currency)
+	}
+	return nil
+}
+
+func (l WithdrawalLimits) isValid() bool {
+	return l.PerTransaction >= 0 && l.Daily >= 0 && l.DailyCount >= 0
+}
+
+// IsUnlimited : No limit is set, there is nothing to check
+func (l WithdrawalLimits) IsUnlimited() bool {
+	return l.PerTransaction == 0 && l.Daily == 0 && l.DailyCount == 0
+}
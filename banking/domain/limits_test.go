@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"banking/money"
+	"testing"
+)
+
+func Test_should_check_the_withdrawal_limits(t *testing.T) {
+	limits := WithdrawalLimits{PerTransaction: money.FromUnits(100), Daily: money.FromUnits(300), DailyCount: 3}
+	tests := []struct {
+		name    string
+		amount  money.Amount
+		usage   WithdrawalUsage
+		allowed bool
+	}{
+		{"within every limit", money.FromUnits(100), WithdrawalUsage{Total: money.FromUnits(200), Count: 2}, true},
+		{"over the per transaction limit", money.FromUnits(101), WithdrawalUsage{}, false},
+		{"over the daily total", money.FromUnits(50), WithdrawalUsage{Total: money.FromUnits(260), Count: 1}, false},
+		{"over the daily count", money.FromUnits(1), WithdrawalUsage{Total: money.FromUnits(10), Count: 3}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := limits.CheckPerTransaction(tc.amount, "USD")
+			if err == nil {
+				err = limits.CheckDaily(tc.amount, "USD", tc.usage)
+			}
+			if (err == nil) != tc.allowed {
+				t.Errorf("allowed = %v, want %v", err == nil, tc.allowed)
+			}
+		})
+	}
+}
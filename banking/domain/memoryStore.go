@@ -0,0 +1,288 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/money"
+	"database/sql"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// MemoryStore : Tables shared by the in-memory adapters, so they see each other's writes like the database
+// repositories do. Every write holds the lock for its whole duration and is undone as a whole when it fails,
+// which gives it the same all or nothing semantics as a database transaction.
+type MemoryStore struct {
+	mu sync.RWMutex
+	t  memoryTables
+}
+
+type memoryTables struct {
+	customers     []Customer
+	accounts      []Account
+	transactions  []Transaction
+	entries       []JournalEntry
+	statusChanges []AccountStatusChange
+	reversals     []TransactionReversal
+	fxRates       []FxRate
+	idempotency   map[string]IdempotencyRecord
+	accruals      []InterestAccrual
+	pending       []PendingTransaction
+	events        []Event
+	subscriptions []WebhookSubscription
+	deliveries    []WebhookDelivery
+	users         []User
+	// lastIds : Last id given out per table, like an auto increment column
+	lastIds map[string]int64
+}
+
+// clone : Copies every table, rows are values so the copy does not share them with the original
+func (t memoryTables) clone() memoryTables {
+	c := memoryTables{
+		customers:     append([]Customer(nil), t.customers...),
+		accounts:      append([]Account(nil), t.accounts...),
+		transactions:  append([]Transaction(nil), t.transactions...),
+		entries:       append([]JournalEntry(nil), t.entries...),
+		statusChanges: append([]AccountStatusChange(nil), t.statusChanges...),
+		reversals:     append([]TransactionReversal(nil), t.reversals...),
+		fxRates:       append([]FxRate(nil), t.fxRates...),
+		idempotency:   make(map[string]IdempotencyRecord, len(t.idempotency)),
+		accruals:      append([]InterestAccrual(nil), t.accruals...),
+		pending:       append([]PendingTransaction(nil), t.pending...),
+		events:        append([]Event(nil), t.events...),
+		subscriptions: append([]WebhookSubscription(nil), t.subscriptions...),
+		deliveries:    append([]WebhookDelivery(nil), t.deliveries...),
+		users:         append([]User(nil), t.users...),
+		lastIds:       make(map[string]int64, len(t.lastIds)),
+	}
+	for k, v := range t.idempotency {
+		c.idempotency[k] = v
+	}
+	for k, v := range t.lastIds {
+		c.lastIds[k] = v
+	}
+	return c
+}
+
+// write : Runs fn with the store locked, the tables are restored when it returns an error
+func (s *MemoryStore) write(fn func(t *memoryTables) *errs.AppError) *errs.AppError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	backup := s.t.clone()
+	if err := fn(&s.t); err != nil {
+		s.t = backup
+		return err
+	}
+	return nil
+}
+
+// read : Runs fn with the store locked for reading
+func (s *MemoryStore) read(fn func(t *memoryTables)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(&s.t)
+}
+
+func (t *memoryTables) nextId(table string) string {
+	t.lastIds[table]++
+	return strconv.FormatInt(t.lastIds[table], 10)
+}
+
+// useId : Keeps the auto increment ahead of a row inserted with its own id
+func (t *memoryTables) useId(table string, id string) {
+	if n, err := strconv.ParseInt(id, 10, 64); err == nil && n > t.lastIds[table] {
+		t.lastIds[table] = n
+	}
+}
+
+func (t *memoryTables) account(accountId string) *Account {
+	for i := range t.accounts {
+		if t.accounts[i].AccountId == accountId {
+			return &t.accounts[i]
+		}
+	}
+	return nil
+}
+
+// balance : The current balance of an account, for the transactions that were just saved on it
+func (t *memoryTables) balance(accountId string) *money.Amount {
+	balance := t.account(accountId).Amount
+	return &balance
+}
+
+func (t *memoryTables) transaction(transactionId string) *Transaction {
+	for i := range t.transactions {
+		if t.transactions[i].TransactionId == transactionId {
+			return &t.transactions[i]
+		}
+	}
+	return nil
+}
+
+func (t *memoryTables) saveAccount(a *Account) *errs.AppError {
+	if a.AccountId == "" {
+		a.AccountId = t.nextId("accounts")
+	} else {
+		t.useId("accounts", a.AccountId)
+	}
+	opened := *a
+	opened.Amount = 0
+	t.accounts = append(t.accounts, opened)
+	if a.Amount > 0 {
+		entry := NewOpeningEntry(*a)
+		if err := t.postJournalEntry(&entry); err != nil {
+			return err
+		}
+	}
+	t.saveEvents(NewAccountOpenedEvent(*a))
+	return nil
+}
+
+// postJournalEntry : Same checks as the database posting, every customer line is checked before any is applied
+func (t *memoryTables) postJournalEntry(e *JournalEntry) *errs.AppError {
+	if err := e.Validate(); err != nil {
+		return errs.NewUnexpectedError("Unexpected error while posting to the ledger")
+	}
+	for _, l := range e.Lines {
+		accountId, ok := CustomerAccountId(l.LedgerAccount)
+		if !ok {
+			continue
+		}
+		a := t.account(accountId)
+		if a == nil {
+			return errs.NewNotFoundError("Account not found")
+		}
+		change := l.BalanceChange()
+		if err := a.CanPost(change < 0 && !e.AllowFrozen); err != nil {
+			return err
+		}
+		if change < 0 && !e.AllowOverdraw && a.Amount+change < -a.OverdraftLimit {
+			return errs.NewInsufficientFundsError("Insufficient balance in the account")
+		}
+		a.Amount += change
+	}
+	e.EntryId = t.nextId("journal_entries")
+	lines := make([]JournalLine, len(e.Lines))
+	for i, l := range e.Lines {
+		l.EntryId = e.EntryId
+		lines[i] = l
+	}
+	e.Lines = lines
+	t.entries = append(t.entries, *e)
+	return nil
+}
+
+func (t *memoryTables) saveTransaction(tr *Transaction) *errs.AppError {
+	if tr.DailyLimits != nil && tr.IsWithdrawal() {
+		if err := t.checkDailyLimits(tr.AccountId, tr.Amount, tr.Currency, *tr.DailyLimits); err != nil {
+			return err
+		}
+	}
+	if tr.Reference.Valid {
+		for _, existing := range t.transactions {
+			if existing.AccountId == tr.AccountId && existing.Reference == tr.Reference {
+				return errs.NewAlreadyPostedError("Transaction " + tr.Reference.String + " was already posted")
+			}
+		}
+	}
+	tr.TransactionId = t.nextId("transactions")
+	t.transactions = append(t.transactions, *tr)
+	entry := NewTransactionEntry(*tr)
+	if err := t.postJournalEntry(&entry); err != nil {
+		return err
+	}
+	tr.Balance = t.balance(tr.AccountId)
+	t.saveEvents(NewTransactionPostedEvent(*tr))
+	return nil
+}
+
+// checkDailyLimits : Sums the withdrawals of the account under the same lock that saves the new one
+func (t *memoryTables) checkDailyLimits(accountId string, amount money.Amount, currency string, check DailyLimitCheck) *errs.AppError {
+	var usage WithdrawalUsage
+	for _, tr := range t.transactions {
+		if tr.AccountId == accountId && tr.TransactionType == WITHDRAWAL && tr.TransactionDate >= check.Since &&
+			!tr.ReversalOf.Valid && !tr.ReversedBy.Valid {
+			usage.Total += tr.Amount
+			usage.Count++
+		}
+	}
+	return check.Limits.CheckDaily(amount, currency, usage)
+}
+
+func (t *memoryTables) saveTransferLegs(tr *Transfer) *errs.AppError {
+	if t.account(tr.FromAccountId) == nil || t.account(tr.ToAccountId) == nil {
+		return errs.NewNotFoundError("Account not found")
+	}
+	if tr.DailyLimits != nil {
+		if err := t.checkDailyLimits(tr.FromAccountId, tr.Amount, tr.Currency, *tr.DailyLimits); err != nil {
+			return err
+		}
+	}
+	transferId := sql.NullString{String: tr.TransferId, Valid: true}
+	tr.Withdrawal = Transaction{AccountId: tr.FromAccountId, Amount: tr.Amount, TransactionType: WITHDRAWAL, TransactionDate: tr.TransferDate,
+		Currency: tr.Currency, OriginalAmount: tr.Amount, OriginalCurrency: tr.Currency, FxRate: money.OneToOne, TransferId: transferId}
+	tr.Deposit = Transaction{AccountId: tr.ToAccountId, Amount: tr.CreditedAmount, TransactionType: DEPOSIT, TransactionDate: tr.TransferDate,
+		Currency: tr.CreditedCurrency, OriginalAmount: tr.Amount, OriginalCurrency: tr.Currency, FxRate: tr.FxRate, TransferId: transferId}
+	for _, l := range []*Transaction{&tr.Withdrawal, &tr.Deposit} {
+		l.TransactionId = t.nextId("transactions")
+		t.transactions = append(t.transactions, *l)
+	}
+	entry := NewTransferEntry(*tr)
+	if err := t.postJournalEntry(&entry); err != nil {
+		return err
+	}
+	tr.Withdrawal.Balance = t.balance(tr.FromAccountId)
+	tr.Deposit.Balance = t.balance(tr.ToAccountId)
+	t.saveEvents(NewTransactionPostedEvent(tr.Withdrawal), NewTransactionPostedEvent(tr.Deposit))
+	return nil
+}
+
+func (t *memoryTables) saveEvents(events ...Event) {
+	for _, e := range events {
+		e.EventId = t.nextId("outbox_events")
+		t.events = append(t.events, e)
+	}
+}
+
+// NewMemoryStore : Returns an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{t: memoryTables{idempotency: make(map[string]IdempotencyRecord), lastIds: make(map[string]int64)}}
+}
+
+// NewSeededMemoryStore : Returns a store with the fixture customers and accounts, the opening deposits are
+// booked in the ledger like any other account opening
+func NewSeededMemoryStore() *MemoryStore {
+	s := NewMemoryStore()
+	s.write(func(t *memoryTables) *errs.AppError {
+		t.customers = append(t.customers,
+			Customer{ID: "1001", Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205", DateofBirth: "1970-01-01", Status: CustomerStatusActive},
+			Customer{ID: "1002", Name: "Jonathan Joestar", City: "England", Zipcode: "95457", DateofBirth: "1868-04-04", Status: CustomerStatusInactive},
+			Customer{ID: "1003", Name: "Joseph Joestar", City: "England", Zipcode: "95825", DateofBirth: "1920-09-27", Status: CustomerStatusActive},
+		)
+		t.useId("customers", "1003")
+		for _, a := range []Account{
+			{AccountId: "95470", CustomerId: "1001", OpeningDate: "2020-08-22 10:20:06", AccountType: "savings", Currency: money.DefaultCurrency,
+				Amount: money.FromUnits(6823), Status: AccountStatusActive},
+			{AccountId: "95471", CustomerId: "1001", OpeningDate: "2020-08-09 10:27:22", AccountType: "checking", Currency: money.DefaultCurrency,
+				Amount: money.FromUnits(3342), Status: AccountStatusActive, OverdraftLimit: money.FromUnits(500)},
+			{AccountId: "95472", CustomerId: "1003", OpeningDate: "2020-08-09 10:35:22", AccountType: "savings", Currency: "EUR",
+				Amount: money.FromUnits(7000), Status: AccountStatusActive},
+		} {
+			if err := t.saveAccount(&a); err != nil {
+				return err
+			}
+		}
+		// the fixtures are not news to anybody, the outbox starts empty
+		t.events = nil
+		return nil
+	})
+	return s
+}
+
+// sortByDate : Orders transactions by date and then id, backdated postings like interest can be saved after
+// newer ones
+func sortByDate(transactions []Transaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].TransactionDate < transactions[j].TransactionDate
+	})
+}
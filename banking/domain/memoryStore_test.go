@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"banking/money"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_should_leave_the_store_untouched_when_a_transfer_fails(t *testing.T) {
+	store := NewSeededMemoryStore()
+	accounts := NewAccountRepositoryStub(store)
+	ledger := NewLedgerRepositoryStub(store)
+	before, _ := ledger.TrialBalance()
+
+	_, appErr := accounts.SaveTransfer(Transfer{TransferId: "t1", FromAccountId: "95470", ToAccountId: "95471",
+		Amount: money.FromUnits(100000), Currency: "USD", CreditedAmount: money.FromUnits(100000), CreditedCurrency: "USD",
+		FxRate: money.OneToOne, TransferDate: "2021-01-31 10:00:00"})
+
+	if appErr == nil {
+		t.Fatal("a transfer over the balance should fail")
+	}
+	history, _ := accounts.FindTransactions(TransactionFilter{AccountId: "95471", Limit: 10})
+	after, _ := ledger.TrialBalance()
+	account, _ := accounts.FindBy("95470")
+	if len(history) != 0 || len(after) != len(before) || account.Amount != money.FromUnits(6823) {
+		t.Error("Test failed while rolling back a failed transfer")
+	}
+}
+
+func Test_should_keep_the_ledger_and_balances_in_step_under_parallel_withdrawals(t *testing.T) {
+	store := NewSeededMemoryStore()
+	accounts := NewAccountRepositoryStub(store)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accounts.SaveTransaction(Transaction{AccountId: "95470", Amount: money.FromUnits(100), TransactionType: WITHDRAWAL,
+				Currency: "USD", OriginalAmount: money.FromUnits(100), OriginalCurrency: "USD", FxRate: money.OneToOne,
+				TransactionDate: "2021-01-31 10:00:00"})
+		}()
+	}
+	wg.Wait()
+
+	account, _ := accounts.FindBy("95470")
+	mismatches, _ := NewLedgerRepositoryStub(store).FindMismatches()
+	if account.Amount < 0 || account.Amount != money.FromUnits(23) || len(mismatches) != 0 {
+		t.Errorf("balance = %s, mismatches = %v", account.Amount.String(), mismatches)
+	}
+}
+
+func Test_should_only_authorize_users_for_their_own_accounts(t *testing.T) {
+	store := NewSeededMemoryStore()
+	auth := NewAuthRepositoryStub(store, DefaultStubLogins()...)
+	jotaro := auth.TokenFor("jotaro")
+	other := NewAuthRepositoryStub(store, DefaultStubLogins()...)
+	admin := strings.Split(auth.TokenFor("admin"), ".")
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + admin[1] + "."
+	tests := []struct {
+		name  string
+		token string
+		route string
+		vars  map[string]string
+		want  bool
+	}{
+		{"own account", jotaro, "GetAccount", map[string]string{"customer_id": "1001", "account_id": "95470"}, true},
+		{"account of another customer", jotaro, "GetAccount", map[string]string{"customer_id": "1001", "account_id": "95472"}, false},
+		{"another customer", jotaro, "GetCustomer", map[string]string{"customer_id": "1003"}, false},
+		{"admin route", jotaro, "FreezeAccount", map[string]string{"customer_id": "1001", "account_id": "95470"}, false},
+		{"admin", auth.TokenFor("admin"), "FreezeAccount", map[string]string{"customer_id": "1001", "account_id": "95470"}, true},
+		{"unknown token", "a.b.c", "GetCustomer", map[string]string{"customer_id": "1001"}, false},
+		{"unsigned token", unsigned, "FreezeAccount", map[string]string{"customer_id": "1001", "account_id": "95470"}, false},
+		{"token of another adapter", other.TokenFor("admin"), "FreezeAccount", map[string]string{"customer_id": "1001", "account_id": "95470"}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := auth.IsAuthorized(tc.token, tc.route, tc.vars); got != tc.want {
+				t.Errorf("IsAuthorized = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
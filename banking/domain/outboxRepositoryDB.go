@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type OutboxRepositoryDB struct {
+	client *sqlx.DB
+}
+
+// outboxRow : SQLite and PostgreSQL return the TEXT payload as a string, which only scans into a plain []byte
+type outboxRow struct {
+	Event
+	RawPayload []byte `db:"raw_payload"`
+}
+
+func (d OutboxRepositoryDB) FindUnpublished(limit int, maxAttempts int) ([]Event, *errs.AppError) {
+	rows := make([]outboxRow, 0)
+	err := selectAll(d.client, &rows, `SELECT event_id, event_type, aggregate_id, payload AS raw_payload, occurred_at, published_at, attempts, last_error
+											FROM outbox_events WHERE published_at IS NULL AND attempts < ? ORDER BY event_id LIMIT ?`, maxAttempts, limit)
+	if err != nil {
+		logger.Error("Error while querying unpublished events: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	events := make([]Event, 0, len(rows))
+	for _, r := range rows {
+		r.Payload = r.RawPayload
+		events = append(events, r.Event)
+	}
+	return events, nil
+}
+
+func (d OutboxRepositoryDB) MarkPublished(eventId string, publishedAt string) *errs.AppError {
+	_, err := exec(d.client, `UPDATE outbox_events SET published_at = ?, attempts = attempts + 1 WHERE event_id = ?`, publishedAt, eventId)
+	if err != nil {
+		logger.Error("Error while marking event as published: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func (d OutboxRepositoryDB) RecordFailure(eventId string, reason string) *errs.AppError {
+	_, err := exec(d.client, `UPDATE outbox_events SET attempts = attempts + 1, last_error = ? WHERE event_id = ?`, reason, eventId)
+	if err != nil {
+		logger.Error("Error while recording event delivery failure: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// saveEvents : Writes the events to the outbox inside the caller's database transaction
+func saveEvents(tx *sqlx.Tx, events ...Event) *errs.AppError {
+	for _, e := range events {
+		_, err := exec(tx, `INSERT INTO outbox_events (event_type, aggregate_id, payload, occurred_at, attempts) VALUES (?, ?, ?, ?, 0)`,
+			e.EventType, e.AggregateId, string(e.Payload), e.OccurredAt)
+		if err != nil {
+			logger.Error("Error while saving event to the outbox: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+	return nil
+}
+
+// NewOutboxRepositoryDB : Returns the outbox repository
+func NewOutboxRepositoryDB(dbClient *sqlx.DB) OutboxRepositoryDB {
+	return OutboxRepositoryDB{dbClient}
+}
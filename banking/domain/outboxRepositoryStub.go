@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"banking/errs"
+	"database/sql"
+)
+
+// OutboxRepositoryStub : Reads the events the other in-memory adapters write to the MemoryStore
+type OutboxRepositoryStub struct {
+	store *MemoryStore
+}
+
+func (s OutboxRepositoryStub) FindUnpublished(limit int, maxAttempts int) ([]Event, *errs.AppError) {
+	events := make([]Event, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, e := range t.events {
+			if len(events) == limit {
+				break
+			}
+			if !e.PublishedAt.Valid && e.Attempts < maxAttempts {
+				events = append(events, e)
+			}
+		}
+	})
+	return events, nil
+}
+
+func (s OutboxRepositoryStub) MarkPublished(eventId string, publishedAt string) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		if e := t.event(eventId); e != nil {
+			e.PublishedAt = sql.NullString{String: publishedAt, Valid: true}
+			e.Attempts++
+		}
+		return nil
+	})
+}
+
+func (s OutboxRepositoryStub) RecordFailure(eventId string, reason string) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		if e := t.event(eventId); e != nil {
+			e.LastError = sql.NullString{String: reason, Valid: true}
+			e.Attempts++
+		}
+		return nil
+	})
+}
+
+func (t *memoryTables) event(eventId string) *Event {
+	for i := range t.events {
+		if t.events[i].EventId == eventId {
+			return &t.events[i]
+		}
+	}
+	return nil
+}
+
+// NewOutboxRepositoryStub : Returns the outbox repository working on the store
+func NewOutboxRepositoryStub(store *MemoryStore) OutboxRepositoryStub {
+	return OutboxRepositoryStub{store}
+}
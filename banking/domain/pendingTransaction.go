@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"banking/dto"
+	"banking/errs"
+	"banking/money"
+	"database/sql"
+	"strings"
+)
+
+// Pending transaction statuses
+const (
+	PendingStatusPending  = "pending"
+	PendingStatusApproved = "approved"
+	PendingStatusRejected = "rejected"
+)
+
+// PendingTransaction : A transaction held by fraud screening, no money moves until an admin approves it
+type PendingTransaction struct {
+	PendingId        string         `db:"pending_id"`
+	AccountId        string         `db:"account_id"`
+	Amount           money.Amount   `db:"amount"`
+	TransactionType  string         `db:"transaction_type"`
+	Currency         string         `db:"currency"`
+	OriginalAmount   money.Amount   `db:"original_amount"`
+	OriginalCurrency string         `db:"original_currency"`
+	FxRate           money.Rate     `db:"fx_rate"`
+	RequestedAt      string         `db:"requested_at"`
+	Reasons          string         `db:"reasons"`
+	Status           string         `db:"status"`
+	DecidedBy        sql.NullString `db:"decided_by"`
+	DecidedAt        sql.NullString `db:"decided_at"`
+	// TransactionId : The transaction posted when it was approved, the withdrawal leg for a transfer
+	TransactionId sql.NullString `db:"transaction_id"`
+	// ToAccountId : Set when the held transaction is the withdrawal leg of a transfer to this account. FxRate then
+	// converts Amount into the currency of the destination.
+	ToAccountId sql.NullString `db:"to_account_id"`
+	// RequestedRole : Role of the user who made the request, its withdrawal limits are checked again on approval
+	RequestedRole string `db:"requested_role"`
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockPendingTransactionRepository.go -package=domain banking/domain PendingTransactionRepository
+type PendingTransactionRepository interface {
+	Save(PendingTransaction) (*PendingTransaction, *errs.AppError)
+	FindById(pendingId string) (*PendingTransaction, *errs.AppError)
+	FindByStatus(status string) ([]PendingTransaction, *errs.AppError)
+	// Approve : Saves the transaction and marks the pending one as approved in the same database transaction,
+	// only while it is still pending
+	Approve(p PendingTransaction, t Transaction, actor string, decidedAt string) (*Transaction, *errs.AppError)
+	// ApproveTransfer : Same as Approve for a held transfer, both legs are saved
+	ApproveTransfer(p PendingTransaction, t Transfer, actor string, decidedAt string) (*Transfer, *errs.AppError)
+	Reject(p PendingTransaction, actor string, decidedAt string) *errs.AppError
+}
+
+// NewPendingTransaction : Holds a transaction the screening sent to review
+func NewPendingTransaction(t Transaction, result ScreeningResult, role string) PendingTransaction {
+	return PendingTransaction{
+		AccountId:        t.AccountId,
+		Amount:           t.Amount,
+		TransactionType:  t.TransactionType,
+		Currency:         t.Currency,
+		OriginalAmount:   t.OriginalAmount,
+		OriginalCurrency: t.OriginalCurrency,
+		FxRate:           t.FxRate,
+		RequestedAt:      t.TransactionDate,
+		Reasons:          strings.Join(result.Reasons, "; "),
+		Status:           PendingStatusPending,
+		RequestedRole:    role,
+	}
+}
+
+// NewPendingTransfer : Holds a transfer the screening sent to review, by its withdrawal leg
+func NewPendingTransfer(t Transfer, result ScreeningResult, role string) PendingTransaction {
+	return PendingTransaction{
+		AccountId:        t.FromAccountId,
+		Amount:           t.Amount,
+		TransactionType:  WITHDRAWAL,
+		Currency:         t.Currency,
+		OriginalAmount:   t.Amount,
+		OriginalCurrency: t.Currency,
+		FxRate:           t.FxRate,
+		RequestedAt:      t.TransferDate,
+		Reasons:          strings.Join(result.Reasons, "; "),
+		Status:           PendingStatusPending,
+		ToAccountId:      sql.NullString{String: t.ToAccountId, Valid: true},
+		RequestedRole:    role,
+	}
+}
+
+// IsTransfer : The held transaction is a transfer, approving it moves the money to ToAccountId
+func (p PendingTransaction) IsTransfer() bool {
+	return p.ToAccountId.Valid
+}
+
+// Transfer : The transfer to post when the pending one is approved, dated when the money moves. The amount is
+// converted with the rate of the request.
+func (p PendingTransaction) Transfer(transferId string, creditedCurrency string, postedAt string) Transfer {
+	return Transfer{
+		TransferId:       transferId,
+		FromAccountId:    p.AccountId,
+		ToAccountId:      p.ToAccountId.String,
+		Amount:           p.Amount,
+		Currency:         p.Currency,
+		CreditedAmount:   p.Amount.Convert(p.FxRate),
+		CreditedCurrency: creditedCurrency,
+		FxRate:           p.FxRate,
+		TransferDate:     postedAt,
+	}
+}
+
+// Transaction : The transaction to post when the pending one is approved, dated when the money moves
+func (p PendingTransaction) Transaction(postedAt string) Transaction {
+	return Transaction{
+		AccountId:        p.AccountId,
+		Amount:           p.Amount,
+		TransactionType:  p.TransactionType,
+		TransactionDate:  postedAt,
+		Currency:         p.Currency,
+		OriginalAmount:   p.OriginalAmount,
+		OriginalCurrency: p.OriginalCurrency,
+		FxRate:           p.FxRate,
+	}
+}
+
+func (p PendingTransaction) ToDto() dto.PendingTransactionResponse {
+	return dto.PendingTransactionResponse{
+		PendingId:       p.PendingId,
+		AccountId:       p.AccountId,
+		Amount:          p.Amount,
+		Currency:        p.Currency,
+		TransactionType: p.TransactionType,
+		RequestedAt:     p.RequestedAt,
+		Reasons:         p.Reasons,
+		Status:          p.Status,
+		DecidedBy:       p.DecidedBy.String,
+		DecidedAt:       p.DecidedAt.String,
+		TransactionId:   p.TransactionId.String,
+		ToAccountId:     p.ToAccountId.String,
+	}
+}
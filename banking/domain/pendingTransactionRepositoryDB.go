@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+	"database/sql"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const pendingColumns = "pending_id, account_id, amount, transaction_type, currency, original_amount, original_currency, fx_rate, requested_at, reasons, status, decided_by, decided_at, transaction_id, to_account_id, requested_role"
+
+type PendingTransactionRepositoryDB struct {
+	client *sqlx.DB
+}
+
+func (d PendingTransactionRepositoryDB) Save(p PendingTransaction) (*PendingTransaction, *errs.AppError) {
+	id, err := insert(d.client, "pending_id", `INSERT INTO pending_transactions (account_id, amount, transaction_type, currency, original_amount, original_currency, fx_rate, requested_at, reasons, status, to_account_id, requested_role)
+										VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, p.AccountId, p.Amount, p.TransactionType, p.Currency,
+		p.OriginalAmount, p.OriginalCurrency, p.FxRate, p.RequestedAt, p.Reasons, p.Status, p.ToAccountId, p.RequestedRole)
+	if err != nil {
+		logger.Error("Error while saving pending transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	p.PendingId = strconv.FormatInt(id, 10)
+	return &p, nil
+}
+
+func (d PendingTransactionRepositoryDB) FindById(pendingId string) (*PendingTransaction, *errs.AppError) {
+	var p PendingTransaction
+	err := get(d.client, &p, "SELECT "+pendingColumns+" FROM pending_transactions WHERE pending_id = ?", pendingId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Pending transaction not found")
+		}
+		logger.Error("Error while fetching pending transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &p, nil
+}
+
+func (d PendingTransactionRepositoryDB) FindByStatus(status string) ([]PendingTransaction, *errs.AppError) {
+	pending := make([]PendingTransaction, 0)
+	err := selectAll(d.client, &pending, "SELECT "+pendingColumns+" FROM pending_transactions WHERE status = ? ORDER BY pending_id", status)
+	if err != nil {
+		logger.Error("Error while querying pending transactions: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return pending, nil
+}
+
+/**
+ * approval = mark the pending transaction as approved + save the transaction through the ledger,
+ * inside the same database transaction so it can only be posted once
+ */
+func (d PendingTransactionRepositoryDB) Approve(p PendingTransaction, t Transaction, actor string, decidedAt string) (*Transaction, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for approval: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	if appErr := decide(tx, p.PendingId, PendingStatusApproved, actor, decidedAt); appErr != nil {
+		tx.Rollback()
+		return nil, appErr
+	}
+	if appErr := saveTransaction(tx, &t); appErr != nil {
+		tx.Rollback()
+		return nil, appErr
+	}
+	if _, err = exec(tx, `UPDATE pending_transactions SET transaction_id = ? WHERE pending_id = ?`, t.TransactionId, p.PendingId); err != nil {
+		tx.Rollback()
+		logger.Error("Error while linking approved transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting approval: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &t, nil
+}
+
+// ApproveTransfer : Like Approve, the pending transaction is linked to the withdrawal leg
+func (d PendingTransactionRepositoryDB) ApproveTransfer(p PendingTransaction, t Transfer, actor string, decidedAt string) (*Transfer, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for approval: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	if appErr := decide(tx, p.PendingId, PendingStatusApproved, actor, decidedAt); appErr != nil {
+		tx.Rollback()
+		return nil, appErr
+	}
+	if appErr := saveTransferLegs(tx, &t); appErr != nil {
+		tx.Rollback()
+		return nil, appErr
+	}
+	if _, err = exec(tx, `UPDATE pending_transactions SET transaction_id = ? WHERE pending_id = ?`, t.Withdrawal.TransactionId, p.PendingId); err != nil {
+		tx.Rollback()
+		logger.Error("Error while linking approved transfer: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting approval: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &t, nil
+}
+
+func (d PendingTransactionRepositoryDB) Reject(p PendingTransaction, actor string, decidedAt string) *errs.AppError {
+	return decide(d.client, p.PendingId, PendingStatusRejected, actor, decidedAt)
+}
+
+// decide : Only a pending transaction can be decided, a concurrent second decision stops here
+func decide(tx sqlx.Ext, pendingId string, status string, actor string, decidedAt string) *errs.AppError {
+	result, err := exec(tx, `UPDATE pending_transactions SET status = ?, decided_by = ?, decided_at = ? WHERE pending_id = ? AND status = ?`,
+		status, actor, decidedAt, pendingId, PendingStatusPending)
+	if err != nil {
+		logger.Error("Error while deciding pending transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return errs.NewValidationError("Transaction is no longer pending")
+	}
+	return nil
+}
+
+// NewPendingTransactionRepositoryDB : Returns the pending transaction repository
+func NewPendingTransactionRepositoryDB(dbClient *sqlx.DB) PendingTransactionRepositoryDB {
+	return PendingTransactionRepositoryDB{dbClient}
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"banking/errs"
+	"database/sql"
+)
+
+// PendingTransactionRepositoryStub : Transactions held by fraud screening, kept in a MemoryStore
+type PendingTransactionRepositoryStub struct {
+	store *MemoryStore
+}
+
+func (s PendingTransactionRepositoryStub) Save(p PendingTransaction) (*PendingTransaction, *errs.AppError) {
+	s.store.write(func(t *memoryTables) *errs.AppError {
+		p.PendingId = t.nextId("pending_transactions")
+		t.pending = append(t.pending, p)
+		return nil
+	})
+	return &p, nil
+}
+
+func (s PendingTransactionRepositoryStub) FindById(pendingId string) (*PendingTransaction, *errs.AppError) {
+	var found *PendingTransaction
+	s.store.read(func(t *memoryTables) {
+		if p := t.pendingTransaction(pendingId); p != nil {
+			copied := *p
+			found = &copied
+		}
+	})
+	if found == nil {
+		return nil, errs.NewNotFoundError("Pending transaction not found")
+	}
+	return found, nil
+}
+
+func (s PendingTransactionRepositoryStub) FindByStatus(status string) ([]PendingTransaction, *errs.AppError) {
+	pending := make([]PendingTransaction, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, p := range t.pending {
+			if p.Status == status {
+				pending = append(pending, p)
+			}
+		}
+	})
+	return pending, nil
+}
+
+// Approve : The decision and the posting are a single write, a failed posting leaves the transaction pending
+func (s PendingTransactionRepositoryStub) Approve(p PendingTransaction, tr Transaction, actor string, decidedAt string) (*Transaction, *errs.AppError) {
+	err := s.store.write(func(t *memoryTables) *errs.AppError {
+		pending, err := t.decide(p.PendingId, PendingStatusApproved, actor, decidedAt)
+		if err != nil {
+			return err
+		}
+		if err = t.saveTransaction(&tr); err != nil {
+			return err
+		}
+		pending.TransactionId = sql.NullString{String: tr.TransactionId, Valid: true}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tr, nil
+}
+
+// ApproveTransfer : Like Approve, the pending transaction is linked to the withdrawal leg
+func (s PendingTransactionRepositoryStub) ApproveTransfer(p PendingTransaction, tr Transfer, actor string, decidedAt string) (*Transfer, *errs.AppError) {
+	err := s.store.write(func(t *memoryTables) *errs.AppError {
+		pending, err := t.decide(p.PendingId, PendingStatusApproved, actor, decidedAt)
+		if err != nil {
+			return err
+		}
+		if err = t.saveTransferLegs(&tr); err != nil {
+			return err
+		}
+		pending.TransactionId = sql.NullString{String: tr.Withdrawal.TransactionId, Valid: true}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tr, nil
+}
+
+func (s PendingTransactionRepositoryStub) Reject(p PendingTransaction, actor string, decidedAt string) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		_, err := t.decide(p.PendingId, PendingStatusRejected, actor, decidedAt)
+		return err
+	})
+}
+
+func (t *memoryTables) pendingTransaction(pendingId string) *PendingTransaction {
+	for i := range t.pending {
+		if t.pending[i].PendingId == pendingId {
+			return &t.pending[i]
+		}
+	}
+	return nil
+}
+
+// decide : Only a pending transaction can be decided
+func (t *memoryTables) decide(pendingId string, status string, actor string, decidedAt string) (*PendingTransaction, *errs.AppError) {
+	p := t.pendingTransaction(pendingId)
+	if p == nil || p.Status != PendingStatusPending {
+		return nil, errs.NewValidationError("Transaction is no longer pending")
+	}
+	p.Status = status
+	p.DecidedBy = sql.NullString{String: actor, Valid: true}
+	p.DecidedAt = sql.NullString{String: decidedAt, Valid: true}
+	return p, nil
+}
+
+// NewPendingTransactionRepositoryStub : Returns the pending transaction repository working on the store
+func NewPendingTransactionRepositoryStub(store *MemoryStore) PendingTransactionRepositoryStub {
+	return PendingTransactionRepositoryStub{store}
+}
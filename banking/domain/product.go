@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"banking/money"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+)
+
+// Product : Behaviour shared by every account of an account type
+type Product struct {
+	Code string `json:"code"`
+	// InterestRate : Annual rate, 0.02 is 2% a year, accounts without a rate do not accrue interest
+	InterestRate money.Rate `json:"interest_rate"`
+	DayCount     string     `json:"day_count"`
+	// OverdraftLimit : How far below zero new accounts may go, each account can be given its own limit later
+	OverdraftLimit money.Amount `json:"overdraft_limit"`
+	// Limits : Withdrawal limits of the product, RoleLimits replaces them for the roles listed there
+	Limits     WithdrawalLimits            `json:"withdrawal_limits"`
+	RoleLimits map[string]WithdrawalLimits `json:"role_limits"`
+}
+
+// Products : Account products by account type
+type Products map[string]Product
+
+// DefaultProducts : Products used when no products file is configured
+func DefaultProducts() Products {
+	// admins move money for customers, so they are not held to the customer limits
+	return Products{
+		"savings": {Code: "savings", InterestRate: money.Rate(20000), DayCount: DayCountActual365,
+			Limits:     WithdrawalLimits{PerTransaction: money.FromUnits(2000), Daily: money.FromUnits(5000), DailyCount: 10},
+			RoleLimits: map[string]WithdrawalLimits{"admin": {}}},
+		"checking": {Code: "checking", DayCount: DayCountActual365, OverdraftLimit: money.FromUnits(500),
+			Limits:     WithdrawalLimits{PerTransaction: money.FromUnits(5000), Daily: money.FromUnits(10000), DailyCount: 20},
+			RoleLimits: map[string]WithdrawalLimits{"admin": {}}},
+	}
+}
+
+// LoadProducts : Reads a JSON list of products, types missing from the file keep their default
+func LoadProducts(path string) (Products, error) {
+	products := DefaultProducts()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var list []Product
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for _, p := range list {
+		p.Code = strings.ToLower(p.Code)
+		if p.DayCount == "" {
+			p.DayCount = DayCountActual365
+		}
+		if !IsDayCountConvention(p.DayCount) {
+			return nil, errUnknownDayCount(p.DayCount)
+		}
+		if p.OverdraftLimit < 0 {
+			return nil, errors.New("overdraft_limit of " + p.Code + " cannot be negative")
+		}
+		if !p.Limits.isValid() {
+			return nil, errors.New("withdrawal_limits of " + p.Code + " cannot be negative")
+		}
+		for role, limits := range p.RoleLimits {
+			if !limits.isValid() {
+				return nil, errors.New("role_limits of " + p.Code + " for " + role + " cannot be negative")
+			}
+		}
+		products[p.Code] = p
+	}
+	return products, nil
+}
+
+// For : Returns the product of an account type, unknown types get a product without interest
+func (p Products) For(accountType string) Product {
+	code := strings.ToLower(accountType)
+	if product, ok := p[code]; ok {
+		return product
+	}
+	return Product{Code: code, DayCount: DayCountActual365}
+}
+
+// AllowsOverdraft : Accounts of products without an overdraft can never go below zero
+func (p Product) AllowsOverdraft() bool {
+	return p.OverdraftLimit > 0
+}
+
+// InterestBearing : Returns the account types with an interest rate
+func (p Products) InterestBearing() []string {
+	types := make([]string, 0)
+	for code, product := range p {
+		if product.InterestRate > 0 {
+			types = append(types, code)
+		}
+	}
+	return types
+}
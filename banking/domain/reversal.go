@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"banking/errs"
+	"database/sql"
+)
+
+// EntryReversal : Journal entry type of a compensating transaction
+const EntryReversal = "reversal"
+
+// TransactionReversal : A compensating transaction for a wrong posting, with who reversed it and why.
+// Forced reversals may overdraw the account and are recorded as such.
+type TransactionReversal struct {
+	Original   Transaction
+	Reversal   Transaction
+	Reason     string
+	Actor      string
+	Forced     bool
+	ReversedAt string
+}
+
+// NewTransactionReversal : Builds the opposite posting of the original transaction, for the same amount and currency
+func NewTransactionReversal(original Transaction, reason string, actor string, forced bool, reversedAt string) (*TransactionReversal, *errs.AppError) {
+	if original.ReversedBy.Valid {
+		return nil, errs.NewValidationError("Transaction is already reversed")
+	}
+	if original.ReversalOf.Valid {
+		return nil, errs.NewValidationError("A reversal cannot be reversed")
+	}
+	if original.TransferId.Valid {
+		return nil, errs.NewValidationError("Transfers cannot be reversed one leg at a time")
+	}
+	reversal := original
+	reversal.TransactionId = ""
+	reversal.TransactionType = WITHDRAWAL
+	if original.IsWithdrawal() {
+		reversal.TransactionType = DEPOSIT
+	}
+	reversal.TransactionDate = reversedAt
+	reversal.Reference = sql.NullString{}
+	reversal.ReversalOf = sql.NullString{String: original.TransactionId, Valid: true}
+	reversal.Balance = nil
+	return &TransactionReversal{
+		Original:   original,
+		Reversal:   reversal,
+		Reason:     reason,
+		Actor:      actor,
+		Forced:     forced,
+		ReversedAt: reversedAt,
+	}, nil
+}
+
+// JournalEntry : Mirrors the entry of the original transaction, so every ledger account is restored exactly
+func (r TransactionReversal) JournalEntry() JournalEntry {
+	original := NewTransactionEntry(r.Original)
+	e := JournalEntry{
+		EntryType:     EntryReversal,
+		TransactionId: sql.NullString{String: r.Reversal.TransactionId, Valid: r.Reversal.TransactionId != ""},
+		PostedAt:      r.ReversedAt,
+		Lines:         make([]JournalLine, 0, len(original.Lines)),
+		AllowOverdraw: r.Forced,
+		AllowFrozen:   true,
+	}
+	for _, l := range original.Lines {
+		e.Lines = append(e.Lines, JournalLine{LedgerAccount: l.LedgerAccount, Currency: l.Currency, Debit: l.Credit, Credit: l.Debit})
+	}
+	return e
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"banking/money"
+	"database/sql"
+	"testing"
+)
+
+func Test_should_undo_every_ledger_line_of_the_original_transaction(t *testing.T) {
+	// Arrange
+	original := Transaction{TransactionId: "512", AccountId: "95470", Amount: money.FromUnits(110), TransactionType: DEPOSIT,
+		Currency: "USD", OriginalAmount: money.FromUnits(100), OriginalCurrency: "EUR", FxRate: money.Rate(1100000)}
+
+	// Act
+	reversal, appError := NewTransactionReversal(original, "wrong amount", "admin", false, "2021-01-02 10:00:00")
+
+	// Assert
+	if appError != nil {
+		t.Fatal(appError.Message)
+	}
+	if reversal.Reversal.TransactionType != WITHDRAWAL || reversal.Reversal.ReversalOf.String != "512" {
+		t.Error("Failed while building the compensating transaction")
+	}
+	totals := make(map[string]money.Amount)
+	for _, entry := range []JournalEntry{NewTransactionEntry(original), reversal.JournalEntry()} {
+		if err := entry.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range entry.Lines {
+			totals[l.LedgerAccount+" "+l.Currency] += l.BalanceChange()
+		}
+	}
+	for account, total := range totals {
+		if total != 0 {
+			t.Errorf("%s is left at %v after the reversal", account, total)
+		}
+	}
+}
+
+func Test_should_refuse_to_reverse_twice(t *testing.T) {
+	reversed := Transaction{TransactionId: "512", TransactionType: DEPOSIT, ReversedBy: sql.NullString{String: "513", Valid: true}}
+	if _, appError := NewTransactionReversal(reversed, "again", "admin", false, "2021-01-02 10:00:00"); appError == nil {
+		t.Error("A reversed transaction should not be reversed again")
+	}
+	reversal := Transaction{TransactionId: "513", TransactionType: WITHDRAWAL, ReversalOf: sql.NullString{String: "512", Valid: true}}
+	if _, appError := NewTransactionReversal(reversal, "undo", "admin", false, "2021-01-02 10:00:00"); appError == nil {
+		t.Error("A reversal should not be reversed")
+	}
+}
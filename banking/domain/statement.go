@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"banking/dto"
+	"banking/money"
+)
+
+// Statement : Account activity for a date range with the balance before, after and along every transaction
+type Statement struct {
+	Account        Account
+	From           string
+	To             string
+	OpeningBalance money.Amount
+	ClosingBalance money.Amount
+	Lines          []StatementLine
+}
+
+type StatementLine struct {
+	Transaction    Transaction
+	RunningBalance money.Amount
+}
+
+// SignedAmount : Returns the amount as it affects the balance, negative for withdrawals
+func (t Transaction) SignedAmount() money.Amount {
+	if t.IsWithdrawal() {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// NewStatement : Builds the statement from the current account balance and every transaction since the start
+// of the range, ordered oldest first. Transactions on or after end are only used to roll the balance back.
+func NewStatement(account Account, from string, to string, end string, since []Transaction) Statement {
+	// the opening balance is the current balance without everything that happened since the range started
+	opening := account.Amount
+	for _, t := range since {
+		opening -= t.SignedAmount()
+	}
+
+	s := Statement{Account: account, From: from, To: to, OpeningBalance: opening, Lines: make([]StatementLine, 0)}
+	balance := opening
+	for _, t := range since {
+		if t.TransactionDate >= end {
+			break
+		}
+		balance += t.SignedAmount()
+		s.Lines = append(s.Lines, StatementLine{Transaction: t, RunningBalance: balance})
+	}
+	s.ClosingBalance = balance
+	return s
+}
+
+func (s Statement) ToDto() dto.StatementResponse {
+	response := dto.StatementResponse{
+		AccountId:      s.Account.AccountId,
+		AccountType:    s.Account.AccountType,
+		Currency:       s.Account.Currency,
+		From:           s.From,
+		To:             s.To,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		Lines:          make([]dto.StatementLineResponse, 0, len(s.Lines)),
+	}
+	for _, l := range s.Lines {
+		response.Lines = append(response.Lines, dto.StatementLineResponse{
+			TransactionId:   l.Transaction.TransactionId,
+			TransactionDate: l.Transaction.TransactionDate,
+			TransactionType: l.Transaction.TransactionType,
+			Amount:          l.Transaction.SignedAmount(),
+			RunningBalance:  l.RunningBalance,
+			TransferId:      l.Transaction.TransferId.String,
+		})
+	}
+	return response
+}
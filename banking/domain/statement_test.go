@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"banking/money"
+	"testing"
+)
+
+func Test_should_roll_the_current_balance_back_to_the_opening_balance(t *testing.T) {
+	// Arrange
+	account := Account{AccountId: "95470", Currency: "USD", Amount: money.FromUnits(1150)}
+	since := []Transaction{
+		{TransactionId: "1", Amount: money.FromUnits(200), TransactionType: DEPOSIT, TransactionDate: "2021-01-05 10:00:00"},
+		{TransactionId: "2", Amount: money.FromUnits(50), TransactionType: WITHDRAWAL, TransactionDate: "2021-01-20 10:00:00"},
+		// after the end of the range, only used to roll the balance back
+		{TransactionId: "3", Amount: money.FromUnits(1000), TransactionType: DEPOSIT, TransactionDate: "2021-02-03 10:00:00"},
+	}
+	// Act
+	statement := NewStatement(account, "2021-01-01", "2021-01-31", "2021-02-01 00:00:00", since)
+
+	// Assert
+	if statement.OpeningBalance != 0 {
+		t.Errorf("Failed while matching the opening balance, got %v", statement.OpeningBalance)
+	}
+	if len(statement.Lines) != 2 || statement.Lines[0].RunningBalance != money.FromUnits(200) {
+		t.Error("Failed while matching the running balance")
+	}
+	if statement.ClosingBalance != money.FromUnits(150) {
+		t.Errorf("Failed while matching the closing balance, got %v", statement.ClosingBalance)
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"config"
+	"database/sql"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/**
+ * The repositories write their SQL with ? placeholders and syntax every database understands. These helpers
+ * rebind the placeholders for the driver of the client and spell out the few statements that differ.
+ */
+
+func exec(e sqlx.Ext, query string, args ...interface{}) (sql.Result, error) {
+	return e.Exec(e.Rebind(query), args...)
+}
+
+func get(q sqlx.Ext, dest interface{}, query string, args ...interface{}) error {
+	return sqlx.Get(q, dest, q.Rebind(query), args...)
+}
+
+func selectAll(q sqlx.Ext, dest interface{}, query string, args ...interface{}) error {
+	return sqlx.Select(q, dest, q.Rebind(query), args...)
+}
+
+// insert : Runs the INSERT and returns the id the database generated for idColumn. PostgreSQL has no
+// LastInsertId, the id is read back with RETURNING instead.
+func insert(e sqlx.Ext, idColumn string, query string, args ...interface{}) (int64, error) {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if e.DriverName() == config.DriverPostgres {
+		var id int64
+		err := e.QueryRowx(e.Rebind(query+" RETURNING "+idColumn), args...).Scan(&id)
+		return id, err
+	}
+	result, err := exec(e, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// insertIgnore : The INSERT skips rows that would break a unique key instead of failing
+func insertIgnore(driver string, query string) string {
+	if driver == config.DriverMySQL {
+		return strings.Replace(query, "INSERT INTO", "INSERT IGNORE INTO", 1)
+	}
+	return query + " ON CONFLICT DO NOTHING"
+}
+
+// upsert : The INSERT replaces the given columns of the row that has the same key
+func upsert(driver string, query string, key []string, columns ...string) string {
+	set := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if driver == config.DriverMySQL {
+			set = append(set, c+" = VALUES("+c+")")
+		} else {
+			set = append(set, c+" = excluded."+c)
+		}
+	}
+	if driver == config.DriverMySQL {
+		return query + " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+	}
+	return query + " ON CONFLICT (" + strings.Join(key, ", ") + ") DO UPDATE SET " + strings.Join(set, ", ")
+}
+
+// concat : String concatenation of the expressions
+func concat(driver string, expressions ...string) string {
+	if driver == config.DriverMySQL {
+		return "CONCAT(" + strings.Join(expressions, ", ") + ")"
+	}
+	return "(" + strings.Join(expressions, " || ") + ")"
+}
+
+// forUpdate : Row lock clause of a SELECT, SQLite locks the whole database on write and has none
+func forUpdate(driver string) string {
+	if driver == config.DriverSQLite {
+		return ""
+	}
+	return " FOR UPDATE"
+}
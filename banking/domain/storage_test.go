@@ -0,0 +1,371 @@
+package domain
+
+import (
+	"banking/dto"
+	"banking/errs"
+	"banking/migrations"
+	"banking/money"
+	"config"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func newSQLiteClient(t *testing.T) *sqlx.DB {
+	client, err := sqlx.Open(config.DriverSQLite, filepath.Join(t.TempDir(), "banking.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetMaxOpenConns(1)
+	t.Cleanup(func() { client.Close() })
+	migrator, err := migrations.NewMigrator(client)
+	if err == nil {
+		_, err = migrator.Up()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func Test_should_save_and_find_customers_on_sqlite(t *testing.T) {
+	repo := NewCustomerRepositoryDb(newSQLiteClient(t))
+
+	saved, appErr := repo.Save(Customer{Name: "Jotaro", City: "Morioh", Zipcode: "12345", DateofBirth: "1971-01-01", Status: "1"})
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	found, appErr := repo.ById(saved.ID)
+
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	if found.Name != "Jotaro" || found.City != "Morioh" {
+		t.Error("Invalid customer read back: ", found)
+	}
+}
+
+func Test_should_post_deposits_and_transfers_on_sqlite(t *testing.T) {
+	repo := NewAccountRepositoryDB(newSQLiteClient(t))
+	from, appErr := repo.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving", Currency: "USD",
+		Amount: money.FromUnits(100), Status: AccountStatusActive})
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	to, _ := repo.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "checking", Currency: "USD",
+		Status: AccountStatusActive})
+
+	_, appErr = repo.SaveTransfer(Transfer{TransferId: "t1", FromAccountId: from.AccountId, ToAccountId: to.AccountId,
+		Amount: money.FromUnits(40), Currency: "USD", CreditedAmount: money.FromUnits(40), CreditedCurrency: "USD",
+		FxRate: money.OneToOne, TransferDate: "2021-01-02 10:00:00"})
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	_, appErr = repo.SaveTransaction(Transaction{AccountId: to.AccountId, Amount: money.FromUnits(100), TransactionType: WITHDRAWAL,
+		TransactionDate: "2021-01-03 10:00:00", Currency: "USD"})
+
+	if appErr == nil || appErr.Message != "Insufficient balance in the account" {
+		t.Error("A withdrawal over the balance should be refused")
+	}
+	account, _ := repo.FindBy(to.AccountId)
+	if account.Amount != money.FromUnits(40) {
+		t.Error("Invalid balance after the transfer: ", account.Amount)
+	}
+	transactions, _ := repo.FindTransactionsBetween(from.AccountId, "2021-01-01 00:00:00", "2021-12-31 23:59:59")
+	if len(transactions) != 1 || transactions[0].Amount != money.FromUnits(40) {
+		t.Error("Invalid transactions of the source account: ", transactions)
+	}
+}
+
+func Test_should_compare_balances_in_cents_on_sqlite(t *testing.T) {
+	client := newSQLiteClient(t)
+	repo := NewAccountRepositoryDB(client)
+	post := func(accountId string, transactionType string, amount string) *errs.AppError {
+		_, appErr := repo.SaveTransaction(Transaction{AccountId: accountId, Amount: money.MustParse(amount), TransactionType: transactionType,
+			TransactionDate: "2021-01-02 10:00:00", Currency: "USD"})
+		return appErr
+	}
+	spent, _ := repo.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving", Currency: "USD",
+		Status: AccountStatusActive})
+	emptied, _ := repo.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving", Currency: "USD",
+		Status: AccountStatusActive})
+
+	// 0.30 - 0.10 is not 0.20 in floating point, the last withdrawal must still fit
+	for _, p := range []struct{ transactionType, amount string }{{DEPOSIT, "0.30"}, {WITHDRAWAL, "0.10"}, {WITHDRAWAL, "0.20"}} {
+		if appErr := post(spent.AccountId, p.transactionType, p.amount); appErr != nil {
+			t.Fatalf("Failed to post the %s of %s: %s", p.transactionType, p.amount, appErr.Message)
+		}
+	}
+	// 0.10 + 0.20 - 0.30 is not 0 in floating point, the account must still be empty enough to close
+	for _, p := range []struct{ transactionType, amount string }{{DEPOSIT, "0.10"}, {DEPOSIT, "0.20"}, {WITHDRAWAL, "0.30"}} {
+		if appErr := post(emptied.AccountId, p.transactionType, p.amount); appErr != nil {
+			t.Fatalf("Failed to post the %s of %s: %s", p.transactionType, p.amount, appErr.Message)
+		}
+	}
+	appErr := repo.SaveStatusChange(AccountStatusChange{AccountId: emptied.AccountId, FromStatus: AccountStatusActive,
+		ToStatus: AccountStatusClosed, Actor: "admin", ChangedAt: "2021-01-03 10:00:00"}, nil)
+	if appErr != nil {
+		t.Error("The emptied account should close: ", appErr.Message)
+	}
+	if mismatches, _ := NewLedgerRepositoryDB(client).FindMismatches(); len(mismatches) != 0 {
+		t.Error("The balances should match the ledger: ", mismatches)
+	}
+}
+
+func Test_should_check_the_status_when_posting_on_sqlite(t *testing.T) {
+	repo := NewAccountRepositoryDB(newSQLiteClient(t))
+	account, appErr := repo.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving", Currency: "USD",
+		Amount: money.FromUnits(100), Status: AccountStatusActive})
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	payout, _ := repo.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "checking", Currency: "USD",
+		Status: AccountStatusActive})
+	// frozen after the caller read the account as active
+	appErr = repo.SaveStatusChange(AccountStatusChange{AccountId: account.AccountId, FromStatus: AccountStatusActive,
+		ToStatus: AccountStatusFrozen, Actor: "admin", ChangedAt: "2021-01-02 10:00:00"}, nil)
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+
+	_, appErr = repo.SaveTransaction(Transaction{AccountId: account.AccountId, Amount: money.FromUnits(10), TransactionType: WITHDRAWAL,
+		TransactionDate: "2021-01-03 10:00:00", Currency: "USD"})
+	if appErr == nil || appErr.ErrorCode != errs.ErrCodeAccountInactive {
+		t.Error("A withdrawal from a frozen account should be refused")
+	}
+	_, appErr = repo.SaveTransfer(Transfer{TransferId: "t1", FromAccountId: account.AccountId, ToAccountId: payout.AccountId,
+		Amount: money.FromUnits(10), Currency: "USD", CreditedAmount: money.FromUnits(10), CreditedCurrency: "USD",
+		FxRate: money.OneToOne, TransferDate: "2021-01-03 10:00:00"})
+	if appErr == nil || appErr.ErrorCode != errs.ErrCodeAccountInactive {
+		t.Error("A transfer from a frozen account should be refused")
+	}
+	_, appErr = repo.SaveTransaction(Transaction{AccountId: account.AccountId, Amount: money.FromUnits(10), TransactionType: DEPOSIT,
+		TransactionDate: "2021-01-03 10:00:00", Currency: "USD"})
+	if appErr != nil {
+		t.Error("A frozen account should take deposits: ", appErr.Message)
+	}
+
+	// closing pays the balance out of the frozen account, after that it takes nothing
+	appErr = repo.SaveStatusChange(AccountStatusChange{AccountId: account.AccountId, FromStatus: AccountStatusFrozen,
+		ToStatus: AccountStatusClosed, Actor: "admin", ChangedAt: "2021-01-04 10:00:00"},
+		&Transfer{TransferId: "t2", FromAccountId: account.AccountId, ToAccountId: payout.AccountId, Amount: money.FromUnits(110),
+			Currency: "USD", CreditedAmount: money.FromUnits(110), CreditedCurrency: "USD", FxRate: money.OneToOne,
+			TransferDate: "2021-01-04 10:00:00", ClosingPayout: true})
+	if appErr != nil {
+		t.Fatal("The frozen account should be paid out and closed: ", appErr.Message)
+	}
+	_, appErr = repo.SaveTransaction(Transaction{AccountId: account.AccountId, Amount: money.FromUnits(10), TransactionType: DEPOSIT,
+		TransactionDate: "2021-01-05 10:00:00", Currency: "USD"})
+	if appErr == nil || appErr.ErrorCode != errs.ErrCodeAccountInactive {
+		t.Error("A deposit on a closed account should be refused")
+	}
+}
+
+func Test_should_spell_the_statements_for_the_driver(t *testing.T) {
+	tests := []struct {
+		driver       string
+		insertIgnore string
+		concat       string
+		forUpdate    string
+		upsert       string
+	}{
+		{config.DriverMySQL, "INSERT IGNORE INTO t (k) VALUES (?)", "CONCAT('a:', k)", " FOR UPDATE",
+			"INSERT INTO t (k, v) VALUES (?, ?) ON DUPLICATE KEY UPDATE v = VALUES(v)"},
+		{config.DriverPostgres, "INSERT INTO t (k) VALUES (?) ON CONFLICT DO NOTHING", "('a:' || k)", " FOR UPDATE",
+			"INSERT INTO t (k, v) VALUES (?, ?) ON CONFLICT (k) DO UPDATE SET v = excluded.v"},
+		{config.DriverSQLite, "INSERT INTO t (k) VALUES (?) ON CONFLICT DO NOTHING", "('a:' || k)", "",
+			"INSERT INTO t (k, v) VALUES (?, ?) ON CONFLICT (k) DO UPDATE SET v = excluded.v"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.driver, func(t *testing.T) {
+			if got := insertIgnore(tc.driver, "INSERT INTO t (k) VALUES (?)"); got != tc.insertIgnore {
+				t.Error("Invalid insert ignore: ", got)
+			}
+			if got := concat(tc.driver, "'a:'", "k"); got != tc.concat {
+				t.Error("Invalid concat: ", got)
+			}
+			if got := forUpdate(tc.driver); got != tc.forUpdate {
+				t.Error("Invalid row lock: ", got)
+			}
+			if got := upsert(tc.driver, "INSERT INTO t (k, v) VALUES (?, ?)", []string{"k"}, "v"); got != tc.upsert {
+				t.Error("Invalid upsert: ", got)
+			}
+		})
+	}
+}
+
+func Test_should_read_the_generated_id_back_with_returning_on_postgres(t *testing.T) {
+	conn := &recordingConn{}
+	client := sqlx.NewDb(sql.OpenDB(recordingConnector{conn}), config.DriverPostgres)
+	defer client.Close()
+
+	id, err := insert(client, "customer_id", "INSERT INTO customers (name, city) VALUES (?, ?);", "Jotaro", "Morioh")
+
+	if err != nil || id != 42 {
+		t.Fatalf("id = %d, err = %v", id, err)
+	}
+	if conn.query != "INSERT INTO customers (name, city) VALUES ($1, $2) RETURNING customer_id" {
+		t.Error("Invalid postgres insert: ", conn.query)
+	}
+}
+
+func Test_should_read_back_the_outbox_events_on_sqlite(t *testing.T) {
+	client := newSQLiteClient(t)
+	accounts, outbox := NewAccountRepositoryDB(client), NewOutboxRepositoryDB(client)
+	account, appErr := accounts.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving",
+		Currency: "USD", Amount: money.FromUnits(100), Status: AccountStatusActive})
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+
+	events, appErr := outbox.FindUnpublished(10, 3)
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	var opened dto.AccountResponse
+	if len(events) != 1 || events[0].EventType != EventAccountOpened || json.Unmarshal(events[0].Payload, &opened) != nil ||
+		opened.AccountId != account.AccountId {
+		t.Fatal("Invalid events read back: ", events)
+	}
+	if appErr = outbox.RecordFailure(events[0].EventId, "broker unavailable"); appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	retried, _ := outbox.FindUnpublished(10, 3)
+	if len(retried) != 1 || retried[0].Attempts != 1 || retried[0].LastError.String != "broker unavailable" {
+		t.Fatal("Invalid failed event read back: ", retried)
+	}
+	if appErr = outbox.MarkPublished(events[0].EventId, "2021-01-01 10:00:01"); appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	if pending, _ := outbox.FindUnpublished(10, 3); len(pending) != 0 {
+		t.Error("A published event should not be read back: ", pending)
+	}
+}
+
+func Test_should_queue_a_replayed_event_once_per_webhook_on_sqlite(t *testing.T) {
+	repo := NewWebhookRepositoryDB(newSQLiteClient(t))
+	subscription, appErr := repo.SaveSubscription(WebhookSubscription{CustomerId: "1", Url: "https://example.com/hook",
+		EventTypes: EventTransactionPosted, Secret: "secret", CreatedAt: "2021-01-01 10:00:00"})
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	event := Event{EventId: "7", EventType: EventTransactionPosted, AggregateId: "95470", Payload: []byte(`{}`)}
+	delivery := subscription.NewDelivery(event, event.Payload, "2021-01-01 10:00:00")
+
+	for i := 0; i < 2; i++ {
+		if appErr = repo.SaveDeliveries([]WebhookDelivery{delivery}); appErr != nil {
+			t.Fatal(appErr.Message)
+		}
+	}
+
+	deliveries, _ := repo.FindDeliveries(subscription.SubscriptionId)
+	if len(deliveries) != 1 {
+		t.Error("A replayed event should be queued once, got deliveries: ", len(deliveries))
+	}
+}
+
+// recordingConnector : A database/sql driver connection that remembers the last query and answers every query
+// with the id 42, enough to run the PostgreSQL statements without a server
+type recordingConnector struct {
+	conn *recordingConn
+}
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c recordingConnector) Driver() driver.Driver {
+	return nil
+}
+
+type recordingConn struct {
+	query string
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	c.query = query
+	return recordingStmt{}, nil
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type recordingStmt struct{}
+
+func (recordingStmt) Close() error {
+	return nil
+}
+
+func (recordingStmt) NumInput() int {
+	return -1
+}
+
+func (recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &idRows{}, nil
+}
+
+type idRows struct {
+	done bool
+}
+
+func (r *idRows) Columns() []string {
+	return []string{"id"}
+}
+
+func (r *idRows) Close() error {
+	return nil
+}
+
+func (r *idRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(42)
+	return nil
+}
+
+func Test_should_hold_and_approve_a_transfer_on_sqlite(t *testing.T) {
+	client := newSQLiteClient(t)
+	accounts, pending := NewAccountRepositoryDB(client), NewPendingTransactionRepositoryDB(client)
+	from, _ := accounts.Save(Account{CustomerId: "1", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving", Currency: "USD",
+		Amount: money.FromUnits(100), Status: AccountStatusActive})
+	to, _ := accounts.Save(Account{CustomerId: "2", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving", Currency: "USD",
+		Status: AccountStatusActive})
+	transfer := Transfer{FromAccountId: from.AccountId, ToAccountId: to.AccountId, Amount: money.FromUnits(40), Currency: "USD",
+		FxRate: money.OneToOne, TransferDate: "2021-01-02 10:00:00"}
+
+	held, appErr := pending.Save(NewPendingTransfer(transfer, ScreeningResult{Decision: ScreeningReview, Reasons: []string{"test"}}, "user"))
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	found, _ := pending.FindById(held.PendingId)
+	if !found.IsTransfer() || found.ToAccountId.String != to.AccountId {
+		t.Fatal("The destination of the held transfer should be read back: ", found)
+	}
+	_, appErr = pending.ApproveTransfer(*found, found.Transfer("t1", "USD", "2021-01-03 10:00:00"), "admin", "2021-01-03 10:00:00")
+
+	if appErr != nil {
+		t.Fatal(appErr.Message)
+	}
+	account, _ := accounts.FindBy(to.AccountId)
+	if account.Amount != money.FromUnits(40) {
+		t.Error("Invalid balance after the approval: ", account.Amount)
+	}
+}
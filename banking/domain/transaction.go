@@ -2,16 +2,53 @@ package domain
 
 import (
 	"banking/dto"
+	"banking/money"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"strconv"
 )
 
 const WITHDRAWAL = "withdrawal"
+const DEPOSIT = "deposit"
 
 type Transaction struct {
-	TransactionId   string  `db:"transaction_id"`
-	AccountId       string  `db:"account_id"`
-	Amount          float64 `db:"amount"`
-	TransactionType string  `db:"transaction_type"`
-	TransactionDate string  `db:"transaction_date"`
+	TransactionId   string       `db:"transaction_id"`
+	AccountId       string       `db:"account_id"`
+	Amount          money.Amount `db:"amount"`
+	TransactionType string       `db:"transaction_type"`
+	TransactionDate string       `db:"transaction_date"`
+	// Currency : Currency of the account, Amount is always in it
+	Currency string `db:"currency"`
+	// OriginalAmount and OriginalCurrency : What the client sent, converted into Amount with FxRate
+	OriginalAmount   money.Amount `db:"original_amount"`
+	OriginalCurrency string       `db:"original_currency"`
+	FxRate           money.Rate   `db:"fx_rate"`
+	// TransferId : Set on both legs of a transfer
+	TransferId sql.NullString `db:"transfer_id"`
+	// Reference : Unique per account when set, used to post system transactions only once
+	Reference sql.NullString `db:"reference"`
+	// ReversalOf : Set on a compensating transaction, the id of the transaction it reverses
+	ReversalOf sql.NullString `db:"reversal_of"`
+	// ReversedBy : Set on a reversed transaction, the id of its compensating transaction
+	ReversedBy sql.NullString `db:"reversed_by"`
+	// DailyLimits : Checked by the repository when saving a withdrawal, nil when no 24 hour limit applies
+	DailyLimits *DailyLimitCheck `db:"-"`
+	// Balance : Account balance after the transaction, only known right after saving it and nil otherwise
+	Balance *money.Amount `db:"-"`
+}
+
+// TransactionFilter : Criteria used to query the transaction history of an account
+type TransactionFilter struct {
+	AccountId       string
+	From            string
+	To              string
+	TransactionType string
+	MinAmount       *money.Amount
+	MaxAmount       *money.Amount
+	// BeforeId : Only transactions older than this id are returned, used as the pagination cursor
+	BeforeId int64
+	Limit    int
 }
 
 func (t Transaction) IsWithdrawal() bool {
@@ -21,12 +58,46 @@ func (t Transaction) IsWithdrawal() bool {
 	return false
 }
 
+// IsConverted : Returns true when the amount was converted from another currency
+func (t Transaction) IsConverted() bool {
+	return t.OriginalCurrency != "" && t.OriginalCurrency != t.Currency
+}
+
 func (t Transaction) ToDto() dto.TransactionResponse {
-	return dto.TransactionResponse{
+	response := dto.TransactionResponse{
 		TransactionId:   t.TransactionId,
 		AccountId:       t.AccountId,
 		Amount:          t.Amount,
+		Currency:        t.Currency,
+		NewBalance:      t.Balance,
 		TransactionType: t.TransactionType,
 		TransactionDate: t.TransactionDate,
+		TransferId:      t.TransferId.String,
+		ReversalOf:      t.ReversalOf.String,
+		ReversedBy:      t.ReversedBy.String,
+	}
+	if t.IsConverted() {
+		response.OriginalAmount = t.OriginalAmount
+		response.OriginalCurrency = t.OriginalCurrency
+		response.FxRate = t.FxRate
+	}
+	return response
+}
+
+// Cursor : Returns the opaque pagination cursor pointing right after this transaction
+func (t Transaction) Cursor() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.TransactionId))
+}
+
+// ParseTransactionCursor : Returns the transaction id encoded into a pagination cursor
+func ParseTransactionCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.New("invalid cursor")
 	}
+	return id, nil
 }
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"banking/dto"
+	"banking/money"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+)
+
+// Transfer : Moves money from one account to another, saved as a withdrawal and a deposit sharing the TransferId
+type Transfer struct {
+	TransferId    string
+	FromAccountId string
+	ToAccountId   string
+	// Amount is debited in Currency, the source account currency
+	Amount   money.Amount
+	Currency string
+	// CreditedAmount is Amount converted with FxRate into the destination account currency
+	CreditedAmount   money.Amount
+	CreditedCurrency string
+	FxRate           money.Rate
+	TransferDate     string
+	// DailyLimits : Checked by the repository on the source account, nil when no 24 hour limit applies
+	DailyLimits *DailyLimitCheck
+	// ClosingPayout : Empties an account that is being closed, the source account may be frozen
+	ClosingPayout bool
+	// Withdrawal and Deposit are the two legs recorded in the transactions table
+	Withdrawal Transaction
+	Deposit    Transaction
+}
+
+// WithdrawalLeg : The money leaving the source account, as fraud screening sees it before the transfer is saved
+func (t Transfer) WithdrawalLeg() Transaction {
+	return Transaction{AccountId: t.FromAccountId, Amount: t.Amount, TransactionType: WITHDRAWAL, TransactionDate: t.TransferDate,
+		Currency: t.Currency, OriginalAmount: t.Amount, OriginalCurrency: t.Currency, FxRate: money.OneToOne,
+		TransferId: sql.NullString{String: t.TransferId, Valid: t.TransferId != ""}}
+}
+
+// NewTransferId : Returns a random id used to link both legs of a transfer
+func NewTransferId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (t Transfer) ToDto() dto.TransferResponse {
+	return dto.TransferResponse{
+		TransferId:       t.TransferId,
+		FromAccountId:    t.FromAccountId,
+		ToAccountId:      t.ToAccountId,
+		Amount:           t.Amount,
+		Currency:         t.Currency,
+		NewBalance:       t.Withdrawal.Balance,
+		CreditedAmount:   t.CreditedAmount,
+		CreditedCurrency: t.CreditedCurrency,
+		FxRate:           t.FxRate,
+		TransferDate:     t.TransferDate,
+		Transactions:     []dto.TransactionResponse{t.Withdrawal.ToDto(), t.Deposit.ToDto()},
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"banking/errs"
+	"database/sql"
+)
+
+// Roles of the users table, banking-auth grants the routes per role
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// User : A login of banking-auth, users with the user role are tied to one customer
+type User struct {
+	Username   string         `db:"username"`
+	Password   string         `db:"password"`
+	Role       string         `db:"role"`
+	CustomerId sql.NullString `db:"customer_id"`
+	CreatedOn  string         `db:"created_on"`
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockUserRepository.go -package=domain banking/domain UserRepository
+type UserRepository interface {
+	// Save : Adds a login, an existing username is refused
+	Save(User) *errs.AppError
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// UserRepositoryDB : Writes the users table banking-auth logs in against, both services share the database
+type UserRepositoryDB struct {
+	client *sqlx.DB
+}
+
+func (d UserRepositoryDB) Save(u User) *errs.AppError {
+	var exists int
+	if err := get(d.client, &exists, `SELECT COUNT(*) FROM users WHERE username = ?`, u.Username); err != nil {
+		logger.Error("Error while fetching user: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if exists > 0 {
+		return errs.NewValidationError("Username " + u.Username + " is already taken")
+	}
+	_, err := exec(d.client, `INSERT INTO users (username, password, role, customer_id, created_on) VALUES (?, ?, ?, ?, ?)`,
+		u.Username, u.Password, u.Role, u.CustomerId, u.CreatedOn)
+	if err != nil {
+		logger.Error("Error while saving user: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+// NewUserRepositoryDB : Returns the users repository
+func NewUserRepositoryDB(dbClient *sqlx.DB) UserRepositoryDB {
+	return UserRepositoryDB{dbClient}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "banking/errs"
+
+// UserRepositoryStub : Users kept in a MemoryStore
+type UserRepositoryStub struct {
+	store *MemoryStore
+}
+
+func (s UserRepositoryStub) Save(u User) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for _, existing := range t.users {
+			if existing.Username == u.Username {
+				return errs.NewValidationError("Username " + u.Username + " is already taken")
+			}
+		}
+		t.users = append(t.users, u)
+		return nil
+	})
+}
+
+// NewUserRepositoryStub : Returns the in-memory users repository
+func NewUserRepositoryStub(store *MemoryStore) UserRepositoryStub {
+	return UserRepositoryStub{store}
+}
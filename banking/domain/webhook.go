@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"banking/dto"
+	"banking/errs"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Webhook delivery statuses, dead deliveries ran out of attempts and are not retried
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+// maxWebhookBackoff : Longest wait between two attempts of a delivery
+const maxWebhookBackoff = 6 * time.Hour
+
+// WebhookEventTypes : Events a customer can subscribe to
+var WebhookEventTypes = []string{EventAccountOpened, EventTransactionPosted, EventAccountStatusChanged}
+
+// WebhookSubscription : A customer endpoint receiving some of the events of the customer's accounts.
+// EventTypes is stored comma separated.
+type WebhookSubscription struct {
+	SubscriptionId string `db:"subscription_id"`
+	CustomerId     string `db:"customer_id"`
+	Url            string `db:"url"`
+	EventTypes     string `db:"event_types"`
+	Secret         string `db:"secret"`
+	CreatedAt      string `db:"created_at"`
+}
+
+// WebhookDelivery : One event sent to one subscription, with the state of its attempts.
+// Url and Secret are read from the subscription when the delivery is due.
+type WebhookDelivery struct {
+	DeliveryId     string         `db:"delivery_id"`
+	SubscriptionId string         `db:"subscription_id"`
+	EventId        string         `db:"event_id"`
+	EventType      string         `db:"event_type"`
+	Payload        []byte         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	NextAttemptAt  string         `db:"next_attempt_at"`
+	LastStatusCode sql.NullInt64  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	CreatedAt      string         `db:"created_at"`
+	DeliveredAt    sql.NullString `db:"delivered_at"`
+	Url            string         `db:"url"`
+	Secret         string         `db:"secret"`
+}
+
+//go:generate mockgen -destination=../mocks/domain/mockWebhookRepository.go -package=domain banking/domain WebhookRepository
+type WebhookRepository interface {
+	SaveSubscription(WebhookSubscription) (*WebhookSubscription, *errs.AppError)
+	FindSubscriptions(customerId string) ([]WebhookSubscription, *errs.AppError)
+	FindSubscription(subscriptionId string) (*WebhookSubscription, *errs.AppError)
+	// DeleteSubscription : Removes the subscription, its pending deliveries are dropped with it
+	DeleteSubscription(subscriptionId string) *errs.AppError
+	// FindSubscribers : Subscriptions of the customer that asked for the event type
+	FindSubscribers(customerId string, eventType string) ([]WebhookSubscription, *errs.AppError)
+	// SaveDeliveries : Queues the deliveries, an event already queued for a subscription is skipped
+	SaveDeliveries([]WebhookDelivery) *errs.AppError
+	// FindDueDeliveries : Pending deliveries whose next attempt is at or before now, oldest first
+	FindDueDeliveries(now string, limit int) ([]WebhookDelivery, *errs.AppError)
+	UpdateDelivery(WebhookDelivery) *errs.AppError
+	FindDeliveries(subscriptionId string) ([]WebhookDelivery, *errs.AppError)
+}
+
+// NewWebhookSecret : Returns a random key for signing the deliveries of a subscription
+func NewWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Subscribes : Returns true when the subscription asked for the event type
+func (s WebhookSubscription) Subscribes(eventType string) bool {
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDelivery : A delivery of the event to the subscription, due right away
+func (s WebhookSubscription) NewDelivery(e Event, payload []byte, now string) WebhookDelivery {
+	return WebhookDelivery{
+		SubscriptionId: s.SubscriptionId,
+		EventId:        e.EventId,
+		EventType:      e.EventType,
+		Payload:        payload,
+		Status:         DeliveryStatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+// Signature : HMAC-SHA256 of "<timestamp>.<payload>" with the subscription secret, hex encoded. Receivers
+// compute the same value and also reject old timestamps, so a captured request cannot be replayed later.
+func (d WebhookDelivery) Signature(timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(d.Secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(d.Payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Delivered : Records a successful attempt
+func (d *WebhookDelivery) Delivered(statusCode int, at time.Time, layout string) {
+	d.Attempts++
+	d.Status = DeliveryStatusDelivered
+	d.LastStatusCode = sql.NullInt64{Int64: int64(statusCode), Valid: statusCode != 0}
+	d.LastError = sql.NullString{}
+	d.DeliveredAt = sql.NullString{String: at.Format(layout), Valid: true}
+}
+
+// Failed : Records a failed attempt, the next one waits twice as long as the previous one starting at backoff.
+// After maxAttempts the delivery is dead lettered.
+func (d *WebhookDelivery) Failed(statusCode int, reason string, at time.Time, layout string, backoff time.Duration, maxAttempts int) {
+	d.Attempts++
+	d.LastStatusCode = sql.NullInt64{Int64: int64(statusCode), Valid: statusCode != 0}
+	d.LastError = sql.NullString{String: reason, Valid: true}
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryStatusDead
+		return
+	}
+	wait := backoff
+	for i := 1; i < d.Attempts && wait < maxWebhookBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxWebhookBackoff {
+		wait = maxWebhookBackoff
+	}
+	d.NextAttemptAt = at.Add(wait).Format(layout)
+}
+
+// ToDto : The subscription without its secret, which is only shown once when it is created
+func (s WebhookSubscription) ToDto() dto.WebhookResponse {
+	return dto.WebhookResponse{
+		WebhookId:  s.SubscriptionId,
+		CustomerId: s.CustomerId,
+		Url:        s.Url,
+		EventTypes: strings.Split(s.EventTypes, ","),
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func (d WebhookDelivery) ToDto() dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		DeliveryId:     d.DeliveryId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: int(d.LastStatusCode.Int64),
+		LastError:      d.LastError.String,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt.String,
+	}
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"banking/errs"
+	"banking/logger"
+	"database/sql"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const subscriptionColumns = "subscription_id, customer_id, url, event_types, secret, created_at"
+
+const deliveryColumns = "d.delivery_id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at, s.url, s.secret"
+
+type WebhookRepositoryDB struct {
+	client *sqlx.DB
+}
+
+func (d WebhookRepositoryDB) SaveSubscription(s WebhookSubscription) (*WebhookSubscription, *errs.AppError) {
+	id, err := insert(d.client, "subscription_id", `INSERT INTO webhook_subscriptions (customer_id, url, event_types, secret, created_at) VALUES (?, ?, ?, ?, ?)`,
+		s.CustomerId, s.Url, s.EventTypes, s.Secret, s.CreatedAt)
+	if err != nil {
+		logger.Error("Error while saving webhook subscription: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	s.SubscriptionId = strconv.FormatInt(id, 10)
+	return &s, nil
+}
+
+func (d WebhookRepositoryDB) FindSubscriptions(customerId string) ([]WebhookSubscription, *errs.AppError) {
+	subscriptions := make([]WebhookSubscription, 0)
+	err := selectAll(d.client, &subscriptions, "SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE customer_id = ? ORDER BY subscription_id", customerId)
+	if err != nil {
+		logger.Error("Error while querying webhook subscriptions: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return subscriptions, nil
+}
+
+func (d WebhookRepositoryDB) FindSubscription(subscriptionId string) (*WebhookSubscription, *errs.AppError) {
+	var s WebhookSubscription
+	err := get(d.client, &s, "SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE subscription_id = ?", subscriptionId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NewNotFoundError("Webhook not found")
+		}
+		logger.Error("Error while fetching webhook subscription: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return &s, nil
+}
+
+func (d WebhookRepositoryDB) DeleteSubscription(subscriptionId string) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for webhook removal: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	// the delivery log of a removed webhook goes with it
+	for _, sqlDelete := range []string{
+		`DELETE FROM webhook_deliveries WHERE subscription_id = ?`,
+		`DELETE FROM webhook_subscriptions WHERE subscription_id = ?`,
+	} {
+		if _, err = exec(tx, sqlDelete, subscriptionId); err != nil {
+			tx.Rollback()
+			logger.Error("Error while deleting webhook subscription: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting webhook removal: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func (d WebhookRepositoryDB) FindSubscribers(customerId string, eventType string) ([]WebhookSubscription, *errs.AppError) {
+	subscriptions, appErr := d.FindSubscriptions(customerId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	// a customer has a handful of webhooks, filtering here keeps the event types column a plain list
+	subscribers := make([]WebhookSubscription, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		if s.Subscribes(eventType) {
+			subscribers = append(subscribers, s)
+		}
+	}
+	return subscribers, nil
+}
+
+func (d WebhookRepositoryDB) SaveDeliveries(deliveries []WebhookDelivery) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting a new transaction for webhook deliveries: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	// the outbox delivers an event at least once, a replayed event finds its deliveries already there
+	sqlInsert := insertIgnore(d.client.DriverName(), `INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+								VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	for _, w := range deliveries {
+		_, err = exec(tx, sqlInsert, w.SubscriptionId, w.EventId, w.EventType, string(w.Payload), w.Status, w.Attempts,
+			w.NextAttemptAt, w.CreatedAt)
+		if err != nil {
+			tx.Rollback()
+			logger.Error("Error while saving webhook delivery: " + err.Error())
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		logger.Error("Error while commiting webhook deliveries: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func (d WebhookRepositoryDB) FindDueDeliveries(now string, limit int) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	err := selectAll(d.client, &deliveries, "SELECT "+deliveryColumns+` FROM webhook_deliveries d
+											JOIN webhook_subscriptions s ON s.subscription_id = d.subscription_id
+											WHERE d.status = ? AND d.next_attempt_at <= ? ORDER BY d.delivery_id LIMIT ?`,
+		DeliveryStatusPending, now, limit)
+	if err != nil {
+		logger.Error("Error while querying due webhook deliveries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return deliveries, nil
+}
+
+func (d WebhookRepositoryDB) UpdateDelivery(w WebhookDelivery) *errs.AppError {
+	_, err := exec(d.client, `UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, delivered_at = ?
+								WHERE delivery_id = ?`, w.Status, w.Attempts, w.NextAttemptAt, w.LastStatusCode, w.LastError, w.DeliveredAt, w.DeliveryId)
+	if err != nil {
+		logger.Error("Error while updating webhook delivery: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	return nil
+}
+
+func (d WebhookRepositoryDB) FindDeliveries(subscriptionId string) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	err := selectAll(d.client, &deliveries, "SELECT "+deliveryColumns+` FROM webhook_deliveries d
+											JOIN webhook_subscriptions s ON s.subscription_id = d.subscription_id
+											WHERE d.subscription_id = ? ORDER BY d.delivery_id DESC`, subscriptionId)
+	if err != nil {
+		logger.Error("Error while querying webhook deliveries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	return deliveries, nil
+}
+
+// NewWebhookRepositoryDB : Returns the webhook repository
+func NewWebhookRepositoryDB(dbClient *sqlx.DB) WebhookRepositoryDB {
+	return WebhookRepositoryDB{dbClient}
+}
@@ -0,0 +1,159 @@
+package domain
+
+import "banking/errs"
+
+// WebhookRepositoryStub : Webhook subscriptions and their deliveries kept in a MemoryStore
+type WebhookRepositoryStub struct {
+	store *MemoryStore
+}
+
+func (s WebhookRepositoryStub) SaveSubscription(w WebhookSubscription) (*WebhookSubscription, *errs.AppError) {
+	s.store.write(func(t *memoryTables) *errs.AppError {
+		w.SubscriptionId = t.nextId("webhook_subscriptions")
+		t.subscriptions = append(t.subscriptions, w)
+		return nil
+	})
+	return &w, nil
+}
+
+func (s WebhookRepositoryStub) FindSubscriptions(customerId string) ([]WebhookSubscription, *errs.AppError) {
+	subscriptions := make([]WebhookSubscription, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, w := range t.subscriptions {
+			if w.CustomerId == customerId {
+				subscriptions = append(subscriptions, w)
+			}
+		}
+	})
+	return subscriptions, nil
+}
+
+func (s WebhookRepositoryStub) FindSubscription(subscriptionId string) (*WebhookSubscription, *errs.AppError) {
+	var found *WebhookSubscription
+	s.store.read(func(t *memoryTables) {
+		if w := t.subscription(subscriptionId); w != nil {
+			copied := *w
+			found = &copied
+		}
+	})
+	if found == nil {
+		return nil, errs.NewNotFoundError("Webhook not found")
+	}
+	return found, nil
+}
+
+func (s WebhookRepositoryStub) DeleteSubscription(subscriptionId string) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		subscriptions := t.subscriptions[:0]
+		for _, w := range t.subscriptions {
+			if w.SubscriptionId != subscriptionId {
+				subscriptions = append(subscriptions, w)
+			}
+		}
+		t.subscriptions = subscriptions
+		deliveries := t.deliveries[:0]
+		for _, d := range t.deliveries {
+			if d.SubscriptionId != subscriptionId {
+				deliveries = append(deliveries, d)
+			}
+		}
+		t.deliveries = deliveries
+		return nil
+	})
+}
+
+func (s WebhookRepositoryStub) FindSubscribers(customerId string, eventType string) ([]WebhookSubscription, *errs.AppError) {
+	subscriptions, _ := s.FindSubscriptions(customerId)
+	subscribers := make([]WebhookSubscription, 0, len(subscriptions))
+	for _, w := range subscriptions {
+		if w.Subscribes(eventType) {
+			subscribers = append(subscribers, w)
+		}
+	}
+	return subscribers, nil
+}
+
+func (s WebhookRepositoryStub) SaveDeliveries(deliveries []WebhookDelivery) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for _, d := range deliveries {
+			if t.hasDelivery(d.SubscriptionId, d.EventId) {
+				continue
+			}
+			d.DeliveryId = t.nextId("webhook_deliveries")
+			t.deliveries = append(t.deliveries, d)
+		}
+		return nil
+	})
+}
+
+func (s WebhookRepositoryStub) FindDueDeliveries(now string, limit int) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	s.store.read(func(t *memoryTables) {
+		for _, d := range t.deliveries {
+			if len(deliveries) == limit {
+				break
+			}
+			if d.Status == DeliveryStatusPending && d.NextAttemptAt <= now {
+				deliveries = append(deliveries, t.withSubscription(d))
+			}
+		}
+	})
+	return deliveries, nil
+}
+
+func (s WebhookRepositoryStub) UpdateDelivery(d WebhookDelivery) *errs.AppError {
+	return s.store.write(func(t *memoryTables) *errs.AppError {
+		for i := range t.deliveries {
+			if t.deliveries[i].DeliveryId == d.DeliveryId {
+				d.Url, d.Secret = "", ""
+				t.deliveries[i] = d
+			}
+		}
+		return nil
+	})
+}
+
+// FindDeliveries : Delivery log of a subscription, newest first
+func (s WebhookRepositoryStub) FindDeliveries(subscriptionId string) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	s.store.read(func(t *memoryTables) {
+		for i := len(t.deliveries) - 1; i >= 0; i-- {
+			if t.deliveries[i].SubscriptionId == subscriptionId {
+				deliveries = append(deliveries, t.withSubscription(t.deliveries[i]))
+			}
+		}
+	})
+	return deliveries, nil
+}
+
+func (t *memoryTables) subscription(subscriptionId string) *WebhookSubscription {
+	for i := range t.subscriptions {
+		if t.subscriptions[i].SubscriptionId == subscriptionId {
+			return &t.subscriptions[i]
+		}
+	}
+	return nil
+}
+
+// withSubscription : Fills in the url and secret like the join of the database repository
+func (t *memoryTables) withSubscription(d WebhookDelivery) WebhookDelivery {
+	if w := t.subscription(d.SubscriptionId); w != nil {
+		d.Url, d.Secret = w.Url, w.Secret
+	}
+	return d
+}
+
+// hasDelivery : Whether the event was already queued for the subscription, like the unique key of the database
+func (t *memoryTables) hasDelivery(subscriptionId string, eventId string) bool {
+	for _, d := range t.deliveries {
+		if d.SubscriptionId == subscriptionId && d.EventId == eventId {
+			return true
+		}
+	}
+	return false
+}
+
+// NewWebhookRepositoryStub : Returns the webhook repository working on the store
+func NewWebhookRepositoryStub(store *MemoryStore) WebhookRepositoryStub {
+	return WebhookRepositoryStub{store}
+}
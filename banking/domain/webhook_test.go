@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_should_double_the_wait_after_every_failed_delivery(t *testing.T) {
+	at := time.Date(2021, 1, 31, 10, 0, 0, 0, time.UTC)
+	d := WebhookDelivery{Status: DeliveryStatusPending}
+	want := []string{"2021-01-31 10:00:30", "2021-01-31 10:01:00", "2021-01-31 10:02:00"}
+	for i, next := range want {
+		d.Failed(500, "receiver answered 500", at, fraudTSLayout, 30*time.Second, 5)
+		if d.Status != DeliveryStatusPending || d.NextAttemptAt != next {
+			t.Errorf("attempt %d: status = %s, next = %s, want %s", i+1, d.Status, d.NextAttemptAt, next)
+		}
+	}
+	d.Failed(500, "receiver answered 500", at, fraudTSLayout, 30*time.Second, 4)
+	if d.Status != DeliveryStatusDead {
+		t.Errorf("status = %s, want %s after the last attempt", d.Status, DeliveryStatusDead)
+	}
+}
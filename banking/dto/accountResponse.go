@@ -0,0 +1,16 @@
+package dto
+
+import "banking/money"
+
+type AccountResponse struct {
+	AccountId   string       `json:"account_id"`
+	CustomerId  string       `json:"customer_id"`
+	OpeningDate string       `json:"opening_date"`
+	AccountType string       `json:"account_type"`
+	Currency    string       `json:"currency"`
+	Balance     money.Amount `json:"balance"`
+	Status      string       `json:"status"`
+	// AvailableBalance : Balance plus what is left of the overdraft
+	AvailableBalance money.Amount `json:"available_balance"`
+	OverdraftLimit   money.Amount `json:"overdraft_limit"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"banking/errs"
+	"strings"
+)
+
+// AccountStatusRequest : Freezes, unfreezes or closes an account, PayoutAccountId only applies when closing
+type AccountStatusRequest struct {
+	AccountId       string `json:"-"`
+	CustomerId      string `json:"-"`
+	Actor           string `json:"-"`
+	Reason          string `json:"reason"`
+	PayoutAccountId string `json:"payout_account_id"`
+}
+
+func (r AccountStatusRequest) Validate() *errs.AppError {
+	if strings.TrimSpace(r.Reason) == "" {
+		return errs.NewValidationError("A reason is required to change the account status")
+	}
+	if r.Actor == "" {
+		return errs.NewValidationError("The user changing the account status is unknown")
+	}
+	if r.PayoutAccountId != "" && r.PayoutAccountId == r.AccountId {
+		return errs.NewValidationError("Cannot pay out to the account being closed")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"banking/errs"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// oldestDateOfBirth : Anything before this is treated as a typo
+const oldestDateOfBirth = "1900-01-01"
+
+var zipcodePattern = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z \-]{2,9}$`)
+
+// CustomerRequest : Creates a customer, or replaces all of its details on PUT
+type CustomerRequest struct {
+	Name        string `json:"full_name"`
+	City        string `json:"city"`
+	Zipcode     string `json:"zipcode"`
+	DateofBirth string `json:"date_of_birth"`
+}
+
+// Validate : Validates the customer details with the bussiness rules
+func (r CustomerRequest) Validate() *errs.AppError {
+	if name := strings.TrimSpace(r.Name); name == "" || len(name) > 100 {
+		return errs.NewValidationError("full_name is required and should be at most 100 characters")
+	}
+	if city := strings.TrimSpace(r.City); city == "" || len(city) > 100 {
+		return errs.NewValidationError("city is required and should be at most 100 characters")
+	}
+	if !zipcodePattern.MatchString(r.Zipcode) {
+		return errs.NewValidationError("zipcode should be 3 to 10 letters, digits, spaces or dashes")
+	}
+	dob, err := time.Parse("2006-01-02", r.DateofBirth)
+	if err != nil {
+		return errs.NewValidationError("date_of_birth should be a date like 2006-01-02")
+	}
+	oldest, _ := time.Parse("2006-01-02", oldestDateOfBirth)
+	if dob.Before(oldest) || !dob.Before(time.Now()) {
+		return errs.NewValidationError("date_of_birth should be between " + oldestDateOfBirth + " and today")
+	}
+	return nil
+}
+
+// CustomerPatchRequest : Changes only the customer details present in the body
+type CustomerPatchRequest struct {
+	Name        *string `json:"full_name"`
+	City        *string `json:"city"`
+	Zipcode     *string `json:"zipcode"`
+	DateofBirth *string `json:"date_of_birth"`
+}
+
+// ApplyTo : Returns the full request after applying the patch, the result still has to be validated
+func (r CustomerPatchRequest) ApplyTo(current CustomerRequest) CustomerRequest {
+	if r.Name != nil {
+		current.Name = *r.Name
+	}
+	if r.City != nil {
+		current.City = *r.City
+	}
+	if r.Zipcode != nil {
+		current.Zipcode = *r.Zipcode
+	}
+	if r.DateofBirth != nil {
+		current.DateofBirth = *r.DateofBirth
+	}
+	return current
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_should_accept_a_valid_customer_request(t *testing.T) {
+	// Arrange
+	request := CustomerRequest{Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205", DateofBirth: "1970-01-01"}
+	// Act
+	appError := request.Validate()
+	// Assert
+	if appError != nil {
+		t.Error("Failed while validating a valid customer: " + appError.Message)
+	}
+}
+
+func Test_should_reject_invalid_customer_details(t *testing.T) {
+	valid := CustomerRequest{Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205", DateofBirth: "1970-01-01"}
+	tests := []struct {
+		name    string
+		request CustomerRequest
+	}{
+		{"empty name", CustomerPatchRequest{Name: ptr("  ")}.ApplyTo(valid)},
+		{"empty city", CustomerPatchRequest{City: ptr("")}.ApplyTo(valid)},
+		{"bad zipcode", CustomerPatchRequest{Zipcode: ptr("1")}.ApplyTo(valid)},
+		{"bad date", CustomerPatchRequest{DateofBirth: ptr("01/01/1970")}.ApplyTo(valid)},
+		{"future date", CustomerPatchRequest{DateofBirth: ptr("2999-01-01")}.ApplyTo(valid)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			appError := tc.request.Validate()
+			if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+				t.Error("Failed while validating " + tc.name)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"banking/errs"
+	"strings"
+	"time"
+)
+
+// DefaultCustomerLimit : Page size used when the client does not ask for one
+const DefaultCustomerLimit = 50
+
+// MaxCustomerLimit : Largest page size a client can ask for
+const MaxCustomerLimit = 200
+
+// CustomerSortFields : Fields the customer list can be sorted by, the customer id always breaks ties
+var CustomerSortFields = []string{"customer_id", "name", "city", "zipcode", "date_of_birth"}
+
+// CustomerSearchRequest : Filters, sorting and pagination for the customer list
+type CustomerSearchRequest struct {
+	Status   string
+	Name     string
+	City     string
+	Zipcode  string
+	BornFrom string
+	BornTo   string
+	Sort     string
+	Order    string
+	Cursor   string
+	Limit    int
+}
+
+// Validate : Validates the customer filters, dates are expected as YYYY-MM-DD
+func (r CustomerSearchRequest) Validate() *errs.AppError {
+	if r.Status != "" && r.Status != "active" && r.Status != "inactive" {
+		return errs.NewValidationError("status can only be active or inactive")
+	}
+	var from, to time.Time
+	var err error
+	if r.BornFrom != "" {
+		if from, err = time.Parse(dateLayout, r.BornFrom); err != nil {
+			return errs.NewValidationError("born_from should be a date formatted as YYYY-MM-DD")
+		}
+	}
+	if r.BornTo != "" {
+		if to, err = time.Parse(dateLayout, r.BornTo); err != nil {
+			return errs.NewValidationError("born_to should be a date formatted as YYYY-MM-DD")
+		}
+	}
+	if r.BornFrom != "" && r.BornTo != "" && to.Before(from) {
+		return errs.NewValidationError("born_from cannot be after born_to")
+	}
+	if r.Sort != "" && !isCustomerSortField(r.Sort) {
+		return errs.NewValidationError("sort can only be one of " + strings.Join(CustomerSortFields, ", "))
+	}
+	if r.Order != "" && r.Order != "asc" && r.Order != "desc" {
+		return errs.NewValidationError("order can only be asc or desc")
+	}
+	if r.Limit < 0 || r.Limit > MaxCustomerLimit {
+		return errs.NewValidationError("limit should be between 1 and 200")
+	}
+	return nil
+}
+
+// SortField : Returns the requested sort field or the customer id
+func (r CustomerSearchRequest) SortField() string {
+	if r.Sort == "" {
+		return "customer_id"
+	}
+	return r.Sort
+}
+
+// PageSize : Returns the requested page size or the default one
+func (r CustomerSearchRequest) PageSize() int {
+	if r.Limit == 0 {
+		return DefaultCustomerLimit
+	}
+	return r.Limit
+}
+
+func isCustomerSortField(field string) bool {
+	for _, f := range CustomerSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomerSearchResponse : A page of customers, Next is the cursor for the following page
+type CustomerSearchResponse struct {
+	Customers []CustomerResponse `json:"customers"`
+	Next      string             `json:"next,omitempty"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_should_reject_invalid_customer_filters(t *testing.T) {
+	tests := []struct {
+		name    string
+		request CustomerSearchRequest
+	}{
+		{"unknown status", CustomerSearchRequest{Status: "closed"}},
+		{"bad born_from", CustomerSearchRequest{BornFrom: "1970/01/01"}},
+		{"inverted range", CustomerSearchRequest{BornFrom: "1980-01-01", BornTo: "1970-01-01"}},
+		{"unknown sort", CustomerSearchRequest{Sort: "status"}},
+		{"unknown order", CustomerSearchRequest{Order: "up"}},
+		{"limit too big", CustomerSearchRequest{Limit: 500}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			appError := tc.request.Validate()
+			if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+				t.Error("Failed while validating " + tc.name)
+			}
+		})
+	}
+}
+
+func Test_should_accept_customer_filters(t *testing.T) {
+	request := CustomerSearchRequest{Status: "active", Name: "joestar", BornFrom: "1900-01-01", BornTo: "1950-12-31",
+		Sort: "date_of_birth", Order: "desc", Limit: 20}
+	if appError := request.Validate(); appError != nil {
+		t.Error("Failed while validating customer filters: " + appError.Message)
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "encoding/json"
+
+// EventMessage : A domain event as delivered to subscribers, Payload depends on the event type
+type EventMessage struct {
+	EventId     string          `json:"event_id"`
+	EventType   string          `json:"event_type"`
+	AggregateId string          `json:"aggregate_id"`
+	OccurredAt  string          `json:"occurred_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// AccountStatusChangedEvent : Payload of AccountStatusChanged
+type AccountStatusChangedEvent struct {
+	AccountId  string `json:"account_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+	Actor      string `json:"actor"`
+	ChangedAt  string `json:"changed_at"`
+}
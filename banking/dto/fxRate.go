@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"banking/errs"
+	"banking/money"
+	"time"
+)
+
+type FxRateRequest struct {
+	BaseCurrency  string     `json:"base_currency"`
+	QuoteCurrency string     `json:"quote_currency"`
+	Rate          money.Rate `json:"rate"`
+	EffectiveDate string     `json:"effective_date"`
+}
+
+// Validate : Validates a rate to be loaded, the effective date is expected as YYYY-MM-DD
+func (r FxRateRequest) Validate() *errs.AppError {
+	if !money.IsSupportedCurrency(r.BaseCurrency) || !money.IsSupportedCurrency(r.QuoteCurrency) {
+		return errs.NewValidationError("Unsupported currency " + r.BaseCurrency + "/" + r.QuoteCurrency)
+	}
+	if r.BaseCurrency == r.QuoteCurrency {
+		return errs.NewValidationError("Base and quote currency should be different")
+	}
+	if r.Rate <= 0 {
+		return errs.NewValidationError("Rate should be greater than zero")
+	}
+	if _, err := time.Parse(dateLayout, r.EffectiveDate); err != nil {
+		return errs.NewValidationError("effective_date should be a date formatted as YYYY-MM-DD")
+	}
+	return nil
+}
+
+type FxRateResponse struct {
+	BaseCurrency  string     `json:"base_currency"`
+	QuoteCurrency string     `json:"quote_currency"`
+	Rate          money.Rate `json:"rate"`
+	EffectiveDate string     `json:"effective_date"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// HealthResponse : Body of the liveness and readiness probes, checks has the result of every dependency
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "banking/money"
+
+// InterestRunResponse : Summary of an interest batch run
+type InterestRunResponse struct {
+	AsOf              string                  `json:"as_of"`
+	AccountsProcessed int                     `json:"accounts_processed"`
+	DaysAccrued       int                     `json:"days_accrued"`
+	Postings          int                     `json:"postings"`
+	PostedByCurrency  map[string]money.Amount `json:"posted_by_currency"`
+}
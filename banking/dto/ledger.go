@@ -0,0 +1,25 @@
+package dto
+
+import "banking/money"
+
+type LedgerBalanceResponse struct {
+	LedgerAccount string       `json:"ledger_account"`
+	Currency      string       `json:"currency"`
+	Debits        money.Amount `json:"debits"`
+	Credits       money.Amount `json:"credits"`
+	Balance       money.Amount `json:"balance"`
+}
+
+type BalanceMismatchResponse struct {
+	AccountId     string       `json:"account_id"`
+	StoredBalance money.Amount `json:"stored_balance"`
+	LedgerBalance money.Amount `json:"ledger_balance"`
+}
+
+// TrialBalanceResponse : Totals per ledger account, Balanced is true when debits equal credits in every
+// currency and no account balance differs from its ledger balance
+type TrialBalanceResponse struct {
+	Accounts   []LedgerBalanceResponse   `json:"accounts"`
+	Mismatches []BalanceMismatchResponse `json:"mismatches"`
+	Balanced   bool                      `json:"balanced"`
+}
@@ -2,19 +2,33 @@ package dto
 
 import (
 	"banking/errs"
+	"banking/money"
 	"strings"
 )
 
+// MinimumOpeningBalances : Smallest deposit a new account can be opened with, per currency
+var MinimumOpeningBalances = map[string]money.Amount{
+	"USD": money.FromUnits(5000),
+	"EUR": money.FromUnits(5000),
+	"GBP": money.FromUnits(4000),
+	"CRC": money.FromUnits(2500000),
+}
+
 type NewAccountRequest struct {
-	CustomerId  string  `json:"customer_id"`
-	AccountType string  `json:"account_type"`
-	Amount      float64 `json:"amount"`
+	CustomerId  string       `json:"customer_id"`
+	AccountType string       `json:"account_type"`
+	Currency    string       `json:"currency"`
+	Amount      money.Amount `json:"amount"`
 }
 
 // Validate : Validates the dto account request with the bussiness rules
 func (r NewAccountRequest) Validate() *errs.AppError {
-	if r.Amount < 5000 {
-		return errs.NewValidationError("To open a new account you need to deposit atleast 5000.00")
+	minimum, ok := MinimumOpeningBalances[r.Currency]
+	if !ok || !money.IsSupportedCurrency(r.Currency) {
+		return errs.NewValidationError("Unsupported currency " + r.Currency)
+	}
+	if r.Amount < minimum {
+		return errs.NewValidationError("To open a new account you need to deposit atleast " + minimum.String() + " " + r.Currency)
 	}
 	if strings.ToLower(r.AccountType) != "savings" && strings.ToLower(r.AccountType) != "checking" {
 		return errs.NewValidationError("Account type should be checking or savings.")
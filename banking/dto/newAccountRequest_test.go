@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"banking/money"
+	"testing"
+)
+
+func Test_should_use_the_minimum_opening_balance_of_the_account_currency(t *testing.T) {
+	// Arrange
+	request := NewAccountRequest{AccountType: "savings", Currency: "GBP", Amount: money.FromUnits(4500)}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err != nil {
+		t.Error("4500.00 GBP should be enough to open an account")
+	}
+	request.Currency = "USD"
+	if err := request.Validate(); err == nil || err.Message != "To open a new account you need to deposit atleast 5000.00 USD" {
+		t.Error("Invalid error message was thrown when validating the opening balance.")
+	}
+}
+
+func Test_should_return_error_when_the_account_currency_is_not_supported(t *testing.T) {
+	// Arrange
+	request := NewAccountRequest{AccountType: "savings", Currency: "XYZ", Amount: money.FromUnits(10000)}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err == nil || err.Message != "Unsupported currency XYZ" {
+		t.Error("Invalid error message was thrown when validating the currency.")
+	}
+}
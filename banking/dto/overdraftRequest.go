@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"banking/errs"
+	"banking/money"
+)
+
+// OverdraftRequest : Replaces the overdraft limit of an account
+type OverdraftRequest struct {
+	AccountId      string        `json:"-"`
+	CustomerId     string        `json:"-"`
+	OverdraftLimit *money.Amount `json:"overdraft_limit"`
+}
+
+func (r OverdraftRequest) Validate() *errs.AppError {
+	if r.OverdraftLimit == nil {
+		return errs.NewValidationError("overdraft_limit is required")
+	}
+	if *r.OverdraftLimit < 0 {
+		return errs.NewValidationError("overdraft_limit cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "banking/money"
+
+// PendingTransactionResponse : A transaction held for review by fraud screening
+type PendingTransactionResponse struct {
+	PendingId       string       `json:"pending_id"`
+	AccountId       string       `json:"account_id"`
+	Amount          money.Amount `json:"amount"`
+	Currency        string       `json:"currency"`
+	TransactionType string       `json:"transaction_type"`
+	RequestedAt     string       `json:"requested_at"`
+	Reasons         string       `json:"reasons"`
+	Status          string       `json:"status"`
+	DecidedBy       string       `json:"decided_by,omitempty"`
+	DecidedAt       string       `json:"decided_at,omitempty"`
+	TransactionId   string       `json:"transaction_id,omitempty"`
+	// ToAccountId : Set when the held transaction is a transfer
+	ToAccountId string `json:"to_account_id,omitempty"`
+}
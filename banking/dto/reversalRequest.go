@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"banking/errs"
+	"strings"
+)
+
+// ReversalRequest : Reverses a posted transaction, Force allows the reversal to overdraw the account
+type ReversalRequest struct {
+	AccountId     string `json:"-"`
+	CustomerId    string `json:"-"`
+	TransactionId string `json:"-"`
+	Actor         string `json:"-"`
+	Reason        string `json:"reason"`
+	Force         bool   `json:"force"`
+}
+
+func (r ReversalRequest) Validate() *errs.AppError {
+	if strings.TrimSpace(r.Reason) == "" {
+		return errs.NewValidationError("A reason is required to reverse a transaction")
+	}
+	if r.Actor == "" {
+		return errs.NewValidationError("The user reversing the transaction is unknown")
+	}
+	return nil
+}
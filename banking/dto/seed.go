@@ -0,0 +1,10 @@
+package dto
+
+// SeedResponse : Summary of a seed run
+type SeedResponse struct {
+	Seed         int64 `json:"seed"`
+	Customers    int   `json:"customers"`
+	Accounts     int   `json:"accounts"`
+	Transactions int   `json:"transactions"`
+	Users        int   `json:"users"`
+}
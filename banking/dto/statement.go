@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"banking/errs"
+	"banking/money"
+	"time"
+)
+
+type StatementRequest struct {
+	AccountId  string
+	CustomerId string
+	From       string
+	To         string
+}
+
+// Validate : Both dates are required and expected as YYYY-MM-DD
+func (r StatementRequest) Validate() *errs.AppError {
+	from, err := time.Parse(dateLayout, r.From)
+	if err != nil {
+		return errs.NewValidationError("from should be a date formatted as YYYY-MM-DD")
+	}
+	to, err := time.Parse(dateLayout, r.To)
+	if err != nil {
+		return errs.NewValidationError("to should be a date formatted as YYYY-MM-DD")
+	}
+	if to.Before(from) {
+		return errs.NewValidationError("from cannot be after to")
+	}
+	return nil
+}
+
+type StatementResponse struct {
+	AccountId      string                  `json:"account_id"`
+	AccountType    string                  `json:"account_type"`
+	Currency       string                  `json:"currency"`
+	From           string                  `json:"from"`
+	To             string                  `json:"to"`
+	OpeningBalance money.Amount            `json:"opening_balance"`
+	ClosingBalance money.Amount            `json:"closing_balance"`
+	Lines          []StatementLineResponse `json:"transactions"`
+}
+
+// StatementLineResponse : Amount is negative for money leaving the account
+type StatementLineResponse struct {
+	TransactionId   string       `json:"transaction_id"`
+	TransactionDate string       `json:"transaction_date"`
+	TransactionType string       `json:"transaction_type"`
+	Amount          money.Amount `json:"amount"`
+	RunningBalance  money.Amount `json:"running_balance"`
+	TransferId      string       `json:"transfer_id,omitempty"`
+}
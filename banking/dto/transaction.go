@@ -1,16 +1,24 @@
 package dto
 
-import "banking/errs"
+import (
+	"banking/errs"
+	"banking/money"
+)
 
 const WITHDRAWAL = "withdrawal"
 const DEPOSIT = "deposit"
+const INTEREST = "interest"
 
 type TransactionRequest struct {
-	AccountId       string  `json:"account_id"`
-	Amount          float64 `json:"amount"`
-	TransactionType string  `json:"transaction_type"`
-	TransactionDate string  `json:"transaction_date"`
-	CustomerId      string  `json:"-"`
+	AccountId       string       `json:"account_id"`
+	Amount          money.Amount `json:"amount"`
+	TransactionType string       `json:"transaction_type"`
+	TransactionDate string       `json:"transaction_date"`
+	// Currency : Currency of the amount, the account currency when empty
+	Currency   string `json:"currency"`
+	CustomerId string `json:"-"`
+	// Role : Role of the caller, some roles have their own withdrawal limits
+	Role string `json:"-"`
 }
 
 func (r TransactionRequest) IsTransactionTypeWithdrawal() bool {
@@ -25,16 +33,41 @@ func (r TransactionRequest) Validate() *errs.AppError {
 	if !r.IsTransactionTypeWithdrawal() && !r.IsTransactionTypeDeposit() {
 		return errs.NewValidationError("Transaction type can only be deposit or withdrawal")
 	}
-	if r.Amount < 0 {
-		return errs.NewValidationError("Amount cannot be less than zero")
+	if r.Amount <= 0 {
+		return errs.NewValidationError("Amount should be greater than zero")
+	}
+	if r.Currency != "" && !money.IsSupportedCurrency(r.Currency) {
+		return errs.NewValidationError("Unsupported currency " + r.Currency)
 	}
 	return nil
 }
 
 type TransactionResponse struct {
-	TransactionId   string  `json:"transaction_id"`
-	AccountId       string  `json:"account_id"`
-	Amount          float64 `json:"new_balance"`
-	TransactionType string  `json:"transaction_type"`
-	TransactionDate string  `json:"transaction_date"`
+	TransactionId string       `json:"transaction_id"`
+	AccountId     string       `json:"account_id"`
+	Amount        money.Amount `json:"amount"`
+	Currency      string       `json:"currency"`
+	// NewBalance : Set only when the balance after the transaction is known, a zero balance is still reported
+	NewBalance      *money.Amount `json:"new_balance,omitempty"`
+	TransactionType string        `json:"transaction_type"`
+	TransactionDate string        `json:"transaction_date"`
+	TransferId      string        `json:"transfer_id,omitempty"`
+	// set only when the amount was converted from another currency
+	OriginalAmount   money.Amount `json:"original_amount,omitempty"`
+	OriginalCurrency string       `json:"original_currency,omitempty"`
+	FxRate           money.Rate   `json:"fx_rate,omitempty"`
+	// set only on reversals and on the transactions they reversed
+	ReversalOf string `json:"reversal_of,omitempty"`
+	ReversedBy string `json:"reversed_by,omitempty"`
+	// set only when fraud screening held the transaction for review, no money has moved yet
+	Status    string `json:"status,omitempty"`
+	PendingId string `json:"pending_id,omitempty"`
+}
+
+// TransactionStatusPending : Status of a transaction held for review
+const TransactionStatusPending = "pending"
+
+// IsPending : The transaction was held for review instead of being posted
+func (r TransactionResponse) IsPending() bool {
+	return r.Status == TransactionStatusPending
 }
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"banking/errs"
+	"banking/money"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// DefaultHistoryLimit : Page size used when the client does not ask for one
+const DefaultHistoryLimit = 50
+
+// MaxHistoryLimit : Largest page size a client can ask for
+const MaxHistoryLimit = 200
+
+// TransactionHistoryRequest : Filters and pagination for the transaction history of an account
+type TransactionHistoryRequest struct {
+	AccountId       string
+	CustomerId      string
+	From            string
+	To              string
+	TransactionType string
+	MinAmount       *money.Amount
+	MaxAmount       *money.Amount
+	Cursor          string
+	Limit           int
+}
+
+// Validate : Validates the history filters, dates are expected as YYYY-MM-DD
+func (r TransactionHistoryRequest) Validate() *errs.AppError {
+	var from, to time.Time
+	var err error
+	if r.From != "" {
+		if from, err = time.Parse(dateLayout, r.From); err != nil {
+			return errs.NewValidationError("from should be a date formatted as YYYY-MM-DD")
+		}
+	}
+	if r.To != "" {
+		if to, err = time.Parse(dateLayout, r.To); err != nil {
+			return errs.NewValidationError("to should be a date formatted as YYYY-MM-DD")
+		}
+	}
+	if r.From != "" && r.To != "" && to.Before(from) {
+		return errs.NewValidationError("from cannot be after to")
+	}
+	if r.TransactionType != "" && r.TransactionType != WITHDRAWAL && r.TransactionType != DEPOSIT && r.TransactionType != INTEREST {
+		return errs.NewValidationError("Transaction type can only be deposit, withdrawal or interest")
+	}
+	if (r.MinAmount != nil && *r.MinAmount < 0) || (r.MaxAmount != nil && *r.MaxAmount < 0) {
+		return errs.NewValidationError("Amount cannot be less than zero")
+	}
+	if r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount > *r.MaxAmount {
+		return errs.NewValidationError("min_amount cannot be greater than max_amount")
+	}
+	if r.Limit < 0 || r.Limit > MaxHistoryLimit {
+		return errs.NewValidationError("limit should be between 1 and 200")
+	}
+	return nil
+}
+
+// PageSize : Returns the requested page size or the default one
+func (r TransactionHistoryRequest) PageSize() int {
+	if r.Limit == 0 {
+		return DefaultHistoryLimit
+	}
+	return r.Limit
+}
+
+// TransactionHistoryResponse : A page of transactions, Next is the cursor for the following page
+type TransactionHistoryResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	Next         string                `json:"next,omitempty"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"banking/money"
+	"net/http"
+	"testing"
+)
+
+func Test_should_return_error_when_history_from_date_is_after_to_date(t *testing.T) {
+	// Arrange
+	request := TransactionHistoryRequest{From: "2021-02-01", To: "2021-01-01"}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err == nil || err.Message != "from cannot be after to" {
+		t.Error("Invalid error message was thrown when validating the history date range.")
+	}
+}
+
+func Test_should_return_error_when_history_min_amount_is_greater_than_max_amount(t *testing.T) {
+	// Arrange
+	min, max := money.FromUnits(500), money.FromUnits(100)
+	request := TransactionHistoryRequest{MinAmount: &min, MaxAmount: &max}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Error("Invalid error code was thrown when validating the history amount range.")
+	}
+}
+
+func Test_should_use_the_default_page_size_when_no_limit_is_given(t *testing.T) {
+	// Arrange
+	request := TransactionHistoryRequest{}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err != nil {
+		t.Error("An empty history request should be valid.")
+	}
+	if request.PageSize() != DefaultHistoryLimit {
+		t.Error("Failed while testing the default page size.")
+	}
+}
@@ -1,7 +1,10 @@
 package dto
 
 import (
+	"banking/money"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -25,10 +28,35 @@ func Test_should_return_error_when_amount_is_less_than_zeo(t *testing.T) {
 	// Act
 	err := request.Validate()
 	// Assert
-	if err.Message != "Amount cannot be less than zero" {
+	if err.Message != "Amount should be greater than zero" {
 		t.Error("Invalid error message was thrown when validating transaction amount.")
 	}
 	if err.Code != http.StatusUnprocessableEntity {
 		t.Error("Invalid error code was thrown when validating transaction amount")
 	}
 }
+
+func Test_should_return_error_when_amount_is_zero(t *testing.T) {
+	// Arrange
+	request := TransactionRequest{TransactionType: DEPOSIT, Amount: 0}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err == nil || err.Message != "Amount should be greater than zero" {
+		t.Error("Invalid error message was thrown when validating a zero transaction amount.")
+	}
+}
+
+func Test_should_report_a_zero_new_balance_and_omit_an_unknown_one(t *testing.T) {
+	zero := money.Amount(0)
+
+	known, _ := json.Marshal(TransactionResponse{TransactionId: "1", NewBalance: &zero})
+	unknown, _ := json.Marshal(TransactionResponse{TransactionId: "2"})
+
+	if !strings.Contains(string(known), `"new_balance":0`) {
+		t.Error("A zero balance should be reported: " + string(known))
+	}
+	if strings.Contains(string(unknown), "new_balance") {
+		t.Error("An unknown balance should be omitted: " + string(unknown))
+	}
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"banking/errs"
+	"banking/money"
+)
+
+type TransferRequest struct {
+	FromAccountId string       `json:"-"`
+	ToAccountId   string       `json:"to_account_id"`
+	Amount        money.Amount `json:"amount"`
+	CustomerId    string       `json:"-"`
+	// Role : Role of the caller, some roles have their own withdrawal limits
+	Role string `json:"-"`
+}
+
+// Validate : Validates the transfer request with the bussiness rules
+func (r TransferRequest) Validate() *errs.AppError {
+	if r.ToAccountId == "" {
+		return errs.NewValidationError("Destination account is required")
+	}
+	if r.ToAccountId == r.FromAccountId {
+		return errs.NewValidationError("Cannot transfer to the same account")
+	}
+	if r.Amount <= 0 {
+		return errs.NewValidationError("Amount should be greater than zero")
+	}
+	return nil
+}
+
+type TransferResponse struct {
+	TransferId    string        `json:"transfer_id"`
+	FromAccountId string        `json:"from_account_id"`
+	ToAccountId   string        `json:"to_account_id"`
+	Amount        money.Amount  `json:"amount"`
+	Currency      string        `json:"currency"`
+	NewBalance    *money.Amount `json:"new_balance,omitempty"`
+	// CreditedAmount : What the destination account received, in its own currency
+	CreditedAmount   money.Amount          `json:"credited_amount"`
+	CreditedCurrency string                `json:"credited_currency"`
+	FxRate           money.Rate            `json:"fx_rate"`
+	TransferDate     string                `json:"transfer_date"`
+	Transactions     []TransactionResponse `json:"transactions"`
+	// set only when fraud screening held the transfer for review, no money has moved yet
+	Status    string `json:"status,omitempty"`
+	PendingId string `json:"pending_id,omitempty"`
+}
+
+// IsPending : The transfer was held for review instead of being posted
+func (r TransferResponse) IsPending() bool {
+	return r.Status == TransactionStatusPending
+}
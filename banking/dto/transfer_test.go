@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"banking/money"
+	"testing"
+)
+
+func Test_should_return_error_when_transferring_to_the_same_account(t *testing.T) {
+	// Arrange
+	request := TransferRequest{FromAccountId: "95470", ToAccountId: "95470", Amount: money.FromUnits(100)}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err == nil || err.Message != "Cannot transfer to the same account" {
+		t.Error("Invalid error message was thrown when validating the transfer accounts.")
+	}
+}
+
+func Test_should_return_error_when_transfer_amount_is_not_positive(t *testing.T) {
+	// Arrange
+	request := TransferRequest{FromAccountId: "95470", ToAccountId: "95471", Amount: 0}
+	// Act
+	err := request.Validate()
+	// Assert
+	if err == nil || err.Message != "Amount should be greater than zero" {
+		t.Error("Invalid error message was thrown when validating the transfer amount.")
+	}
+}
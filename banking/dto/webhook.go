@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"banking/errs"
+	"net/url"
+	"strings"
+)
+
+type WebhookRequest struct {
+	CustomerId string   `json:"-"`
+	Url        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+// Validate : The url must be absolute https and every event type one of the allowed ones
+func (r WebhookRequest) Validate(allowed []string) *errs.AppError {
+	u, err := url.Parse(r.Url)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errs.NewValidationError("Webhook url should be an absolute https url")
+	}
+	if len(r.EventTypes) == 0 {
+		return errs.NewValidationError("At least one event type is required")
+	}
+	for _, t := range r.EventTypes {
+		if !contains(allowed, t) {
+			return errs.NewValidationError("Unknown event type " + t + ", expected one of " + strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookResponse struct {
+	WebhookId  string   `json:"webhook_id"`
+	CustomerId string   `json:"customer_id"`
+	Url        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	CreatedAt  string   `json:"created_at"`
+	// Secret : Key of the X-Webhook-Signature header, only returned when the webhook is created
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookDeliveryResponse struct {
+	DeliveryId     string `json:"delivery_id"`
+	EventId        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+}
@@ -2,15 +2,27 @@ package errs
 
 import "net/http"
 
+// Error codes telling clients apart errors that share an http status
+const (
+	ErrCodeLimitExceeded     = "LIMIT_EXCEEDED"
+	ErrCodeTransactionDenied = "TRANSACTION_DENIED"
+	ErrCodeInsufficientFunds = "INSUFFICIENT_FUNDS"
+	ErrCodeAccountInactive   = "ACCOUNT_INACTIVE"
+	ErrCodeAlreadyPosted     = "ALREADY_POSTED"
+)
+
 type AppError struct {
 	Code    int `json:",omitempty"`
 	Message string
+	// ErrorCode : Stable code for errors clients need to tell apart, empty for most errors
+	ErrorCode string `json:",omitempty"`
 }
 
-// AsMessage : Returns the error message only, Code will be empty
+// AsMessage : Returns the error message and error code only, Code will be empty
 func (e AppError) AsMessage() *AppError {
 	return &AppError{
-		Message: e.Message,
+		Message:   e.Message,
+		ErrorCode: e.ErrorCode,
 	}
 }
 
@@ -38,3 +50,48 @@ func NewValidationError(message string) *AppError {
 		Code:    http.StatusUnprocessableEntity,
 	}
 }
+
+// NewLimitExceededError : Returns a validation error for a withdrawal over one of the account limits
+func NewLimitExceededError(message string) *AppError {
+	return &AppError{
+		Message:   message,
+		Code:      http.StatusUnprocessableEntity,
+		ErrorCode: ErrCodeLimitExceeded,
+	}
+}
+
+// NewTransactionDeniedError : Returns a validation error for a transaction refused by fraud screening
+func NewTransactionDeniedError(message string) *AppError {
+	return &AppError{
+		Message:   message,
+		Code:      http.StatusUnprocessableEntity,
+		ErrorCode: ErrCodeTransactionDenied,
+	}
+}
+
+// NewInsufficientFundsError : Returns a validation error for a withdrawal over the balance and overdraft
+func NewInsufficientFundsError(message string) *AppError {
+	return &AppError{
+		Message:   message,
+		Code:      http.StatusUnprocessableEntity,
+		ErrorCode: ErrCodeInsufficientFunds,
+	}
+}
+
+// NewAccountInactiveError : Returns a validation error for a transaction on a frozen or closed account
+func NewAccountInactiveError(message string) *AppError {
+	return &AppError{
+		Message:   message,
+		Code:      http.StatusUnprocessableEntity,
+		ErrorCode: ErrCodeAccountInactive,
+	}
+}
+
+// NewAlreadyPostedError : Returns a conflict error for a transaction whose reference the account already has
+func NewAlreadyPostedError(message string) *AppError {
+	return &AppError{
+		Message:   message,
+		Code:      http.StatusConflict,
+		ErrorCode: ErrCodeAlreadyPosted,
+	}
+}
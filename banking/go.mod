@@ -1,13 +1,23 @@
 module banking
 
-go 1.15
+go 1.16
 
 require (
+	config v0.0.0-00010101000000-000000000000
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/golang/mock v1.4.4
 	github.com/gorilla/mux v1.8.0
 	github.com/jmoiron/sqlx v1.2.0
-	github.com/joho/godotenv v1.3.0
+	github.com/lib/pq v1.9.0
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/prometheus/client_golang v1.12.2
 	go.uber.org/multierr v1.6.0 // indirect
 	go.uber.org/zap v1.16.0
+	golang.org/x/sys v0.10.0 // indirect
+	google.golang.org/protobuf v1.27.1 // indirect
+	platform v0.0.0-00010101000000-000000000000
 )
+
+replace config => ../config
+
+replace platform => ../platform
@@ -3,9 +3,25 @@ package main
 import (
 	"banking/app"
 	"banking/logger"
+	"os"
 )
 
 func main() {
+	// batch commands run instead of the server: banking interest -as-of 2021-01-31, banking migrate up,
+	// banking seed -customers 100
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "interest":
+			app.RunInterest(os.Args[2:])
+			return
+		case "migrate":
+			app.RunMigrate(os.Args[2:])
+			return
+		case "seed":
+			app.RunSeed(os.Args[2:])
+			return
+		}
+	}
 	logger.Info("Starting server... 🚀")
-	app.Start()
+	app.Start(os.Args[1:])
 }
@@ -0,0 +1,65 @@
+CREATE TABLE customers (
+  customer_id INT NOT NULL AUTO_INCREMENT,
+  name VARCHAR(100) NOT NULL,
+  date_of_birth DATE NOT NULL,
+  city VARCHAR(100) NOT NULL,
+  zipcode VARCHAR(10) NOT NULL,
+  status CHAR(1) NOT NULL DEFAULT '1',
+  PRIMARY KEY (customer_id)
+) ENGINE=InnoDB AUTO_INCREMENT=2000;
+
+CREATE TABLE accounts (
+  account_id INT NOT NULL AUTO_INCREMENT,
+  customer_id INT NOT NULL,
+  opening_date DATETIME NOT NULL,
+  account_type VARCHAR(20) NOT NULL,
+  currency CHAR(3) NOT NULL DEFAULT 'USD',
+  amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+  status CHAR(1) NOT NULL DEFAULT '1',
+  overdraft_limit DECIMAL(15,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (account_id),
+  KEY accounts_customer_id (customer_id),
+  CONSTRAINT accounts_customer_fk FOREIGN KEY (customer_id) REFERENCES customers (customer_id)
+) ENGINE=InnoDB AUTO_INCREMENT=95470;
+
+CREATE TABLE transactions (
+  transaction_id INT NOT NULL AUTO_INCREMENT,
+  account_id INT NOT NULL,
+  amount DECIMAL(15,2) NOT NULL,
+  transaction_type VARCHAR(20) NOT NULL,
+  transaction_date DATETIME NOT NULL,
+  currency CHAR(3) NOT NULL,
+  original_amount DECIMAL(15,2) NOT NULL,
+  original_currency CHAR(3) NOT NULL,
+  fx_rate DECIMAL(18,6) NOT NULL,
+  reference VARCHAR(64) NULL,
+  transfer_id VARCHAR(64) NULL,
+  reversal_of INT NULL,
+  reversed_by INT NULL,
+  PRIMARY KEY (transaction_id),
+  KEY transactions_account_date (account_id, transaction_date),
+  KEY transactions_transfer_id (transfer_id),
+  CONSTRAINT transactions_account_fk FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE account_status_changes (
+  change_id INT NOT NULL AUTO_INCREMENT,
+  account_id INT NOT NULL,
+  from_status CHAR(1) NOT NULL,
+  to_status CHAR(1) NOT NULL,
+  reason VARCHAR(255) NOT NULL,
+  actor VARCHAR(64) NOT NULL,
+  changed_at DATETIME NOT NULL,
+  PRIMARY KEY (change_id),
+  KEY account_status_changes_account_id (account_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE transaction_reversals (
+  transaction_id INT NOT NULL,
+  reversal_id INT NOT NULL,
+  reason VARCHAR(255) NOT NULL,
+  actor VARCHAR(64) NOT NULL,
+  forced BOOLEAN NOT NULL DEFAULT FALSE,
+  reversed_at DATETIME NOT NULL,
+  PRIMARY KEY (transaction_id)
+) ENGINE=InnoDB;
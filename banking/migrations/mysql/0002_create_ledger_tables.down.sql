@@ -0,0 +1,4 @@
+DROP TABLE interest_accruals;
+DROP TABLE fx_rates;
+DROP TABLE journal_lines;
+DROP TABLE journal_entries;
@@ -0,0 +1,39 @@
+CREATE TABLE journal_entries (
+  entry_id INT NOT NULL AUTO_INCREMENT,
+  entry_type VARCHAR(20) NOT NULL,
+  transaction_id INT NULL,
+  transfer_id VARCHAR(64) NULL,
+  posted_at DATETIME NOT NULL,
+  PRIMARY KEY (entry_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE journal_lines (
+  line_id INT NOT NULL AUTO_INCREMENT,
+  entry_id INT NOT NULL,
+  ledger_account VARCHAR(64) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  debit DECIMAL(15,2) NOT NULL DEFAULT 0,
+  credit DECIMAL(15,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (line_id),
+  KEY journal_lines_ledger_account (ledger_account, currency),
+  CONSTRAINT journal_lines_entry_fk FOREIGN KEY (entry_id) REFERENCES journal_entries (entry_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE fx_rates (
+  base_currency CHAR(3) NOT NULL,
+  quote_currency CHAR(3) NOT NULL,
+  rate DECIMAL(18,6) NOT NULL,
+  effective_date DATE NOT NULL,
+  PRIMARY KEY (base_currency, quote_currency, effective_date)
+) ENGINE=InnoDB;
+
+CREATE TABLE interest_accruals (
+  account_id INT NOT NULL,
+  accrual_date DATE NOT NULL,
+  period CHAR(7) NOT NULL,
+  balance DECIMAL(15,2) NOT NULL,
+  rate DECIMAL(18,6) NOT NULL,
+  day_count VARCHAR(10) NOT NULL,
+  accrued_micros BIGINT NOT NULL,
+  PRIMARY KEY (account_id, accrual_date)
+) ENGINE=InnoDB;
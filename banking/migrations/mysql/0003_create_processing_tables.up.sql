@@ -0,0 +1,71 @@
+CREATE TABLE idempotency_keys (
+  idempotency_key VARCHAR(255) NOT NULL,
+  fingerprint VARCHAR(64) NOT NULL,
+  status_code INT NOT NULL DEFAULT 0,
+  response MEDIUMBLOB NULL,
+  created_at DATETIME NOT NULL,
+  expires_at DATETIME NOT NULL,
+  PRIMARY KEY (idempotency_key),
+  KEY idempotency_keys_expires_at (expires_at)
+) ENGINE=InnoDB;
+
+CREATE TABLE pending_transactions (
+  pending_id INT NOT NULL AUTO_INCREMENT,
+  account_id INT NOT NULL,
+  amount DECIMAL(15,2) NOT NULL,
+  transaction_type VARCHAR(20) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  original_amount DECIMAL(15,2) NOT NULL,
+  original_currency CHAR(3) NOT NULL,
+  fx_rate DECIMAL(18,6) NOT NULL,
+  requested_at DATETIME NOT NULL,
+  reasons TEXT NOT NULL,
+  status VARCHAR(10) NOT NULL,
+  decided_by VARCHAR(64) NULL,
+  decided_at DATETIME NULL,
+  transaction_id INT NULL,
+  PRIMARY KEY (pending_id),
+  KEY pending_transactions_status (status)
+) ENGINE=InnoDB;
+
+CREATE TABLE outbox_events (
+  event_id BIGINT NOT NULL AUTO_INCREMENT,
+  event_type VARCHAR(64) NOT NULL,
+  aggregate_id VARCHAR(64) NOT NULL,
+  payload TEXT NOT NULL,
+  occurred_at DATETIME NOT NULL,
+  published_at DATETIME NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT NULL,
+  PRIMARY KEY (event_id),
+  KEY outbox_events_published_at (published_at, event_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE webhook_subscriptions (
+  subscription_id INT NOT NULL AUTO_INCREMENT,
+  customer_id INT NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  event_types VARCHAR(255) NOT NULL,
+  secret VARCHAR(128) NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (subscription_id),
+  KEY webhook_subscriptions_customer_id (customer_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE webhook_deliveries (
+  delivery_id BIGINT NOT NULL AUTO_INCREMENT,
+  subscription_id INT NOT NULL,
+  event_id BIGINT NOT NULL,
+  event_type VARCHAR(64) NOT NULL,
+  payload TEXT NOT NULL,
+  status VARCHAR(10) NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at DATETIME NOT NULL,
+  last_status_code INT NULL,
+  last_error TEXT NULL,
+  created_at DATETIME NOT NULL,
+  delivered_at DATETIME NULL,
+  PRIMARY KEY (delivery_id),
+  KEY webhook_deliveries_due (status, next_attempt_at),
+  CONSTRAINT webhook_deliveries_subscription_fk FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (subscription_id) ON DELETE CASCADE
+) ENGINE=InnoDB;
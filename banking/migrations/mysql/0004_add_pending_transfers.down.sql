@@ -0,0 +1 @@
+ALTER TABLE pending_transactions DROP COLUMN to_account_id;
@@ -0,0 +1 @@
+DROP INDEX transactions_account_reference ON transactions;
@@ -0,0 +1 @@
+ALTER TABLE pending_transactions ADD COLUMN requested_role VARCHAR(20) NOT NULL DEFAULT '';
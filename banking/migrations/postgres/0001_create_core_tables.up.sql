@@ -0,0 +1,65 @@
+CREATE TABLE customers (
+  customer_id INTEGER GENERATED BY DEFAULT AS IDENTITY (START WITH 2000),
+  name VARCHAR(100) NOT NULL,
+  date_of_birth VARCHAR(10) NOT NULL,
+  city VARCHAR(100) NOT NULL,
+  zipcode VARCHAR(10) NOT NULL,
+  status CHAR(1) NOT NULL DEFAULT '1',
+  PRIMARY KEY (customer_id)
+);
+
+CREATE TABLE accounts (
+  account_id INTEGER GENERATED BY DEFAULT AS IDENTITY (START WITH 95470),
+  customer_id INTEGER NOT NULL,
+  opening_date VARCHAR(19) NOT NULL,
+  account_type VARCHAR(20) NOT NULL,
+  currency CHAR(3) NOT NULL DEFAULT 'USD',
+  amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+  status CHAR(1) NOT NULL DEFAULT '1',
+  overdraft_limit NUMERIC(15,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (account_id),
+  CONSTRAINT accounts_customer_fk FOREIGN KEY (customer_id) REFERENCES customers (customer_id)
+);
+CREATE INDEX accounts_customer_id ON accounts (customer_id);
+
+CREATE TABLE transactions (
+  transaction_id INTEGER GENERATED BY DEFAULT AS IDENTITY,
+  account_id INTEGER NOT NULL,
+  amount NUMERIC(15,2) NOT NULL,
+  transaction_type VARCHAR(20) NOT NULL,
+  transaction_date VARCHAR(19) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  original_amount NUMERIC(15,2) NOT NULL,
+  original_currency CHAR(3) NOT NULL,
+  fx_rate NUMERIC(18,6) NOT NULL,
+  reference VARCHAR(64) NULL,
+  transfer_id VARCHAR(64) NULL,
+  reversal_of INTEGER NULL,
+  reversed_by INTEGER NULL,
+  PRIMARY KEY (transaction_id),
+  CONSTRAINT transactions_account_fk FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+CREATE INDEX transactions_account_date ON transactions (account_id, transaction_date);
+CREATE INDEX transactions_transfer_id ON transactions (transfer_id);
+
+CREATE TABLE account_status_changes (
+  change_id INTEGER GENERATED BY DEFAULT AS IDENTITY,
+  account_id INTEGER NOT NULL,
+  from_status CHAR(1) NOT NULL,
+  to_status CHAR(1) NOT NULL,
+  reason VARCHAR(255) NOT NULL,
+  actor VARCHAR(64) NOT NULL,
+  changed_at VARCHAR(19) NOT NULL,
+  PRIMARY KEY (change_id)
+);
+CREATE INDEX account_status_changes_account_id ON account_status_changes (account_id);
+
+CREATE TABLE transaction_reversals (
+  transaction_id INTEGER NOT NULL,
+  reversal_id INTEGER NOT NULL,
+  reason VARCHAR(255) NOT NULL,
+  actor VARCHAR(64) NOT NULL,
+  forced BOOLEAN NOT NULL DEFAULT FALSE,
+  reversed_at VARCHAR(19) NOT NULL,
+  PRIMARY KEY (transaction_id)
+);
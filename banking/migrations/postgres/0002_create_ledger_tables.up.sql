@@ -0,0 +1,39 @@
+CREATE TABLE journal_entries (
+  entry_id INTEGER GENERATED BY DEFAULT AS IDENTITY,
+  entry_type VARCHAR(20) NOT NULL,
+  transaction_id INTEGER NULL,
+  transfer_id VARCHAR(64) NULL,
+  posted_at VARCHAR(19) NOT NULL,
+  PRIMARY KEY (entry_id)
+);
+
+CREATE TABLE journal_lines (
+  line_id INTEGER GENERATED BY DEFAULT AS IDENTITY,
+  entry_id INTEGER NOT NULL,
+  ledger_account VARCHAR(64) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  debit NUMERIC(15,2) NOT NULL DEFAULT 0,
+  credit NUMERIC(15,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (line_id),
+  CONSTRAINT journal_lines_entry_fk FOREIGN KEY (entry_id) REFERENCES journal_entries (entry_id)
+);
+CREATE INDEX journal_lines_ledger_account ON journal_lines (ledger_account, currency);
+
+CREATE TABLE fx_rates (
+  base_currency CHAR(3) NOT NULL,
+  quote_currency CHAR(3) NOT NULL,
+  rate NUMERIC(18,6) NOT NULL,
+  effective_date VARCHAR(10) NOT NULL,
+  PRIMARY KEY (base_currency, quote_currency, effective_date)
+);
+
+CREATE TABLE interest_accruals (
+  account_id INTEGER NOT NULL,
+  accrual_date VARCHAR(10) NOT NULL,
+  period CHAR(7) NOT NULL,
+  balance NUMERIC(15,2) NOT NULL,
+  rate NUMERIC(18,6) NOT NULL,
+  day_count VARCHAR(10) NOT NULL,
+  accrued_micros BIGINT NOT NULL,
+  PRIMARY KEY (account_id, accrual_date)
+);
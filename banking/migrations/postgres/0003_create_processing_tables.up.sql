@@ -0,0 +1,71 @@
+CREATE TABLE idempotency_keys (
+  idempotency_key VARCHAR(255) NOT NULL,
+  fingerprint VARCHAR(64) NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  response BYTEA NULL,
+  created_at VARCHAR(19) NOT NULL,
+  expires_at VARCHAR(19) NOT NULL,
+  PRIMARY KEY (idempotency_key)
+);
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE pending_transactions (
+  pending_id INTEGER GENERATED BY DEFAULT AS IDENTITY,
+  account_id INTEGER NOT NULL,
+  amount NUMERIC(15,2) NOT NULL,
+  transaction_type VARCHAR(20) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  original_amount NUMERIC(15,2) NOT NULL,
+  original_currency CHAR(3) NOT NULL,
+  fx_rate NUMERIC(18,6) NOT NULL,
+  requested_at VARCHAR(19) NOT NULL,
+  reasons TEXT NOT NULL,
+  status VARCHAR(10) NOT NULL,
+  decided_by VARCHAR(64) NULL,
+  decided_at VARCHAR(19) NULL,
+  transaction_id INTEGER NULL,
+  PRIMARY KEY (pending_id)
+);
+CREATE INDEX pending_transactions_status ON pending_transactions (status);
+
+CREATE TABLE outbox_events (
+  event_id BIGINT GENERATED BY DEFAULT AS IDENTITY,
+  event_type VARCHAR(64) NOT NULL,
+  aggregate_id VARCHAR(64) NOT NULL,
+  payload TEXT NOT NULL,
+  occurred_at VARCHAR(19) NOT NULL,
+  published_at VARCHAR(19) NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NULL,
+  PRIMARY KEY (event_id)
+);
+CREATE INDEX outbox_events_published_at ON outbox_events (published_at, event_id);
+
+CREATE TABLE webhook_subscriptions (
+  subscription_id INTEGER GENERATED BY DEFAULT AS IDENTITY,
+  customer_id INTEGER NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  event_types VARCHAR(255) NOT NULL,
+  secret VARCHAR(128) NOT NULL,
+  created_at VARCHAR(19) NOT NULL,
+  PRIMARY KEY (subscription_id)
+);
+CREATE INDEX webhook_subscriptions_customer_id ON webhook_subscriptions (customer_id);
+
+CREATE TABLE webhook_deliveries (
+  delivery_id BIGINT GENERATED BY DEFAULT AS IDENTITY,
+  subscription_id INTEGER NOT NULL,
+  event_id BIGINT NOT NULL,
+  event_type VARCHAR(64) NOT NULL,
+  payload TEXT NOT NULL,
+  status VARCHAR(10) NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at VARCHAR(19) NOT NULL,
+  last_status_code INTEGER NULL,
+  last_error TEXT NULL,
+  created_at VARCHAR(19) NOT NULL,
+  delivered_at VARCHAR(19) NULL,
+  PRIMARY KEY (delivery_id),
+  CONSTRAINT webhook_deliveries_subscription_fk FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (subscription_id) ON DELETE CASCADE
+);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
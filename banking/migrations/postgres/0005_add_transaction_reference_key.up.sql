@@ -0,0 +1 @@
+CREATE UNIQUE INDEX transactions_account_reference ON transactions (account_id, reference);
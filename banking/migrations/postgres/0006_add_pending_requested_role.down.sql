@@ -0,0 +1 @@
+ALTER TABLE pending_transactions DROP COLUMN requested_role;
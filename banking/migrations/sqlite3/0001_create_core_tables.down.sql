@@ -0,0 +1,5 @@
+DROP TABLE transaction_reversals;
+DROP TABLE account_status_changes;
+DROP TABLE transactions;
+DROP TABLE accounts;
+DROP TABLE customers;
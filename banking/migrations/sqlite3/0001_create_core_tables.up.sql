@@ -0,0 +1,64 @@
+CREATE TABLE customers (
+  customer_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL,
+  date_of_birth TEXT NOT NULL,
+  city TEXT NOT NULL,
+  zipcode TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT '1'
+);
+
+CREATE TABLE accounts (
+  account_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  customer_id INTEGER NOT NULL,
+  opening_date TEXT NOT NULL,
+  account_type TEXT NOT NULL,
+  currency TEXT NOT NULL DEFAULT 'USD',
+  amount NUMERIC NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT '1',
+  overdraft_limit NUMERIC NOT NULL DEFAULT 0,
+  CONSTRAINT accounts_customer_fk FOREIGN KEY (customer_id) REFERENCES customers (customer_id)
+);
+CREATE INDEX accounts_customer_id ON accounts (customer_id);
+
+CREATE TABLE transactions (
+  transaction_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_id INTEGER NOT NULL,
+  amount NUMERIC NOT NULL,
+  transaction_type TEXT NOT NULL,
+  transaction_date TEXT NOT NULL,
+  currency TEXT NOT NULL,
+  original_amount NUMERIC NOT NULL,
+  original_currency TEXT NOT NULL,
+  fx_rate NUMERIC NOT NULL,
+  reference TEXT NULL,
+  transfer_id TEXT NULL,
+  reversal_of INTEGER NULL,
+  reversed_by INTEGER NULL,
+  CONSTRAINT transactions_account_fk FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+CREATE INDEX transactions_account_date ON transactions (account_id, transaction_date);
+CREATE INDEX transactions_transfer_id ON transactions (transfer_id);
+
+CREATE TABLE account_status_changes (
+  change_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_id INTEGER NOT NULL,
+  from_status TEXT NOT NULL,
+  to_status TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  actor TEXT NOT NULL,
+  changed_at TEXT NOT NULL
+);
+CREATE INDEX account_status_changes_account_id ON account_status_changes (account_id);
+
+CREATE TABLE transaction_reversals (
+  transaction_id INTEGER NOT NULL,
+  reversal_id INTEGER NOT NULL,
+  reason TEXT NOT NULL,
+  actor TEXT NOT NULL,
+  forced INTEGER NOT NULL DEFAULT 0,
+  reversed_at TEXT NOT NULL,
+  PRIMARY KEY (transaction_id)
+);
+
+-- the ids start where the MySQL and PostgreSQL schemas start them
+INSERT INTO sqlite_sequence (name, seq) VALUES ('customers', 1999), ('accounts', 95469);
@@ -0,0 +1,37 @@
+CREATE TABLE journal_entries (
+  entry_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  entry_type TEXT NOT NULL,
+  transaction_id INTEGER NULL,
+  transfer_id TEXT NULL,
+  posted_at TEXT NOT NULL
+);
+
+CREATE TABLE journal_lines (
+  line_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  entry_id INTEGER NOT NULL,
+  ledger_account TEXT NOT NULL,
+  currency TEXT NOT NULL,
+  debit NUMERIC NOT NULL DEFAULT 0,
+  credit NUMERIC NOT NULL DEFAULT 0,
+  CONSTRAINT journal_lines_entry_fk FOREIGN KEY (entry_id) REFERENCES journal_entries (entry_id)
+);
+CREATE INDEX journal_lines_ledger_account ON journal_lines (ledger_account, currency);
+
+CREATE TABLE fx_rates (
+  base_currency TEXT NOT NULL,
+  quote_currency TEXT NOT NULL,
+  rate NUMERIC NOT NULL,
+  effective_date TEXT NOT NULL,
+  PRIMARY KEY (base_currency, quote_currency, effective_date)
+);
+
+CREATE TABLE interest_accruals (
+  account_id INTEGER NOT NULL,
+  accrual_date TEXT NOT NULL,
+  period TEXT NOT NULL,
+  balance NUMERIC NOT NULL,
+  rate NUMERIC NOT NULL,
+  day_count TEXT NOT NULL,
+  accrued_micros BIGINT NOT NULL,
+  PRIMARY KEY (account_id, accrual_date)
+);
@@ -0,0 +1,67 @@
+CREATE TABLE idempotency_keys (
+  idempotency_key TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  response BLOB NULL,
+  created_at TEXT NOT NULL,
+  expires_at TEXT NOT NULL,
+  PRIMARY KEY (idempotency_key)
+);
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE pending_transactions (
+  pending_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_id INTEGER NOT NULL,
+  amount NUMERIC NOT NULL,
+  transaction_type TEXT NOT NULL,
+  currency TEXT NOT NULL,
+  original_amount NUMERIC NOT NULL,
+  original_currency TEXT NOT NULL,
+  fx_rate NUMERIC NOT NULL,
+  requested_at TEXT NOT NULL,
+  reasons TEXT NOT NULL,
+  status TEXT NOT NULL,
+  decided_by TEXT NULL,
+  decided_at TEXT NULL,
+  transaction_id INTEGER NULL
+);
+CREATE INDEX pending_transactions_status ON pending_transactions (status);
+
+CREATE TABLE outbox_events (
+  event_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  event_type TEXT NOT NULL,
+  aggregate_id TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  occurred_at TEXT NOT NULL,
+  published_at TEXT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NULL
+);
+CREATE INDEX outbox_events_published_at ON outbox_events (published_at, event_id);
+
+CREATE TABLE webhook_subscriptions (
+  subscription_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  customer_id INTEGER NOT NULL,
+  url TEXT NOT NULL,
+  event_types TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  created_at TEXT NOT NULL
+);
+CREATE INDEX webhook_subscriptions_customer_id ON webhook_subscriptions (customer_id);
+
+CREATE TABLE webhook_deliveries (
+  delivery_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  subscription_id INTEGER NOT NULL,
+  event_id BIGINT NOT NULL,
+  event_type TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  status TEXT NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TEXT NOT NULL,
+  last_status_code INTEGER NULL,
+  last_error TEXT NULL,
+  created_at TEXT NOT NULL,
+  delivered_at TEXT NULL,
+  CONSTRAINT webhook_deliveries_subscription_fk FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (subscription_id) ON DELETE CASCADE
+);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
@@ -0,0 +1 @@
+ALTER TABLE pending_transactions ADD COLUMN to_account_id INTEGER NULL;
@@ -0,0 +1,25 @@
+-- the bundled SQLite has no DROP COLUMN, the table is copied without it
+CREATE TABLE pending_transactions_old (
+  pending_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_id INTEGER NOT NULL,
+  amount NUMERIC NOT NULL,
+  transaction_type TEXT NOT NULL,
+  currency TEXT NOT NULL,
+  original_amount NUMERIC NOT NULL,
+  original_currency TEXT NOT NULL,
+  fx_rate NUMERIC NOT NULL,
+  requested_at TEXT NOT NULL,
+  reasons TEXT NOT NULL,
+  status TEXT NOT NULL,
+  decided_by TEXT NULL,
+  decided_at TEXT NULL,
+  transaction_id INTEGER NULL,
+  to_account_id INTEGER NULL
+);
+INSERT INTO pending_transactions_old
+  SELECT pending_id, account_id, amount, transaction_type, currency, original_amount, original_currency, fx_rate,
+         requested_at, reasons, status, decided_by, decided_at, transaction_id, to_account_id
+  FROM pending_transactions;
+DROP TABLE pending_transactions;
+ALTER TABLE pending_transactions_old RENAME TO pending_transactions;
+CREATE INDEX pending_transactions_status ON pending_transactions (status);
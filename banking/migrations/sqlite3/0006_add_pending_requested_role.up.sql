@@ -0,0 +1 @@
+ALTER TABLE pending_transactions ADD COLUMN requested_role TEXT NOT NULL DEFAULT '';
@@ -7,6 +7,7 @@ package domain
 import (
 	domain "banking/domain"
 	errs "banking/errs"
+	money "banking/money"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -49,6 +50,51 @@ func (mr *MockAccountRepositoryMockRecorder) FindBy(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBy", reflect.TypeOf((*MockAccountRepository)(nil).FindBy), arg0)
 }
 
+// FindTransaction mocks base method
+func (m *MockAccountRepository) FindTransaction(arg0 string) (*domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransaction", arg0)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransaction indicates an expected call of FindTransaction
+func (mr *MockAccountRepositoryMockRecorder) FindTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransaction", reflect.TypeOf((*MockAccountRepository)(nil).FindTransaction), arg0)
+}
+
+// FindTransactions mocks base method
+func (m *MockAccountRepository) FindTransactions(arg0 domain.TransactionFilter) ([]domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactions", arg0)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactions indicates an expected call of FindTransactions
+func (mr *MockAccountRepositoryMockRecorder) FindTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactions", reflect.TypeOf((*MockAccountRepository)(nil).FindTransactions), arg0)
+}
+
+// FindTransactionsBetween mocks base method
+func (m *MockAccountRepository) FindTransactionsBetween(arg0, arg1, arg2 string) ([]domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionsBetween", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactionsBetween indicates an expected call of FindTransactionsBetween
+func (mr *MockAccountRepositoryMockRecorder) FindTransactionsBetween(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionsBetween", reflect.TypeOf((*MockAccountRepository)(nil).FindTransactionsBetween), arg0, arg1, arg2)
+}
+
 // Save mocks base method
 func (m *MockAccountRepository) Save(arg0 domain.Account) (*domain.Account, *errs.AppError) {
 	m.ctrl.T.Helper()
@@ -64,6 +110,49 @@ func (mr *MockAccountRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAccountRepository)(nil).Save), arg0)
 }
 
+// SaveOverdraftLimit mocks base method
+func (m *MockAccountRepository) SaveOverdraftLimit(arg0 string, arg1 money.Amount) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOverdraftLimit", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// SaveOverdraftLimit indicates an expected call of SaveOverdraftLimit
+func (mr *MockAccountRepositoryMockRecorder) SaveOverdraftLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOverdraftLimit", reflect.TypeOf((*MockAccountRepository)(nil).SaveOverdraftLimit), arg0, arg1)
+}
+
+// SaveReversal mocks base method
+func (m *MockAccountRepository) SaveReversal(arg0 domain.TransactionReversal) (*domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReversal", arg0)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SaveReversal indicates an expected call of SaveReversal
+func (mr *MockAccountRepositoryMockRecorder) SaveReversal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReversal", reflect.TypeOf((*MockAccountRepository)(nil).SaveReversal), arg0)
+}
+
+// SaveStatusChange mocks base method
+func (m *MockAccountRepository) SaveStatusChange(arg0 domain.AccountStatusChange, arg1 *domain.Transfer) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveStatusChange", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// SaveStatusChange indicates an expected call of SaveStatusChange
+func (mr *MockAccountRepositoryMockRecorder) SaveStatusChange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStatusChange", reflect.TypeOf((*MockAccountRepository)(nil).SaveStatusChange), arg0, arg1)
+}
+
 // SaveTransaction mocks base method
 func (m *MockAccountRepository) SaveTransaction(arg0 domain.Transaction) (*domain.Transaction, *errs.AppError) {
 	m.ctrl.T.Helper()
@@ -78,3 +167,18 @@ func (mr *MockAccountRepositoryMockRecorder) SaveTransaction(arg0 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransaction", reflect.TypeOf((*MockAccountRepository)(nil).SaveTransaction), arg0)
 }
+
+// SaveTransfer mocks base method
+func (m *MockAccountRepository) SaveTransfer(arg0 domain.Transfer) (*domain.Transfer, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTransfer", arg0)
+	ret0, _ := ret[0].(*domain.Transfer)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SaveTransfer indicates an expected call of SaveTransfer
+func (mr *MockAccountRepositoryMockRecorder) SaveTransfer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransfer", reflect.TypeOf((*MockAccountRepository)(nil).SaveTransfer), arg0)
+}
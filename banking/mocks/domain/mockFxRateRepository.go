@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: banking/domain (interfaces: FxRateRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	domain "banking/domain"
+	errs "banking/errs"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockFxRateRepository is a mock of FxRateRepository interface
+type MockFxRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRateRepositoryMockRecorder
+}
+
+// MockFxRateRepositoryMockRecorder is the mock recorder for MockFxRateRepository
+type MockFxRateRepositoryMockRecorder struct {
+	mock *MockFxRateRepository
+}
+
+// NewMockFxRateRepository creates a new mock instance
+func NewMockFxRateRepository(ctrl *gomock.Controller) *MockFxRateRepository {
+	mock := &MockFxRateRepository{ctrl: ctrl}
+	mock.recorder = &MockFxRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockFxRateRepository) EXPECT() *MockFxRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method
+func (m *MockFxRateRepository) FindAll(arg0, arg1 string) ([]domain.FxRate, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]domain.FxRate)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll
+func (mr *MockFxRateRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFxRateRepository)(nil).FindAll), arg0, arg1)
+}
+
+// FindEffective mocks base method
+func (m *MockFxRateRepository) FindEffective(arg0, arg1, arg2 string) (*domain.FxRate, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEffective", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.FxRate)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindEffective indicates an expected call of FindEffective
+func (mr *MockFxRateRepositoryMockRecorder) FindEffective(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEffective", reflect.TypeOf((*MockFxRateRepository)(nil).FindEffective), arg0, arg1, arg2)
+}
+
+// Save mocks base method
+func (m *MockFxRateRepository) Save(arg0 []domain.FxRate) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockFxRateRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFxRateRepository)(nil).Save), arg0)
+}
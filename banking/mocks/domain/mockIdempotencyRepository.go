@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: banking/domain (interfaces: IdempotencyRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	domain "banking/domain"
+	errs "banking/errs"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockIdempotencyRepository is a mock of IdempotencyRepository interface
+type MockIdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryMockRecorder
+}
+
+// MockIdempotencyRepositoryMockRecorder is the mock recorder for MockIdempotencyRepository
+type MockIdempotencyRepositoryMockRecorder struct {
+	mock *MockIdempotencyRepository
+}
+
+// NewMockIdempotencyRepository creates a new mock instance
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method
+func (m *MockIdempotencyRepository) Complete(arg0 string, arg1 int, arg2 []byte) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete
+func (mr *MockIdempotencyRepositoryMockRecorder) Complete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyRepository)(nil).Complete), arg0, arg1, arg2)
+}
+
+// DeleteExpired mocks base method
+func (m *MockIdempotencyRepository) DeleteExpired(arg0 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", arg0)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteExpired(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteExpired), arg0)
+}
+
+// Release mocks base method
+func (m *MockIdempotencyRepository) Release(arg0 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// Release indicates an expected call of Release
+func (mr *MockIdempotencyRepositoryMockRecorder) Release(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotencyRepository)(nil).Release), arg0)
+}
+
+// Reserve mocks base method
+func (m *MockIdempotencyRepository) Reserve(arg0 domain.IdempotencyRecord, arg1 string) (*domain.IdempotencyRecord, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", arg0, arg1)
+	ret0, _ := ret[0].(*domain.IdempotencyRecord)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve
+func (mr *MockIdempotencyRepositoryMockRecorder) Reserve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIdempotencyRepository)(nil).Reserve), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: banking/domain (interfaces: LedgerRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	domain "banking/domain"
+	errs "banking/errs"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockLedgerRepository is a mock of LedgerRepository interface
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindMismatches mocks base method
+func (m *MockLedgerRepository) FindMismatches() ([]domain.BalanceMismatch, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMismatches")
+	ret0, _ := ret[0].([]domain.BalanceMismatch)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindMismatches indicates an expected call of FindMismatches
+func (mr *MockLedgerRepositoryMockRecorder) FindMismatches() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMismatches", reflect.TypeOf((*MockLedgerRepository)(nil).FindMismatches))
+}
+
+// TrialBalance mocks base method
+func (m *MockLedgerRepository) TrialBalance() ([]domain.LedgerBalance, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrialBalance")
+	ret0, _ := ret[0].([]domain.LedgerBalance)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// TrialBalance indicates an expected call of TrialBalance
+func (mr *MockLedgerRepositoryMockRecorder) TrialBalance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrialBalance", reflect.TypeOf((*MockLedgerRepository)(nil).TrialBalance))
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: banking/domain (interfaces: OutboxRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	domain "banking/domain"
+	errs "banking/errs"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindUnpublished mocks base method
+func (m *MockOutboxRepository) FindUnpublished(arg0, arg1 int) ([]domain.Event, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnpublished", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindUnpublished indicates an expected call of FindUnpublished
+func (mr *MockOutboxRepositoryMockRecorder) FindUnpublished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnpublished", reflect.TypeOf((*MockOutboxRepository)(nil).FindUnpublished), arg0, arg1)
+}
+
+// MarkPublished mocks base method
+func (m *MockOutboxRepository) MarkPublished(arg0, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished
+func (mr *MockOutboxRepositoryMockRecorder) MarkPublished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxRepository)(nil).MarkPublished), arg0, arg1)
+}
+
+// RecordFailure mocks base method
+func (m *MockOutboxRepository) RecordFailure(arg0, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure
+func (mr *MockOutboxRepositoryMockRecorder) RecordFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockOutboxRepository)(nil).RecordFailure), arg0, arg1)
+}
@@ -34,11 +34,26 @@ func (m *MockCustomerService) EXPECT() *MockCustomerServiceMockRecorder {
 	return m.recorder
 }
 
+// DeactivateCustomer mocks base method
+func (m *MockCustomerService) DeactivateCustomer(arg0 string) (*dto.CustomerResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateCustomer", arg0)
+	ret0, _ := ret[0].(*dto.CustomerResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// DeactivateCustomer indicates an expected call of DeactivateCustomer
+func (mr *MockCustomerServiceMockRecorder) DeactivateCustomer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateCustomer", reflect.TypeOf((*MockCustomerService)(nil).DeactivateCustomer), arg0)
+}
+
 // GetAllCustomer mocks base method
-func (m *MockCustomerService) GetAllCustomer(arg0 string) ([]dto.CustomerResponse, *errs.AppError) {
+func (m *MockCustomerService) GetAllCustomer(arg0 dto.CustomerSearchRequest) (*dto.CustomerSearchResponse, *errs.AppError) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllCustomer", arg0)
-	ret0, _ := ret[0].([]dto.CustomerResponse)
+	ret0, _ := ret[0].(*dto.CustomerSearchResponse)
 	ret1, _ := ret[1].(*errs.AppError)
 	return ret0, ret1
 }
@@ -63,3 +78,48 @@ func (mr *MockCustomerServiceMockRecorder) GetCustomer(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomer", reflect.TypeOf((*MockCustomerService)(nil).GetCustomer), arg0)
 }
+
+// NewCustomer mocks base method
+func (m *MockCustomerService) NewCustomer(arg0 dto.CustomerRequest) (*dto.CustomerResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewCustomer", arg0)
+	ret0, _ := ret[0].(*dto.CustomerResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// NewCustomer indicates an expected call of NewCustomer
+func (mr *MockCustomerServiceMockRecorder) NewCustomer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCustomer", reflect.TypeOf((*MockCustomerService)(nil).NewCustomer), arg0)
+}
+
+// PatchCustomer mocks base method
+func (m *MockCustomerService) PatchCustomer(arg0 string, arg1 dto.CustomerPatchRequest) (*dto.CustomerResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchCustomer", arg0, arg1)
+	ret0, _ := ret[0].(*dto.CustomerResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// PatchCustomer indicates an expected call of PatchCustomer
+func (mr *MockCustomerServiceMockRecorder) PatchCustomer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchCustomer", reflect.TypeOf((*MockCustomerService)(nil).PatchCustomer), arg0, arg1)
+}
+
+// UpdateCustomer mocks base method
+func (m *MockCustomerService) UpdateCustomer(arg0 string, arg1 dto.CustomerRequest) (*dto.CustomerResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomer", arg0, arg1)
+	ret0, _ := ret[0].(*dto.CustomerResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// UpdateCustomer indicates an expected call of UpdateCustomer
+func (mr *MockCustomerServiceMockRecorder) UpdateCustomer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockCustomerService)(nil).UpdateCustomer), arg0, arg1)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: banking/service (interfaces: WebhookService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	dto "banking/dto"
+	errs "banking/errs"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockWebhookService is a mock of WebhookService interface
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteWebhook mocks base method
+func (m *MockWebhookService) DeleteWebhook(arg0, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook
+func (mr *MockWebhookServiceMockRecorder) DeleteWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookService)(nil).DeleteWebhook), arg0, arg1)
+}
+
+// GetDeliveries mocks base method
+func (m *MockWebhookService) GetDeliveries(arg0, arg1 string) ([]dto.WebhookDeliveryResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]dto.WebhookDeliveryResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries
+func (mr *MockWebhookServiceMockRecorder) GetDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookService)(nil).GetDeliveries), arg0, arg1)
+}
+
+// GetWebhooks mocks base method
+func (m *MockWebhookService) GetWebhooks(arg0 string) ([]dto.WebhookResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", arg0)
+	ret0, _ := ret[0].([]dto.WebhookResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks
+func (mr *MockWebhookServiceMockRecorder) GetWebhooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookService)(nil).GetWebhooks), arg0)
+}
+
+// NewWebhook mocks base method
+func (m *MockWebhookService) NewWebhook(arg0 dto.WebhookRequest) (*dto.WebhookResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWebhook", arg0)
+	ret0, _ := ret[0].(*dto.WebhookResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// NewWebhook indicates an expected call of NewWebhook
+func (mr *MockWebhookServiceMockRecorder) NewWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWebhook", reflect.TypeOf((*MockWebhookService)(nil).NewWebhook), arg0)
+}
@@ -0,0 +1,17 @@
+package money
+
+// DefaultCurrency : Currency used when an account is opened without one
+const DefaultCurrency = "USD"
+
+// Currencies : Currency codes accounts can be opened in, all of them have two decimal places
+var Currencies = []string{"USD", "EUR", "GBP", "CRC"}
+
+// IsSupportedCurrency : Returns true when accounts can hold the currency code
+func IsSupportedCurrency(code string) bool {
+	for _, c := range Currencies {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Amount : An exact amount of money, stored as minor units (cents) so balances never drift
+type Amount int64
+
+// minorUnits : How many minor units make one unit, amounts have at most two fractional digits
+const minorUnits = 100
+
+var ErrInvalidAmount = errors.New("amount should be a number with at most two decimal places")
+
+// FromUnits : Returns the amount for a whole number of units, FromUnits(5000) == 5000.00
+func FromUnits(units int64) Amount {
+	return Amount(units * minorUnits)
+}
+
+// FromMinorUnits : Returns the amount for a number of minor units, FromMinorUnits(10) == 0.10
+func FromMinorUnits(minor int64) Amount {
+	return Amount(minor)
+}
+
+// Parse : Parses a decimal string like "12", "-0.5" or "1500.25", more than two fractional digits are rejected
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, ErrInvalidAmount
+	}
+	// trailing zeros do not add precision, databases may return "10.5000"
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > 2 {
+		return 0, ErrInvalidAmount
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/minorUnits {
+		return 0, ErrInvalidAmount
+	}
+	cents, _ := strconv.ParseInt((fraction + "00")[:2], 10, 64)
+	a := Amount(units*minorUnits + cents)
+	if negative {
+		a = -a
+	}
+	return a, nil
+}
+
+// MustParse : Like Parse but panics on invalid input, meant for constants and tests
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MinorUnits : Returns the amount as a number of minor units
+func (a Amount) MinorUnits() int64 {
+	return int64(a)
+}
+
+// Float64 : Returns the amount in units for reporting, like metrics, never use it to compute balances
+func (a Amount) Float64() float64 {
+	return float64(a) / minorUnits
+}
+
+// String : Returns the amount with exactly two decimal places, "1500.25"
+func (a Amount) String() string {
+	sign := ""
+	v := int64(a)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/minorUnits, v%minorUnits)
+}
+
+// MarshalJSON : Encodes the amount as a JSON number with two decimal places
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON : Accepts a JSON number or a string, the literal is parsed as written so no float rounding happens
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Scan : Reads a DECIMAL column, drivers hand it over as text, integers or floats
+func (a *Amount) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+	case []byte:
+		*a, err = Parse(string(v))
+	case string:
+		*a, err = Parse(v)
+	case int64:
+		*a = FromUnits(v)
+	case float64:
+		// drivers without a decimal type return floats, they are rounded back to the closest cent
+		*a = Amount(math.Round(v * minorUnits))
+	default:
+		err = fmt.Errorf("cannot scan %T into money.Amount", src)
+	}
+	return err
+}
+
+// Value : Writes the amount as a decimal string so the database keeps it exact
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
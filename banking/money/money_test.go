@@ -0,0 +1,110 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_should_parse_amounts_with_up_to_two_decimal_places(t *testing.T) {
+	// Arrange
+	cases := map[string]Amount{"12": 1200, "0.1": 10, "1500.25": 150025, "-0.50": -50, "10.5000": 1050}
+	for input, expected := range cases {
+		// Act
+		a, err := Parse(input)
+		// Assert
+		if err != nil || a != expected {
+			t.Errorf("Failed while parsing %q, got %v", input, a)
+		}
+	}
+}
+
+func Test_should_reject_amounts_with_more_than_two_decimal_places(t *testing.T) {
+	// Arrange
+	inputs := []string{"0.001", "1.234", "", "abc", "1e3", "1.2.3", "."}
+	for _, input := range inputs {
+		// Act
+		_, err := Parse(input)
+		// Assert
+		if err == nil {
+			t.Errorf("Expected an error while parsing %q", input)
+		}
+	}
+}
+
+func Test_should_not_drift_when_adding_ten_cents_repeatedly(t *testing.T) {
+	// Arrange
+	var balance Amount
+	// Act
+	for i := 0; i < 1000; i++ {
+		balance += MustParse("0.10")
+	}
+	// Assert
+	if balance != FromUnits(100) {
+		t.Errorf("Balance drifted to %v", balance)
+	}
+}
+
+func Test_should_encode_and_decode_json_amounts(t *testing.T) {
+	// Arrange
+	var request struct {
+		Amount Amount `json:"amount"`
+	}
+	// Act
+	err := json.Unmarshal([]byte(`{"amount": 19.99}`), &request)
+	encoded, _ := json.Marshal(request)
+	// Assert
+	if err != nil || request.Amount != 1999 {
+		t.Error("Failed while decoding a json amount")
+	}
+	if string(encoded) != `{"amount":19.99}` {
+		t.Errorf("Failed while encoding a json amount, got %s", encoded)
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 19.999}`), &request); err == nil {
+		t.Error("Expected an error while decoding an amount with three decimal places")
+	}
+}
+
+func Test_should_scan_database_values(t *testing.T) {
+	// Arrange
+	var a Amount
+	// Act / Assert
+	if err := a.Scan([]byte("6000.00")); err != nil || a != FromUnits(6000) {
+		t.Error("Failed while scanning a decimal column")
+	}
+	if err := a.Scan(0.30000000000000004); err != nil || a != 30 {
+		t.Error("Failed while scanning a float column")
+	}
+	if v, _ := MustParse("-12.3").Value(); v != "-12.30" {
+		t.Error("Failed while valuing an amount")
+	}
+}
+
+func Test_should_convert_amounts_rounding_to_the_closest_cent(t *testing.T) {
+	// Arrange
+	rate, err := ParseRate("0.925")
+	// Act
+	converted := MustParse("10.01").Convert(rate)
+	// Assert
+	if err != nil || converted != MustParse("9.26") {
+		t.Errorf("Failed while converting an amount, got %v", converted)
+	}
+	if MustParse("-10.01").Convert(rate) != MustParse("-9.26") {
+		t.Error("Failed while converting a negative amount")
+	}
+	if rate.Inverse().String() != "1.081081" {
+		t.Errorf("Failed while inverting a rate, got %v", rate.Inverse())
+	}
+}
+
+func Test_should_reject_rates_with_more_than_six_decimal_places(t *testing.T) {
+	// Arrange
+	inputs := []string{"1.0000001", "0", "-1.2", "abc"}
+	for _, input := range inputs {
+		// Act
+		_, err := ParseRate(input)
+		// Assert
+		if err == nil {
+			t.Errorf("Expected an error while parsing rate %q", input)
+		}
+	}
+}
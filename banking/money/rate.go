@@ -0,0 +1,124 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Rate : An exchange rate with six decimal places, 1 unit of the base currency buys Rate units of the quote currency
+type Rate int64
+
+// rateScale : Rates are stored as millionths
+const rateScale = 1000000
+
+// OneToOne : The rate applied when no conversion happens
+const OneToOne = Rate(rateScale)
+
+var ErrInvalidRate = errors.New("rate should be a positive number with at most six decimal places")
+
+// ParseRate : Parses a decimal string like "0.925" or "1.0825", more than six fractional digits are rejected
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, ErrInvalidRate
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > 6 {
+		return 0, ErrInvalidRate
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/rateScale {
+		return 0, ErrInvalidRate
+	}
+	micros, _ := strconv.ParseInt((fraction + "000000")[:6], 10, 64)
+	r := Rate(units*rateScale + micros)
+	if r <= 0 {
+		return 0, ErrInvalidRate
+	}
+	return r, nil
+}
+
+// String : Returns the rate with six decimal places, "0.925000"
+func (r Rate) String() string {
+	return fmt.Sprintf("%d.%06d", int64(r)/rateScale, int64(r)%rateScale)
+}
+
+// Inverse : Returns the rate for the opposite direction, rounded to six decimal places
+func (r Rate) Inverse() Rate {
+	return Rate(divRound(big.NewInt(rateScale*rateScale), big.NewInt(int64(r))))
+}
+
+// Convert : Converts the amount with the rate, rounding half away from zero to the closest cent
+func (a Amount) Convert(r Rate) Amount {
+	product := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(r)))
+	return Amount(divRound(product, big.NewInt(rateScale)))
+}
+
+// divRound : Integer division rounding half away from zero
+func divRound(n, d *big.Int) int64 {
+	q, m := new(big.Int).QuoRem(n, d, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(new(big.Int).Abs(d)) >= 0 {
+		if n.Sign()*d.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q.Int64()
+}
+
+// MarshalJSON : Encodes the rate as a JSON number with six decimal places
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON : Accepts a JSON number or a string
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Scan : Reads a DECIMAL rate column
+func (r *Rate) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*r = 0
+	case []byte:
+		*r, err = ParseRate(string(v))
+	case string:
+		*r, err = ParseRate(v)
+	case int64:
+		*r = Rate(v * rateScale)
+	case float64:
+		*r = Rate(math.Round(v * rateScale))
+	default:
+		err = fmt.Errorf("cannot scan %T into money.Rate", src)
+	}
+	return err
+}
+
+// Value : Writes the rate as a decimal string so the database keeps it exact
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
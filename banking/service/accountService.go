@@ -4,22 +4,45 @@ import (
 	"banking/domain"
 	"banking/dto"
 	"banking/errs"
+	"banking/money"
+	"strings"
 	"time"
 )
 
 const dbTSLayout = "2006-01-02 15:04:05"
+const dateLayout = "2006-01-02"
 
 type AccountService interface {
 	NewAccount(dto.NewAccountRequest) (*dto.NewAccountResponse, *errs.AppError)
 	MakeTransaction(request dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError)
+	MakeTransfer(request dto.TransferRequest) (*dto.TransferResponse, *errs.AppError)
+	GetTransactions(request dto.TransactionHistoryRequest) (*dto.TransactionHistoryResponse, *errs.AppError)
+	GetStatement(request dto.StatementRequest) (*dto.StatementResponse, *errs.AppError)
+	ReverseTransaction(request dto.ReversalRequest) (*dto.TransactionResponse, *errs.AppError)
+	GetAccount(accountId string, customerId string) (*dto.AccountResponse, *errs.AppError)
+	SetOverdraftLimit(request dto.OverdraftRequest) (*dto.AccountResponse, *errs.AppError)
+	FreezeAccount(request dto.AccountStatusRequest) (*dto.AccountResponse, *errs.AppError)
+	UnfreezeAccount(request dto.AccountStatusRequest) (*dto.AccountResponse, *errs.AppError)
+	CloseAccount(request dto.AccountStatusRequest) (*dto.AccountResponse, *errs.AppError)
 }
 
+// DefaultAccountService : Every change it saves also writes its domain events (AccountOpened, TransactionPosted,
+// AccountStatusChanged) to the outbox, in the same database transaction, for the OutboxRelay to deliver
 type DefaultAccountService struct {
-	repo domain.AccountRepository
+	repo     domain.AccountRepository
+	rates    domain.FxRateRepository
+	products domain.Products
+	// fraud : Screens transactions before they are posted, nil turns screening off
+	fraud FraudService
 }
 
 // NewAccount : Create a new account and returns a account response dto.
 func (s DefaultAccountService) NewAccount(req dto.NewAccountRequest) (*dto.NewAccountResponse, *errs.AppError) {
+	// accounts are opened in the default currency unless the client picks one
+	req.Currency = strings.ToUpper(req.Currency)
+	if req.Currency == "" {
+		req.Currency = money.DefaultCurrency
+	}
 	// Validate the request
 	err := req.Validate()
 
@@ -32,8 +55,11 @@ func (s DefaultAccountService) NewAccount(req dto.NewAccountRequest) (*dto.NewAc
 		CustomerId:  req.CustomerId,
 		OpeningDate: time.Now().Format("2006-01-02T15:04:05"),
 		AccountType: req.AccountType,
+		Currency:    req.Currency,
 		Amount:      req.Amount,
-		Status:      "1",
+		Status:      domain.AccountStatusActive,
+		// the product sets the starting overdraft, admins can change it per account
+		OverdraftLimit: s.products.For(req.AccountType).OverdraftLimit,
 	}
 	newAccount, err := s.repo.Save(a)
 	if err != nil {
@@ -45,26 +71,62 @@ func (s DefaultAccountService) NewAccount(req dto.NewAccountRequest) (*dto.NewAc
 
 func (s DefaultAccountService) MakeTransaction(req dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError) {
 	// incoming request validation
+	req.Currency = strings.ToUpper(req.Currency)
 	err := req.Validate()
 	if err != nil {
 		return nil, err
 	}
-	// server side validation for checking the available balance in the account
-	if req.IsTransactionTypeWithdrawal() {
-		account, err := s.repo.FindBy(req.AccountId)
+	account, err := s.repo.FindBy(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if err := account.CanPost(req.IsTransactionTypeWithdrawal()); err != nil {
+		return nil, err
+	}
+	if req.Currency == "" {
+		req.Currency = account.Currency
+	}
+
+	// build the domain object & save the transaction, the repository checks the
+	// available balance in the same statement that updates it
+	now := time.Now()
+	t := domain.Transaction{
+		AccountId:        req.AccountId,
+		Amount:           req.Amount,
+		TransactionType:  req.TransactionType,
+		TransactionDate:  now.Format(dbTSLayout),
+		Currency:         account.Currency,
+		OriginalAmount:   req.Amount,
+		OriginalCurrency: req.Currency,
+		FxRate:           money.OneToOne,
+	}
+	// amounts in another currency are converted with the rate effective today
+	if req.Currency != account.Currency {
+		rate, err := domain.FindConversionRate(s.rates, req.Currency, account.Currency, now.Format(dateLayout))
 		if err != nil {
 			return nil, err
 		}
-		if !account.CanWithdraw(req.Amount) {
-			return nil, errs.NewValidationError("Insufficient balance in the account")
+		t.Amount = req.Amount.Convert(rate)
+		t.FxRate = rate
+		if t.Amount <= 0 {
+			return nil, errs.NewValidationError("Amount is too small to convert into " + account.Currency)
 		}
 	}
-	// if all is well, build the domain object & save the transaction
-	t := domain.Transaction{
-		AccountId:       req.AccountId,
-		Amount:          req.Amount,
-		TransactionType: req.TransactionType,
-		TransactionDate: time.Now().Format(dbTSLayout),
+	if t.IsWithdrawal() {
+		dailyLimits, err := checkWithdrawalLimits(s.products, *account, t.Amount, req.Role, now)
+		if err != nil {
+			return nil, err
+		}
+		t.DailyLimits = dailyLimits
+	}
+	if s.fraud != nil {
+		held, err := s.fraud.Screen(*account, t, req.Role)
+		if err != nil {
+			return nil, err
+		}
+		if held != nil {
+			return held, nil
+		}
 	}
 	transaction, appError := s.repo.SaveTransaction(t)
 	if appError != nil {
@@ -74,6 +136,349 @@ func (s DefaultAccountService) MakeTransaction(req dto.TransactionRequest) (*dto
 	return &response, nil
 }
 
-func NewAccountService(repo domain.AccountRepository) DefaultAccountService {
-	return DefaultAccountService{repo}
+// MakeTransfer : Moves money between two accounts in a single database transaction
+func (s DefaultAccountService) MakeTransfer(req dto.TransferRequest) (*dto.TransferResponse, *errs.AppError) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	// the source account must belong to the customer in the url
+	account, err := s.repo.FindBy(req.FromAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if account.CustomerId != req.CustomerId {
+		return nil, errs.NewNotFoundError("Account not found")
+	}
+	if err := account.CanPost(true); err != nil {
+		return nil, err
+	}
+	destination, err := s.repo.FindBy(req.ToAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if err := destination.CanPost(false); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	rate, err := domain.FindConversionRate(s.rates, account.Currency, destination.Currency, now.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount.Convert(rate) <= 0 {
+		return nil, errs.NewValidationError("Amount is too small to convert into " + destination.Currency)
+	}
+
+	dailyLimits, err := checkWithdrawalLimits(s.products, *account, req.Amount, req.Role, now)
+	if err != nil {
+		return nil, err
+	}
+
+	transferId, idErr := domain.NewTransferId()
+	if idErr != nil {
+		return nil, errs.NewUnexpectedError("Unexpected error while creating the transfer")
+	}
+	t := domain.Transfer{
+		TransferId:       transferId,
+		FromAccountId:    req.FromAccountId,
+		ToAccountId:      req.ToAccountId,
+		Amount:           req.Amount,
+		Currency:         account.Currency,
+		CreditedAmount:   req.Amount.Convert(rate),
+		CreditedCurrency: destination.Currency,
+		FxRate:           rate,
+		TransferDate:     now.Format(dbTSLayout),
+		DailyLimits:      dailyLimits,
+	}
+	if s.fraud != nil {
+		held, err := s.fraud.ScreenTransfer(*account, t, req.Role)
+		if err != nil {
+			return nil, err
+		}
+		if held != nil {
+			return held, nil
+		}
+	}
+	transfer, err := s.repo.SaveTransfer(t)
+	if err != nil {
+		return nil, err
+	}
+	response := transfer.ToDto()
+	return &response, nil
+}
+
+// ReverseTransaction : Posts the opposite of a wrong transaction and marks the original as reversed
+func (s DefaultAccountService) ReverseTransaction(req dto.ReversalRequest) (*dto.TransactionResponse, *errs.AppError) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	account, err := s.findCustomerAccount(req.AccountId, req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == domain.AccountStatusClosed {
+		return nil, errs.NewValidationError("Account is closed")
+	}
+	original, err := s.repo.FindTransaction(req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if original.AccountId != account.AccountId {
+		return nil, errs.NewNotFoundError("Transaction not found")
+	}
+	reversal, err := domain.NewTransactionReversal(*original, req.Reason, req.Actor, req.Force, time.Now().Format(dbTSLayout))
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := s.repo.SaveReversal(*reversal)
+	if err != nil {
+		return nil, err
+	}
+	response := transaction.ToDto()
+	return &response, nil
+}
+
+// checkWithdrawalLimits : Money leaving an account must stay within the limits of its product for the caller role.
+// The per transaction limit is checked here, the 24 hour limits are returned for the repository to check when it
+// posts the withdrawal, nil when there are none.
+func checkWithdrawalLimits(products domain.Products, a domain.Account, amount money.Amount, role string, now time.Time) (*domain.DailyLimitCheck, *errs.AppError) {
+	limits := products.For(a.AccountType).LimitsFor(role)
+	if limits.IsUnlimited() {
+		return nil, nil
+	}
+	if err := limits.CheckPerTransaction(amount, a.Currency); err != nil {
+		return nil, err
+	}
+	if limits.Daily == 0 && limits.DailyCount == 0 {
+		return nil, nil
+	}
+	return &domain.DailyLimitCheck{Limits: limits, Since: now.Add(-24 * time.Hour).Format(dbTSLayout)}, nil
+}
+
+// GetTransactions : Returns a page of the account transactions matching the request filters
+func (s DefaultAccountService) GetTransactions(req dto.TransactionHistoryRequest) (*dto.TransactionHistoryResponse, *errs.AppError) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	account, err := s.repo.FindBy(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	// the account must belong to the customer in the url
+	if account.CustomerId != req.CustomerId {
+		return nil, errs.NewNotFoundError("Account not found")
+	}
+
+	filter := domain.TransactionFilter{
+		AccountId:       req.AccountId,
+		TransactionType: req.TransactionType,
+		MinAmount:       req.MinAmount,
+		MaxAmount:       req.MaxAmount,
+		// ask for one more row to know if there is a next page
+		Limit: req.PageSize() + 1,
+	}
+	if req.From != "" {
+		from, _ := time.Parse(dateLayout, req.From)
+		filter.From = from.Format(dbTSLayout)
+	}
+	if req.To != "" {
+		// the to date is inclusive, so the upper bound is the start of the next day
+		to, _ := time.Parse(dateLayout, req.To)
+		filter.To = to.AddDate(0, 0, 1).Format(dbTSLayout)
+	}
+	if req.Cursor != "" {
+		id, cursorErr := domain.ParseTransactionCursor(req.Cursor)
+		if cursorErr != nil {
+			return nil, errs.NewValidationError("Invalid pagination cursor")
+		}
+		filter.BeforeId = id
+	}
+
+	transactions, err := s.repo.FindTransactions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.TransactionHistoryResponse{Transactions: make([]dto.TransactionResponse, 0)}
+	if len(transactions) > req.PageSize() {
+		transactions = transactions[:req.PageSize()]
+		response.Next = transactions[len(transactions)-1].Cursor()
+	}
+	for _, t := range transactions {
+		response.Transactions = append(response.Transactions, t.ToDto())
+	}
+	return &response, nil
+}
+
+// GetStatement : Returns the opening balance, the transactions with their running balance and the closing balance
+// of the account between two dates, both included
+func (s DefaultAccountService) GetStatement(req dto.StatementRequest) (*dto.StatementResponse, *errs.AppError) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	account, err := s.repo.FindBy(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if account.CustomerId != req.CustomerId {
+		return nil, errs.NewNotFoundError("Account not found")
+	}
+
+	from, _ := time.Parse(dateLayout, req.From)
+	to, _ := time.Parse(dateLayout, req.To)
+	start := from.Format(dbTSLayout)
+	end := to.AddDate(0, 0, 1).Format(dbTSLayout)
+
+	// everything since the start is needed to roll the current balance back to the opening balance
+	since, err := s.repo.FindTransactionsBetween(req.AccountId, start, "")
+	if err != nil {
+		return nil, err
+	}
+	response := domain.NewStatement(*account, req.From, req.To, end, since).ToDto()
+	return &response, nil
+}
+
+// GetAccount : Returns the account with its balance and status
+func (s DefaultAccountService) GetAccount(accountId string, customerId string) (*dto.AccountResponse, *errs.AppError) {
+	account, err := s.findCustomerAccount(accountId, customerId)
+	if err != nil {
+		return nil, err
+	}
+	response := account.ToDto()
+	return &response, nil
+}
+
+// SetOverdraftLimit : Gives one account its own overdraft limit, only for products that allow an overdraft
+func (s DefaultAccountService) SetOverdraftLimit(req dto.OverdraftRequest) (*dto.AccountResponse, *errs.AppError) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	account, err := s.findCustomerAccount(req.AccountId, req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == domain.AccountStatusClosed {
+		return nil, errs.NewValidationError("Account is closed")
+	}
+	if *req.OverdraftLimit > 0 && !s.products.For(account.AccountType).AllowsOverdraft() {
+		return nil, errs.NewValidationError("Account type " + account.AccountType + " does not allow an overdraft")
+	}
+	if err = s.repo.SaveOverdraftLimit(account.AccountId, *req.OverdraftLimit); err != nil {
+		return nil, err
+	}
+	account.OverdraftLimit = *req.OverdraftLimit
+	response := account.ToDto()
+	return &response, nil
+}
+
+// FreezeAccount : Stops money leaving the account, deposits are still accepted
+func (s DefaultAccountService) FreezeAccount(req dto.AccountStatusRequest) (*dto.AccountResponse, *errs.AppError) {
+	return s.changeStatus(req, domain.AccountStatusFrozen)
+}
+
+// UnfreezeAccount : Makes a frozen account active again
+func (s DefaultAccountService) UnfreezeAccount(req dto.AccountStatusRequest) (*dto.AccountResponse, *errs.AppError) {
+	return s.changeStatus(req, domain.AccountStatusActive)
+}
+
+// CloseAccount : Closes an account with a zero balance, or pays the balance out to another account and closes it
+func (s DefaultAccountService) CloseAccount(req dto.AccountStatusRequest) (*dto.AccountResponse, *errs.AppError) {
+	return s.changeStatus(req, domain.AccountStatusClosed)
+}
+
+func (s DefaultAccountService) changeStatus(req dto.AccountStatusRequest, toStatus string) (*dto.AccountResponse, *errs.AppError) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	account, err := s.findCustomerAccount(req.AccountId, req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	change, err := domain.NewAccountStatusChange(*account, toStatus, req.Reason, req.Actor, now.Format(dbTSLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	var payout *domain.Transfer
+	if toStatus == domain.AccountStatusClosed {
+		if payout, err = s.closingPayout(*account, req.PayoutAccountId, now); err != nil {
+			return nil, err
+		}
+	} else if req.PayoutAccountId != "" {
+		return nil, errs.NewValidationError("A payout account only applies when closing an account")
+	}
+
+	if err = s.repo.SaveStatusChange(*change, payout); err != nil {
+		return nil, err
+	}
+	account.Status = toStatus
+	if payout != nil {
+		account.Amount = 0
+	}
+	response := account.ToDto()
+	return &response, nil
+}
+
+// closingPayout : Builds the transfer that empties a closing account, nil when there is nothing to pay out
+func (s DefaultAccountService) closingPayout(account domain.Account, payoutAccountId string, now time.Time) (*domain.Transfer, *errs.AppError) {
+	if account.Amount < 0 {
+		return nil, errs.NewValidationError("Account with a negative balance cannot be closed")
+	}
+	if account.Amount == 0 {
+		if payoutAccountId != "" {
+			return nil, errs.NewValidationError("Account balance is zero, there is nothing to pay out")
+		}
+		return nil, nil
+	}
+	if payoutAccountId == "" {
+		return nil, errs.NewValidationError("Account balance should be zero to close it, or a payout account is required")
+	}
+	destination, err := s.repo.FindBy(payoutAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if err := destination.CanPost(false); err != nil {
+		return nil, err
+	}
+	rate, err := domain.FindConversionRate(s.rates, account.Currency, destination.Currency, now.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+	if account.Amount.Convert(rate) <= 0 {
+		return nil, errs.NewValidationError("Balance is too small to pay out in " + destination.Currency)
+	}
+	transferId, idErr := domain.NewTransferId()
+	if idErr != nil {
+		return nil, errs.NewUnexpectedError("Unexpected error while creating the transfer")
+	}
+	return &domain.Transfer{
+		TransferId:       transferId,
+		FromAccountId:    account.AccountId,
+		ToAccountId:      destination.AccountId,
+		Amount:           account.Amount,
+		Currency:         account.Currency,
+		CreditedAmount:   account.Amount.Convert(rate),
+		CreditedCurrency: destination.Currency,
+		FxRate:           rate,
+		TransferDate:     now.Format(dbTSLayout),
+		ClosingPayout:    true,
+	}, nil
+}
+
+// findCustomerAccount : The account must belong to the customer in the url
+func (s DefaultAccountService) findCustomerAccount(accountId string, customerId string) (*domain.Account, *errs.AppError) {
+	account, err := s.repo.FindBy(accountId)
+	if err != nil {
+		return nil, err
+	}
+	if account.CustomerId != customerId {
+		return nil, errs.NewNotFoundError("Account not found")
+	}
+	return account, nil
+}
+
+func NewAccountService(repo domain.AccountRepository, rates domain.FxRateRepository, products domain.Products,
+	fraud FraudService) DefaultAccountService {
+	return DefaultAccountService{repo, rates, products, fraud}
 }
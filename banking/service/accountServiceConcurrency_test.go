@@ -0,0 +1,164 @@
+package service
+
+import (
+	realdomain "banking/domain"
+	"banking/dto"
+	"banking/errs"
+	"banking/migrations"
+	"banking/money"
+	"config"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// newSQLiteClient : A migrated database of its own for the test, the repositories run on it like in production
+func newSQLiteClient(t *testing.T) *sqlx.DB {
+	client, err := sqlx.Open(config.DriverSQLite, filepath.Join(t.TempDir(), "banking.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetMaxOpenConns(1)
+	t.Cleanup(func() { client.Close() })
+	migrator, err := migrations.NewMigrator(client)
+	if err == nil {
+		_, err = migrator.Up()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func Test_should_never_drive_the_balance_below_zero_with_parallel_withdrawals(t *testing.T) {
+	// Arrange
+	repo := realdomain.NewAccountRepositoryDB(newSQLiteClient(t))
+	account, appError := repo.Save(realdomain.Account{CustomerId: "2000", OpeningDate: "2021-01-01 10:00:00", AccountType: "saving",
+		Currency: "USD", Amount: money.FromUnits(1000), Status: realdomain.AccountStatusActive})
+	if appError != nil {
+		t.Fatal(appError.Message)
+	}
+	service := NewAccountService(repo, nil, nil, nil)
+	req := dto.TransactionRequest{AccountId: account.AccountId, Amount: money.FromUnits(100), TransactionType: dto.WITHDRAWAL}
+
+	// Act
+	var wg sync.WaitGroup
+	results := make(chan *errs.AppError, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, appError := service.MakeTransaction(req)
+			results <- appError
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// Assert
+	succeeded := 0
+	for appError := range results {
+		if appError == nil {
+			succeeded++
+		} else if appError.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Unexpected error while withdrawing: %s", appError.Message)
+		}
+	}
+	if succeeded != 10 {
+		t.Errorf("Expected 10 withdrawals to succeed, got %d", succeeded)
+	}
+	found, _ := repo.FindBy(account.AccountId)
+	if found.Amount != 0 {
+		t.Errorf("Expected the balance to end at zero, got %v", found.Amount)
+	}
+}
+
+func Test_should_hold_parallel_withdrawals_to_the_daily_count_limit(t *testing.T) {
+	// Arrange
+	repo := realdomain.NewAccountRepositoryDB(newSQLiteClient(t))
+	account, appError := repo.Save(realdomain.Account{CustomerId: "2000", OpeningDate: "2021-01-01 10:00:00", AccountType: "savings",
+		Currency: "USD", Amount: money.FromUnits(1000), Status: realdomain.AccountStatusActive})
+	if appError != nil {
+		t.Fatal(appError.Message)
+	}
+	products := realdomain.Products{"savings": {Code: "savings", Limits: realdomain.WithdrawalLimits{DailyCount: 3}}}
+	service := NewAccountService(repo, nil, products, nil)
+	req := dto.TransactionRequest{AccountId: account.AccountId, Amount: money.FromUnits(10), TransactionType: dto.WITHDRAWAL, Role: "user"}
+
+	// Act
+	var wg sync.WaitGroup
+	results := make(chan *errs.AppError, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, appError := service.MakeTransaction(req)
+			results <- appError
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// Assert
+	succeeded := 0
+	for appError := range results {
+		if appError == nil {
+			succeeded++
+		} else if appError.ErrorCode != errs.ErrCodeLimitExceeded {
+			t.Errorf("Unexpected error while withdrawing: %s", appError.Message)
+		}
+	}
+	if succeeded != 3 {
+		t.Errorf("Expected 3 withdrawals to succeed, got %d", succeeded)
+	}
+	found, _ := repo.FindBy(account.AccountId)
+	if found.Amount != money.FromUnits(970) {
+		t.Errorf("Expected the balance to end at 970, got %v", found.Amount)
+	}
+}
+
+func Test_should_post_the_interest_of_a_month_once_with_overlapping_runs(t *testing.T) {
+	// Arrange
+	client := newSQLiteClient(t)
+	repo := realdomain.NewAccountRepositoryDB(client)
+	account, appError := repo.Save(realdomain.Account{CustomerId: "2000", OpeningDate: "2021-01-01 10:00:00", AccountType: "savings",
+		Currency: "USD", Amount: money.FromUnits(100000), Status: realdomain.AccountStatusActive})
+	if appError != nil {
+		t.Fatal(appError.Message)
+	}
+	service := NewInterestService(repo, realdomain.NewInterestRepositoryDB(client), realdomain.DefaultProducts())
+	asOf := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	var wg sync.WaitGroup
+	results := make(chan *errs.AppError, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, appError := service.Run(asOf)
+			results <- appError
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// Assert
+	for appError := range results {
+		if appError != nil {
+			t.Errorf("Unexpected error while running interest: %s", appError.Message)
+		}
+	}
+	var postings int
+	if err := client.Get(&postings, `SELECT COUNT(*) FROM transactions WHERE account_id = ? AND transaction_type = ?`,
+		account.AccountId, realdomain.INTEREST); err != nil {
+		t.Fatal(err)
+	}
+	if postings != 2 {
+		t.Errorf("Expected January and February to be posted once each, got %d postings", postings)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"banking/dto"
 	"banking/errs"
 	"banking/mocks/domain"
+	servicemocks "banking/mocks/service"
+	"banking/money"
+	"net/http"
 	"testing"
 	"time"
 
@@ -12,13 +15,15 @@ import (
 )
 
 var mockRepo *domain.MockAccountRepository
-var ctrl gomock.Controller
+var mockRates *domain.MockFxRateRepository
+var ctrl *gomock.Controller
 var service AccountService
 
 func setup(t *testing.T) func() {
-	ctrl := gomock.NewController(t)
+	ctrl = gomock.NewController(t)
 	mockRepo = domain.NewMockAccountRepository(ctrl)
-	service = NewAccountService(mockRepo)
+	mockRates = domain.NewMockFxRateRepository(ctrl)
+	service = NewAccountService(mockRepo, mockRates, realdomain.DefaultProducts(), nil)
 	return func() {
 		service = nil
 		defer ctrl.Finish()
@@ -35,7 +40,7 @@ func Test_should_return_a_validation_error_response_when_the_request_is_not_vali
 		AccountType: "saving",
 		Amount:      0,
 	}
-	service := NewAccountService(nil)
+	service := NewAccountService(nil, nil, nil, nil)
 	// Act
 	_, appError := service.NewAccount(req)
 	// Assert
@@ -52,12 +57,13 @@ func Test_should_return_an_error_from_the_server_side_if_the_new_account_cannot_
 	req := dto.NewAccountRequest{
 		CustomerId:  "100",
 		AccountType: "savings",
-		Amount:      6000,
+		Amount:      money.FromUnits(6000),
 	}
 	account := realdomain.Account{
 		CustomerId:  req.CustomerId,
 		OpeningDate: time.Now().Format("2006-01-02T15:04:05"),
 		AccountType: req.AccountType,
+		Currency:    money.DefaultCurrency,
 		Amount:      req.Amount,
 		Status:      "1",
 	}
@@ -79,12 +85,13 @@ func Test_should_return_new_account_response_when_a_new_account_is_saved_success
 	req := dto.NewAccountRequest{
 		CustomerId:  "100",
 		AccountType: "savings",
-		Amount:      6000,
+		Amount:      money.FromUnits(6000),
 	}
 	account := realdomain.Account{
 		CustomerId:  req.CustomerId,
 		OpeningDate: time.Now().Format("2006-01-02T15:04:05"),
 		AccountType: req.AccountType,
+		Currency:    money.DefaultCurrency,
 		Amount:      req.Amount,
 		Status:      "1",
 	}
@@ -103,3 +110,472 @@ func Test_should_return_new_account_response_when_a_new_account_is_saved_success
 		t.Error("Failed while mathching new account id")
 	}
 }
+
+func Test_should_return_next_cursor_when_there_are_more_transactions_than_the_page_size(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionHistoryRequest{AccountId: "95470", CustomerId: "2000", Limit: 2}
+	mockRepo.EXPECT().FindBy("95470").Return(&realdomain.Account{AccountId: "95470", CustomerId: "2000"}, nil)
+	transactions := []realdomain.Transaction{
+		{TransactionId: "30", AccountId: "95470", Amount: money.FromUnits(100), TransactionType: "deposit"},
+		{TransactionId: "20", AccountId: "95470", Amount: money.FromUnits(50), TransactionType: "withdrawal"},
+		{TransactionId: "10", AccountId: "95470", Amount: money.FromUnits(25), TransactionType: "deposit"},
+	}
+	mockRepo.EXPECT().FindTransactions(realdomain.TransactionFilter{AccountId: "95470", Limit: 3}).Return(transactions, nil)
+	// Act
+	history, appError := service.GetTransactions(req)
+
+	// Assert
+	if appError != nil {
+		t.Fatal("Test failed while fetching the transaction history")
+	}
+	if len(history.Transactions) != 2 {
+		t.Error("Failed while matching the page size")
+	}
+	if history.Next != transactions[1].Cursor() {
+		t.Error("Failed while matching the next cursor")
+	}
+}
+
+func Test_should_return_not_found_when_the_account_belongs_to_another_customer(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionHistoryRequest{AccountId: "95470", CustomerId: "2001"}
+	mockRepo.EXPECT().FindBy("95470").Return(&realdomain.Account{AccountId: "95470", CustomerId: "2000"}, nil)
+	// Act
+	_, appError := service.GetTransactions(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusNotFound {
+		t.Error("Failed while validating the account owner")
+	}
+}
+
+func Test_should_return_the_repository_error_when_the_transfer_would_overdraw_the_source(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransferRequest{FromAccountId: "95470", ToAccountId: "95471", Amount: money.FromUnits(700), CustomerId: "2000"}
+	mockRepo.EXPECT().FindBy("95470").Return(&realdomain.Account{AccountId: "95470", CustomerId: "2000", Currency: "USD", Amount: money.FromUnits(500)}, nil)
+	mockRepo.EXPECT().FindBy("95471").Return(&realdomain.Account{AccountId: "95471", CustomerId: "2001", Currency: "USD"}, nil)
+	mockRepo.EXPECT().SaveTransfer(gomock.Any()).Return(nil, errs.NewValidationError("Insufficient balance in the account"))
+	// Act
+	_, appError := service.MakeTransfer(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Failed while validating the transfer balance")
+	}
+}
+
+func Test_should_convert_a_deposit_in_another_currency_with_the_effective_rate(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionRequest{AccountId: "95470", Amount: money.FromUnits(100), TransactionType: dto.DEPOSIT, Currency: "eur"}
+	mockRepo.EXPECT().FindBy("95470").Return(&realdomain.Account{AccountId: "95470", Currency: "USD"}, nil)
+	mockRates.EXPECT().FindEffective("EUR", "USD", gomock.Any()).Return(&realdomain.FxRate{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1082500}, nil)
+	mockRepo.EXPECT().SaveTransaction(gomock.Any()).DoAndReturn(func(t realdomain.Transaction) (*realdomain.Transaction, *errs.AppError) {
+		return &t, nil
+	})
+	// Act
+	transaction, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError != nil {
+		t.Fatal("Test failed while making a deposit in another currency")
+	}
+	if transaction.Amount != money.MustParse("108.25") || transaction.Currency != "USD" {
+		t.Error("Failed while matching the converted amount")
+	}
+	if transaction.OriginalAmount != money.FromUnits(100) || transaction.OriginalCurrency != "EUR" {
+		t.Error("Failed while matching the original amount")
+	}
+}
+
+func Test_should_reject_a_deposit_that_converts_to_zero(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionRequest{AccountId: "95470", Amount: money.FromUnits(1), TransactionType: dto.DEPOSIT, Currency: "CRC"}
+	mockRepo.EXPECT().FindBy("95470").Return(&realdomain.Account{AccountId: "95470", Currency: "USD"}, nil)
+	mockRates.EXPECT().FindEffective("CRC", "USD", gomock.Any()).Return(&realdomain.FxRate{BaseCurrency: "CRC", QuoteCurrency: "USD", Rate: 1900}, nil)
+	// Act
+	_, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Failed while validating a deposit converted to zero")
+	}
+}
+
+func Test_should_reject_a_transfer_that_converts_to_zero(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransferRequest{FromAccountId: "95470", ToAccountId: "95471", Amount: money.FromUnits(1), CustomerId: "2000"}
+	mockRepo.EXPECT().FindBy("95470").Return(&realdomain.Account{AccountId: "95470", CustomerId: "2000", Currency: "CRC", Amount: money.FromUnits(500)}, nil)
+	mockRepo.EXPECT().FindBy("95471").Return(&realdomain.Account{AccountId: "95471", CustomerId: "2001", Currency: "USD"}, nil)
+	mockRates.EXPECT().FindEffective("CRC", "USD", gomock.Any()).Return(&realdomain.FxRate{BaseCurrency: "CRC", QuoteCurrency: "USD", Rate: 1900}, nil)
+	// Act
+	_, appError := service.MakeTransfer(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Failed while validating a transfer converted to zero")
+	}
+}
+
+func Test_should_return_a_validation_error_when_no_rate_is_loaded_for_the_currencies(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionRequest{AccountId: "95470", Amount: money.FromUnits(100), TransactionType: dto.DEPOSIT, Currency: "GBP"}
+	mockRepo.EXPECT().FindBy("95470").Return(&realdomain.Account{AccountId: "95470", Currency: "USD"}, nil)
+	mockRates.EXPECT().FindEffective(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	// Act
+	_, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Failed while validating a missing exchange rate")
+	}
+}
+
+func Test_should_reject_a_withdrawal_from_a_frozen_account(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionRequest{
+		AccountId:       "2000",
+		CustomerId:      "100",
+		Amount:          money.FromUnits(10),
+		TransactionType: "withdrawal",
+	}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(100), Status: realdomain.AccountStatusFrozen}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	// Act
+	_, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError == nil || appError.Message != "Account is frozen" {
+		t.Error("Test failed while validating a withdrawal from a frozen account")
+	}
+}
+
+func Test_should_require_a_payout_account_to_close_an_account_with_balance(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.AccountStatusRequest{AccountId: "2000", CustomerId: "100", Actor: "admin", Reason: "customer request"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(100), Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	// Act
+	_, appError := service.CloseAccount(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Test failed while validating the close account payout")
+	}
+}
+
+func Test_should_pay_out_the_balance_when_closing_an_account(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.AccountStatusRequest{AccountId: "2000", CustomerId: "100", Actor: "admin", Reason: "customer request",
+		PayoutAccountId: "2001"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(100), Status: realdomain.AccountStatusActive}
+	payoutAccount := realdomain.Account{AccountId: "2001", CustomerId: "100", Currency: money.DefaultCurrency,
+		Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	mockRepo.EXPECT().FindBy("2001").Return(&payoutAccount, nil)
+	mockRepo.EXPECT().SaveStatusChange(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(c realdomain.AccountStatusChange, payout *realdomain.Transfer) *errs.AppError {
+			if c.ToStatus != realdomain.AccountStatusClosed || c.Actor != "admin" {
+				t.Error("Test failed while validating the status change")
+			}
+			if payout == nil || payout.Amount != money.FromUnits(100) || payout.ToAccountId != "2001" {
+				t.Error("Test failed while validating the payout transfer")
+			}
+			return nil
+		})
+	// Act
+	response, appError := service.CloseAccount(req)
+
+	// Assert
+	if appError != nil || response.Status != "Closed" || response.Balance != 0 {
+		t.Error("Test failed while closing an account")
+	}
+}
+
+func Test_should_not_reverse_a_transaction_of_another_account(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.ReversalRequest{AccountId: "2000", CustomerId: "100", TransactionId: "512", Actor: "admin", Reason: "wrong deposit"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	mockRepo.EXPECT().FindTransaction("512").Return(&realdomain.Transaction{TransactionId: "512", AccountId: "2001"}, nil)
+	// Act
+	_, appError := service.ReverseTransaction(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusNotFound {
+		t.Error("Test failed while reversing a transaction of another account")
+	}
+}
+
+func Test_should_open_checking_accounts_with_the_product_overdraft(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.NewAccountRequest{CustomerId: "100", AccountType: "checking", Amount: money.FromUnits(6000)}
+	mockRepo.EXPECT().Save(gomock.Any()).DoAndReturn(func(a realdomain.Account) (*realdomain.Account, *errs.AppError) {
+		if a.OverdraftLimit != money.FromUnits(500) {
+			t.Errorf("Failed while matching the overdraft limit, got %v", a.OverdraftLimit)
+		}
+		a.AccountId = "201"
+		return &a, nil
+	})
+	// Act
+	_, appError := service.NewAccount(req)
+
+	// Assert
+	if appError != nil {
+		t.Error("Test failed while opening a checking account")
+	}
+}
+
+func Test_should_not_give_an_overdraft_to_a_savings_account(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	limit := money.FromUnits(100)
+	req := dto.OverdraftRequest{AccountId: "2000", CustomerId: "100", OverdraftLimit: &limit}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", AccountType: "savings", Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	// Act
+	_, appError := service.SetOverdraftLimit(req)
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Test failed while setting an overdraft on a savings account")
+	}
+}
+
+func Test_should_hand_the_daily_limits_to_the_repository_with_the_withdrawal(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionRequest{AccountId: "2000", CustomerId: "100", Amount: money.FromUnits(1500), TransactionType: "withdrawal", Role: "user"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", AccountType: "savings", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(9000), Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	var saved realdomain.Transaction
+	mockRepo.EXPECT().SaveTransaction(gomock.Any()).DoAndReturn(func(t realdomain.Transaction) (*realdomain.Transaction, *errs.AppError) {
+		saved = t
+		return &t, nil
+	})
+	// Act
+	_, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError != nil || saved.DailyLimits == nil || saved.DailyLimits.Limits.Daily != money.FromUnits(5000) {
+		t.Error("Test failed while passing the daily withdrawal limits to the repository")
+	}
+}
+
+func Test_should_refuse_a_withdrawal_over_the_per_transaction_limit_before_saving_it(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionRequest{AccountId: "2000", CustomerId: "100", Amount: money.FromUnits(2500), TransactionType: "withdrawal", Role: "user"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", AccountType: "savings", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(9000), Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	// Act
+	_, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError == nil || appError.ErrorCode != errs.ErrCodeLimitExceeded {
+		t.Error("Test failed while validating the per transaction withdrawal limit")
+	}
+}
+
+func Test_should_not_hold_admins_to_the_customer_withdrawal_limits(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+
+	req := dto.TransactionRequest{AccountId: "2000", CustomerId: "100", Amount: money.FromUnits(3000), TransactionType: "withdrawal", Role: "admin"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", AccountType: "savings", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(9000), Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	mockRepo.EXPECT().SaveTransaction(gomock.Any()).DoAndReturn(func(t realdomain.Transaction) (*realdomain.Transaction, *errs.AppError) {
+		if t.DailyLimits != nil {
+			return nil, errs.NewLimitExceededError("Admins should not be held to daily limits")
+		}
+		t.TransactionId = "512"
+		return &t, nil
+	})
+	// Act
+	_, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError != nil {
+		t.Error("Test failed while withdrawing over the customer limit as admin")
+	}
+}
+
+func Test_should_hold_a_transaction_flagged_for_review(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+	mockFraud := servicemocks.NewMockFraudService(ctrl)
+	service = NewAccountService(mockRepo, mockRates, realdomain.DefaultProducts(), mockFraud)
+
+	req := dto.TransactionRequest{AccountId: "2000", CustomerId: "100", Amount: money.FromUnits(100), TransactionType: "deposit", Role: "user"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", AccountType: "savings", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(9000), Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	mockFraud.EXPECT().Screen(account, gomock.Any(), "user").
+		Return(&dto.TransactionResponse{AccountId: "2000", Status: dto.TransactionStatusPending, PendingId: "7"}, nil)
+	// Act
+	response, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError != nil || !response.IsPending() || response.PendingId != "7" {
+		t.Error("Test failed while holding a transaction for review")
+	}
+}
+
+func Test_should_return_a_denied_error_when_fraud_screening_denies_the_transaction(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+	mockFraud := servicemocks.NewMockFraudService(ctrl)
+	service = NewAccountService(mockRepo, mockRates, realdomain.DefaultProducts(), mockFraud)
+
+	req := dto.TransactionRequest{AccountId: "2000", CustomerId: "100", Amount: money.FromUnits(100), TransactionType: "deposit", Role: "user"}
+	account := realdomain.Account{AccountId: "2000", CustomerId: "100", AccountType: "savings", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(9000), Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&account, nil)
+	mockFraud.EXPECT().Screen(account, gomock.Any(), "user").Return(nil, errs.NewTransactionDeniedError("Transaction denied by fraud screening"))
+	// Act
+	_, appError := service.MakeTransaction(req)
+
+	// Assert
+	if appError == nil || appError.ErrorCode != errs.ErrCodeTransactionDenied || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Test failed while denying a transaction in fraud screening")
+	}
+}
+
+func Test_should_hold_a_transfer_flagged_for_review(t *testing.T) {
+	// Arrange
+	teardown := setup(t)
+	defer teardown()
+	mockFraud := servicemocks.NewMockFraudService(ctrl)
+	service = NewAccountService(mockRepo, mockRates, realdomain.DefaultProducts(), mockFraud)
+
+	req := dto.TransferRequest{FromAccountId: "2000", ToAccountId: "2001", CustomerId: "100", Amount: money.FromUnits(100), Role: "user"}
+	from := realdomain.Account{AccountId: "2000", CustomerId: "100", AccountType: "savings", Currency: money.DefaultCurrency,
+		Amount: money.FromUnits(9000), Status: realdomain.AccountStatusActive}
+	to := realdomain.Account{AccountId: "2001", CustomerId: "200", AccountType: "savings", Currency: money.DefaultCurrency,
+		Status: realdomain.AccountStatusActive}
+	mockRepo.EXPECT().FindBy("2000").Return(&from, nil)
+	mockRepo.EXPECT().FindBy("2001").Return(&to, nil)
+	mockFraud.EXPECT().ScreenTransfer(from, gomock.Any(), "user").
+		Return(&dto.TransferResponse{FromAccountId: "2000", ToAccountId: "2001", Status: dto.TransactionStatusPending, PendingId: "8"}, nil)
+	// Act
+	response, appError := service.MakeTransfer(req)
+
+	// Assert
+	if appError != nil || !response.IsPending() || response.PendingId != "8" {
+		t.Error("Test failed while holding a transfer for review")
+	}
+}
+
+func Test_should_move_a_held_transfer_only_once_it_is_approved(t *testing.T) {
+	// Arrange
+	store := realdomain.NewSeededMemoryStore()
+	accounts := realdomain.NewAccountRepositoryStub(store)
+	reviewAll := realdomain.FraudRules{Rules: []realdomain.FraudRule{
+		realdomain.VelocityRule{Window: time.Hour, MaxCount: 0, TransactionType: realdomain.WITHDRAWAL, Decision: realdomain.ScreeningReview},
+	}}
+	fraud := NewFraudService(accounts, realdomain.NewPendingTransactionRepositoryStub(store), reviewAll, realdomain.DefaultProducts())
+	service := NewAccountService(accounts, realdomain.NewFxRateRepositoryStub(store), realdomain.DefaultProducts(), fraud)
+	req := dto.TransferRequest{FromAccountId: "95470", ToAccountId: "95471", CustomerId: "1001", Amount: money.FromUnits(100), Role: "user"}
+
+	// Act
+	held, appError := service.MakeTransfer(req)
+	if appError != nil || !held.IsPending() {
+		t.Fatal("The transfer should be held for review")
+	}
+	before, _ := accounts.FindBy("95471")
+	approved, appError := fraud.ApprovePendingTransaction(held.PendingId, "admin")
+
+	// Assert
+	if appError != nil || approved.TransferId == "" || approved.AccountId != "95470" {
+		t.Fatal("Test failed while approving the held transfer")
+	}
+	after, _ := accounts.FindBy("95471")
+	if before.Amount != money.FromUnits(3342) || after.Amount != money.FromUnits(3442) {
+		t.Error("The destination should only be credited on approval: ", before.Amount, after.Amount)
+	}
+}
+
+func Test_should_hold_an_approved_withdrawal_to_the_daily_limits_of_the_requester(t *testing.T) {
+	// Arrange
+	store := realdomain.NewSeededMemoryStore()
+	accounts := realdomain.NewAccountRepositoryStub(store)
+	reviewAll := realdomain.FraudRules{Rules: []realdomain.FraudRule{
+		realdomain.VelocityRule{Window: time.Hour, MaxCount: 0, TransactionType: realdomain.WITHDRAWAL, Decision: realdomain.ScreeningReview},
+	}}
+	products := realdomain.Products{"savings": {Code: "savings", Limits: realdomain.WithdrawalLimits{DailyCount: 1}}}
+	fraud := NewFraudService(accounts, realdomain.NewPendingTransactionRepositoryStub(store), reviewAll, products)
+	service := NewAccountService(accounts, realdomain.NewFxRateRepositoryStub(store), products, fraud)
+	req := dto.TransactionRequest{AccountId: "95470", CustomerId: "1001", Amount: money.FromUnits(10), TransactionType: dto.WITHDRAWAL, Role: "user"}
+
+	// both fit the limit when they are requested, nothing was withdrawn yet
+	first, appError := service.MakeTransaction(req)
+	if appError != nil || !first.IsPending() {
+		t.Fatal("The first withdrawal should be held for review")
+	}
+	second, appError := service.MakeTransaction(req)
+	if appError != nil || !second.IsPending() {
+		t.Fatal("The second withdrawal should be held for review")
+	}
+
+	// Act
+	_, firstError := fraud.ApprovePendingTransaction(first.PendingId, "admin")
+	_, secondError := fraud.ApprovePendingTransaction(second.PendingId, "admin")
+
+	// Assert
+	if firstError != nil {
+		t.Fatal("Test failed while approving the first withdrawal: ", firstError.Message)
+	}
+	if secondError == nil || secondError.ErrorCode != errs.ErrCodeLimitExceeded {
+		t.Error("The approval over the daily count of the requester should be refused")
+	}
+}
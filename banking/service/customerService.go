@@ -4,13 +4,19 @@ import (
 	"banking/domain"
 	"banking/dto"
 	"banking/errs"
+	"strings"
 )
 
-// CustomerService : Primary Port
 //go:generate mockgen -destination=../mocks/service/mockCustomerService.go -package=service banking/service CustomerService
+
+// CustomerService : Primary Port
 type CustomerService interface {
-	GetAllCustomer(status string) ([]dto.CustomerResponse, *errs.AppError)
+	GetAllCustomer(request dto.CustomerSearchRequest) (*dto.CustomerSearchResponse, *errs.AppError)
 	GetCustomer(id string) (*dto.CustomerResponse, *errs.AppError)
+	NewCustomer(request dto.CustomerRequest) (*dto.CustomerResponse, *errs.AppError)
+	UpdateCustomer(id string, request dto.CustomerRequest) (*dto.CustomerResponse, *errs.AppError)
+	PatchCustomer(id string, request dto.CustomerPatchRequest) (*dto.CustomerResponse, *errs.AppError)
+	DeactivateCustomer(id string) (*dto.CustomerResponse, *errs.AppError)
 }
 
 // DefaultCustomerService : Named Default because the could be more implementations
@@ -19,29 +25,50 @@ type DefaultCustomerService struct {
 	repo domain.CustomerRepository
 }
 
-// GetAllCustomer : Helper function which returns a slice of customer dto, status can be passed
-func (s DefaultCustomerService) GetAllCustomer(status string) ([]dto.CustomerResponse, *errs.AppError) {
-	var query string
-
-	if status == "active" {
-		query = "1"
-	} else if status == "inactive" {
-		query = "0"
-	} else {
-		query = ""
+// GetAllCustomer : Returns a page of the customers matching the request filters
+func (s DefaultCustomerService) GetAllCustomer(req dto.CustomerSearchRequest) (*dto.CustomerSearchResponse, *errs.AppError) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	filter := domain.CustomerFilter{
+		Name:       strings.TrimSpace(req.Name),
+		City:       strings.TrimSpace(req.City),
+		Zipcode:    strings.TrimSpace(req.Zipcode),
+		BornFrom:   req.BornFrom,
+		BornTo:     req.BornTo,
+		SortBy:     req.SortField(),
+		Descending: req.Order == "desc",
+		// ask for one more row to know if there is a next page
+		Limit: req.PageSize() + 1,
+	}
+	switch req.Status {
+	case "active":
+		filter.Status = domain.CustomerStatusActive
+	case "inactive":
+		filter.Status = domain.CustomerStatusInactive
+	}
+	if req.Cursor != "" {
+		after, cursorErr := domain.ParseCustomerCursor(req.Cursor, filter.SortBy)
+		if cursorErr != nil {
+			return nil, errs.NewValidationError("Invalid pagination cursor")
+		}
+		filter.After = after
 	}
 
-	var customersResponse []dto.CustomerResponse
-
-	customers, err := s.repo.FindAll(query)
+	customers, err := s.repo.FindAll(filter)
 	if err != nil {
 		return nil, err
 	}
 
+	response := dto.CustomerSearchResponse{Customers: make([]dto.CustomerResponse, 0)}
+	if len(customers) > req.PageSize() {
+		customers = customers[:req.PageSize()]
+		response.Next = customers[len(customers)-1].Cursor(filter.SortBy)
+	}
 	for _, c := range customers {
-		customersResponse = append(customersResponse, c.ToDTO())
+		response.Customers = append(response.Customers, c.ToDTO())
 	}
-	return customersResponse, nil
+	return &response, nil
 }
 
 // GetCustomer : Helper function which returns a customer DTO
@@ -54,6 +81,86 @@ func (s DefaultCustomerService) GetCustomer(id string) (*dto.CustomerResponse, *
 	return &response, nil
 }
 
+// NewCustomer : Validates and saves a new active customer
+func (s DefaultCustomerService) NewCustomer(req dto.CustomerRequest) (*dto.CustomerResponse, *errs.AppError) {
+	req = trimCustomerRequest(req)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	c := domain.Customer{
+		Name:        req.Name,
+		City:        req.City,
+		Zipcode:     req.Zipcode,
+		DateofBirth: req.DateofBirth,
+		Status:      domain.CustomerStatusActive,
+	}
+	customer, err := s.repo.Save(c)
+	if err != nil {
+		return nil, err
+	}
+	response := customer.ToDTO()
+	return &response, nil
+}
+
+// UpdateCustomer : Replaces all the customer details
+func (s DefaultCustomerService) UpdateCustomer(id string, req dto.CustomerRequest) (*dto.CustomerResponse, *errs.AppError) {
+	c, err := s.repo.ById(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveCustomerDetails(*c, req)
+}
+
+// PatchCustomer : Changes only the customer details present in the request
+func (s DefaultCustomerService) PatchCustomer(id string, req dto.CustomerPatchRequest) (*dto.CustomerResponse, *errs.AppError) {
+	c, err := s.repo.ById(id)
+	if err != nil {
+		return nil, err
+	}
+	current := dto.CustomerRequest{Name: c.Name, City: c.City, Zipcode: c.Zipcode, DateofBirth: c.DateofBirth}
+	return s.saveCustomerDetails(*c, req.ApplyTo(current))
+}
+
+// DeactivateCustomer : Marks the customer as inactive, the customer accounts are left as they are
+func (s DefaultCustomerService) DeactivateCustomer(id string) (*dto.CustomerResponse, *errs.AppError) {
+	c, err := s.repo.ById(id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status == domain.CustomerStatusInactive {
+		return nil, errs.NewValidationError("Customer is already inactive")
+	}
+	if err = s.repo.Deactivate(id); err != nil {
+		return nil, err
+	}
+	c.Status = domain.CustomerStatusInactive
+	response := c.ToDTO()
+	return &response, nil
+}
+
+func (s DefaultCustomerService) saveCustomerDetails(c domain.Customer, req dto.CustomerRequest) (*dto.CustomerResponse, *errs.AppError) {
+	req = trimCustomerRequest(req)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	c.Name = req.Name
+	c.City = req.City
+	c.Zipcode = req.Zipcode
+	c.DateofBirth = req.DateofBirth
+	if err := s.repo.Update(c); err != nil {
+		return nil, err
+	}
+	response := c.ToDTO()
+	return &response, nil
+}
+
+func trimCustomerRequest(req dto.CustomerRequest) dto.CustomerRequest {
+	req.Name = strings.TrimSpace(req.Name)
+	req.City = strings.TrimSpace(req.City)
+	req.Zipcode = strings.TrimSpace(req.Zipcode)
+	return req
+}
+
 // NewCustomerService : Return a new DefaultCustomerService which takes a CustomerRepository.
 func NewCustomerService(repository domain.CustomerRepository) DefaultCustomerService {
 	return DefaultCustomerService{repository}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	realdomain "banking/domain"
+	"banking/dto"
+	"banking/mocks/domain"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_should_keep_the_other_customer_details_when_patching(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockCustomerRepository(ctrl)
+	service := NewCustomerService(repo)
+
+	current := realdomain.Customer{ID: "1001", Name: "Jotaro Kujo", City: "Okinawa", Zipcode: "30205",
+		DateofBirth: "1970-01-01", Status: realdomain.CustomerStatusActive}
+	updated := current
+	updated.City = "Morioh"
+	repo.EXPECT().ById("1001").Return(&current, nil)
+	repo.EXPECT().Update(updated).Return(nil)
+	city := " Morioh "
+
+	// Act
+	response, appError := service.PatchCustomer("1001", dto.CustomerPatchRequest{City: &city})
+
+	// Assert
+	if appError != nil || response.City != "Morioh" || response.Name != "Jotaro Kujo" {
+		t.Error("Failed while patching the customer city")
+	}
+}
+
+func Test_should_not_deactivate_an_inactive_customer(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockCustomerRepository(ctrl)
+	service := NewCustomerService(repo)
+
+	inactive := realdomain.Customer{ID: "1002", Status: realdomain.CustomerStatusInactive}
+	repo.EXPECT().ById("1002").Return(&inactive, nil)
+
+	// Act
+	_, appError := service.DeactivateCustomer("1002")
+
+	// Assert
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Failed while deactivating an inactive customer")
+	}
+}
+
+func Test_should_return_a_next_cursor_when_there_are_more_customers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockCustomerRepository(ctrl)
+	service := NewCustomerService(repo)
+
+	customers := []realdomain.Customer{
+		{ID: "1003", Name: "Joseph Joestar", DateofBirth: "1920-09-27", Status: "1"},
+		{ID: "1001", Name: "Jotaro Kujo", DateofBirth: "1970-01-01", Status: "1"},
+		{ID: "1004", Name: "Josuke Higashikata", DateofBirth: "1983-05-16", Status: "1"},
+	}
+	repo.EXPECT().FindAll(realdomain.CustomerFilter{SortBy: "date_of_birth", Limit: 3}).Return(customers, nil)
+
+	// Act
+	response, appError := service.GetAllCustomer(dto.CustomerSearchRequest{Sort: "date_of_birth", Limit: 2})
+
+	// Assert
+	if appError != nil || len(response.Customers) != 2 || response.Next == "" {
+		t.Fatal("Failed while paginating customers")
+	}
+	after, err := realdomain.ParseCustomerCursor(response.Next, "date_of_birth")
+	if err != nil || after.Id != 1001 || after.Value != "1970-01-01" {
+		t.Error("Failed while matching the next cursor")
+	}
+	if _, err = realdomain.ParseCustomerCursor(response.Next, "name"); err == nil {
+		t.Error("A cursor should only be valid for the sort it was created with")
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"banking/errs"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/service/mockFraudService.go -package=service banking/service FraudService
+type FraudService interface {
+	// Screen : Returns nil when the transaction can be posted, the held transaction when it needs a review,
+	// or an error when it is denied. The role of the requester is kept with a held transaction for its approval.
+	Screen(account domain.Account, t domain.Transaction, role string) (*dto.TransactionResponse, *errs.AppError)
+	// ScreenTransfer : Screens the withdrawal leg of the transfer the same way, a held transfer moves no money
+	// on either account until it is approved
+	ScreenTransfer(account domain.Account, t domain.Transfer, role string) (*dto.TransferResponse, *errs.AppError)
+	GetPendingTransactions() ([]dto.PendingTransactionResponse, *errs.AppError)
+	ApprovePendingTransaction(pendingId string, actor string) (*dto.TransactionResponse, *errs.AppError)
+	RejectPendingTransaction(pendingId string, actor string) (*dto.PendingTransactionResponse, *errs.AppError)
+}
+
+type DefaultFraudService struct {
+	accounts domain.AccountRepository
+	pending  domain.PendingTransactionRepository
+	rules    domain.FraudRules
+	products domain.Products
+}
+
+func (s DefaultFraudService) Screen(account domain.Account, t domain.Transaction, role string) (*dto.TransactionResponse, *errs.AppError) {
+	result, err := s.screen(account, t)
+	if result == nil || err != nil {
+		return nil, err
+	}
+	pending, err := s.pending.Save(domain.NewPendingTransaction(t, *result, role))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TransactionResponse{
+		AccountId:       pending.AccountId,
+		Amount:          pending.Amount,
+		Currency:        pending.Currency,
+		TransactionType: pending.TransactionType,
+		TransactionDate: pending.RequestedAt,
+		Status:          dto.TransactionStatusPending,
+		PendingId:       pending.PendingId,
+	}, nil
+}
+
+func (s DefaultFraudService) ScreenTransfer(account domain.Account, t domain.Transfer, role string) (*dto.TransferResponse, *errs.AppError) {
+	result, err := s.screen(account, t.WithdrawalLeg())
+	if result == nil || err != nil {
+		return nil, err
+	}
+	pending, err := s.pending.Save(domain.NewPendingTransfer(t, *result, role))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TransferResponse{
+		FromAccountId: pending.AccountId,
+		ToAccountId:   pending.ToAccountId.String,
+		Amount:        pending.Amount,
+		Currency:      pending.Currency,
+		FxRate:        pending.FxRate,
+		TransferDate:  pending.RequestedAt,
+		Transactions:  make([]dto.TransactionResponse, 0),
+		Status:        dto.TransactionStatusPending,
+		PendingId:     pending.PendingId,
+	}, nil
+}
+
+// screen : Runs the rules on the transaction, returns the result when it needs a review and an error when it is denied
+func (s DefaultFraudService) screen(account domain.Account, t domain.Transaction) (*domain.ScreeningResult, *errs.AppError) {
+	now := time.Now()
+	history, err := s.accounts.FindTransactionsBetween(account.AccountId, now.Add(-s.rules.Lookback).Format(dbTSLayout), "")
+	if err != nil {
+		return nil, err
+	}
+	result := s.rules.Screen(domain.ScreeningContext{Account: account, Transaction: t, History: history, Now: now})
+	switch result.Decision {
+	case domain.ScreeningDeny:
+		return nil, errs.NewTransactionDeniedError("Transaction denied by fraud screening: " + strings.Join(result.Reasons, "; "))
+	case domain.ScreeningReview:
+		return &result, nil
+	}
+	return nil, nil
+}
+
+// GetPendingTransactions : Transactions waiting for an admin decision, oldest first
+func (s DefaultFraudService) GetPendingTransactions() ([]dto.PendingTransactionResponse, *errs.AppError) {
+	pending, err := s.pending.FindByStatus(domain.PendingStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.PendingTransactionResponse, 0, len(pending))
+	for _, p := range pending {
+		response = append(response, p.ToDto())
+	}
+	return response, nil
+}
+
+// ApprovePendingTransaction : Posts the held transaction, the account must still accept it and a withdrawal must
+// still fit the limits of the requester, counted when the money moves. For a held transfer both accounts must
+// accept it, and the withdrawal leg is returned.
+func (s DefaultFraudService) ApprovePendingTransaction(pendingId string, actor string) (*dto.TransactionResponse, *errs.AppError) {
+	if actor == "" {
+		return nil, errs.NewValidationError("The user approving the transaction is unknown")
+	}
+	p, err := s.pending.FindById(pendingId)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.accounts.FindBy(p.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if err := account.CanPost(p.TransactionType == domain.WITHDRAWAL); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var dailyLimits *domain.DailyLimitCheck
+	if p.TransactionType == domain.WITHDRAWAL {
+		if dailyLimits, err = checkWithdrawalLimits(s.products, *account, p.Amount, p.RequestedRole, now); err != nil {
+			return nil, err
+		}
+	}
+	if p.IsTransfer() {
+		return s.approveTransfer(*p, dailyLimits, actor, now.Format(dbTSLayout))
+	}
+	t := p.Transaction(now.Format(dbTSLayout))
+	t.DailyLimits = dailyLimits
+	transaction, err := s.pending.Approve(*p, t, actor, now.Format(dbTSLayout))
+	if err != nil {
+		return nil, err
+	}
+	response := transaction.ToDto()
+	return &response, nil
+}
+
+func (s DefaultFraudService) approveTransfer(p domain.PendingTransaction, dailyLimits *domain.DailyLimitCheck, actor string, now string) (*dto.TransactionResponse, *errs.AppError) {
+	destination, err := s.accounts.FindBy(p.ToAccountId.String)
+	if err != nil {
+		return nil, err
+	}
+	if err := destination.CanPost(false); err != nil {
+		return nil, err
+	}
+	transferId, idErr := domain.NewTransferId()
+	if idErr != nil {
+		return nil, errs.NewUnexpectedError("Unexpected error while creating the transfer")
+	}
+	t := p.Transfer(transferId, destination.Currency, now)
+	t.DailyLimits = dailyLimits
+	transfer, err := s.pending.ApproveTransfer(p, t, actor, now)
+	if err != nil {
+		return nil, err
+	}
+	response := transfer.Withdrawal.ToDto()
+	return &response, nil
+}
+
+// RejectPendingTransaction : Drops the held transaction, no money moves
+func (s DefaultFraudService) RejectPendingTransaction(pendingId string, actor string) (*dto.PendingTransactionResponse, *errs.AppError) {
+	if actor == "" {
+		return nil, errs.NewValidationError("The user rejecting the transaction is unknown")
+	}
+	p, err := s.pending.FindById(pendingId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Format(dbTSLayout)
+	if err = s.pending.Reject(*p, actor, now); err != nil {
+		return nil, err
+	}
+	p.Status = domain.PendingStatusRejected
+	p.DecidedBy.String, p.DecidedBy.Valid = actor, true
+	p.DecidedAt.String, p.DecidedAt.Valid = now, true
+	response := p.ToDto()
+	return &response, nil
+}
+
+func NewFraudService(accounts domain.AccountRepository, pending domain.PendingTransactionRepository, rules domain.FraudRules,
+	products domain.Products) DefaultFraudService {
+	return DefaultFraudService{accounts, pending, rules, products}
+}
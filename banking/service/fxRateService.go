@@ -0,0 +1,63 @@
+package service
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"banking/errs"
+	"strings"
+)
+
+type FxRateService interface {
+	LoadRates([]dto.FxRateRequest) ([]dto.FxRateResponse, *errs.AppError)
+	GetRates(base string, quote string) ([]dto.FxRateResponse, *errs.AppError)
+}
+
+type DefaultFxRateService struct {
+	repo domain.FxRateRepository
+}
+
+// LoadRates : Validates every rate first, so either the whole batch is stored or none of it
+func (s DefaultFxRateService) LoadRates(requests []dto.FxRateRequest) ([]dto.FxRateResponse, *errs.AppError) {
+	if len(requests) == 0 {
+		return nil, errs.NewValidationError("At least one rate is required")
+	}
+	rates := make([]domain.FxRate, 0, len(requests))
+	for _, req := range requests {
+		req.BaseCurrency = strings.ToUpper(req.BaseCurrency)
+		req.QuoteCurrency = strings.ToUpper(req.QuoteCurrency)
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+		rates = append(rates, domain.FxRate{
+			BaseCurrency:  req.BaseCurrency,
+			QuoteCurrency: req.QuoteCurrency,
+			Rate:          req.Rate,
+			EffectiveDate: req.EffectiveDate,
+		})
+	}
+	if err := s.repo.Save(rates); err != nil {
+		return nil, err
+	}
+	response := make([]dto.FxRateResponse, 0, len(rates))
+	for _, r := range rates {
+		response = append(response, r.ToDto())
+	}
+	return response, nil
+}
+
+// GetRates : Returns the loaded rates, base and quote are optional filters
+func (s DefaultFxRateService) GetRates(base string, quote string) ([]dto.FxRateResponse, *errs.AppError) {
+	rates, err := s.repo.FindAll(strings.ToUpper(base), strings.ToUpper(quote))
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.FxRateResponse, 0, len(rates))
+	for _, r := range rates {
+		response = append(response, r.ToDto())
+	}
+	return response, nil
+}
+
+func NewFxRateService(repo domain.FxRateRepository) DefaultFxRateService {
+	return DefaultFxRateService{repo}
+}
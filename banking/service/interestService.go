@@ -0,0 +1,201 @@
+package service
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"banking/errs"
+	"banking/logger"
+	"banking/money"
+	"database/sql"
+	"time"
+)
+
+const periodLayout = "2006-01"
+
+// InterestService : Accrues daily interest on interest bearing accounts and posts it monthly
+type InterestService interface {
+	Run(asOf time.Time) (*dto.InterestRunResponse, *errs.AppError)
+}
+
+type DefaultInterestService struct {
+	accounts domain.AccountRepository
+	repo     domain.InterestRepository
+	products domain.Products
+}
+
+// Run : Accrues every day up to asOf that was not accrued yet and posts the interest of every completed month.
+// Days and months already handled are skipped, so the run can be repeated or used to backfill.
+func (s DefaultInterestService) Run(asOf time.Time) (*dto.InterestRunResponse, *errs.AppError) {
+	asOf = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	summary := dto.InterestRunResponse{AsOf: asOf.Format(dateLayout), PostedByCurrency: make(map[string]money.Amount)}
+
+	accounts, err := s.repo.FindAccountsByType(s.products.InterestBearing())
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accounts {
+		if err := s.runAccount(a, asOf, &summary); err != nil {
+			logger.Error("Error while running interest for account " + a.AccountId + ": " + err.Message)
+			return nil, err
+		}
+		summary.AccountsProcessed++
+	}
+	return &summary, nil
+}
+
+func (s DefaultInterestService) runAccount(a domain.Account, asOf time.Time, summary *dto.InterestRunResponse) *errs.AppError {
+	product := s.products.For(a.AccountType)
+
+	start, accruedBefore, err := s.firstDayToAccrue(a)
+	if err != nil {
+		return err
+	}
+
+	// months completed by an earlier run that stopped before posting them
+	if accruedBefore {
+		lastComplete := lastCompletePeriod(minDate(asOf, start.AddDate(0, 0, -1)))
+		periods, err := s.repo.UnpostedPeriods(a.AccountId, lastComplete)
+		if err != nil {
+			return err
+		}
+		for _, period := range periods {
+			monthEnd, _ := time.Parse(periodLayout, period)
+			if _, err := s.post(a, period, monthEnd.AddDate(0, 1, -1), summary); err != nil {
+				return err
+			}
+		}
+	}
+	if start.After(asOf) {
+		return nil
+	}
+
+	// the balance at the end of the day before start is the current balance without everything since then
+	account, err := s.accounts.FindBy(a.AccountId)
+	if err != nil {
+		return err
+	}
+	since, err := s.accounts.FindTransactionsBetween(a.AccountId, start.Format(dbTSLayout), "")
+	if err != nil {
+		return err
+	}
+	balance := account.Amount
+	for _, t := range since {
+		balance -= t.SignedAmount()
+	}
+
+	accruals := make([]domain.InterestAccrual, 0)
+	next := 0
+	for day := start; !day.After(asOf); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1).Format(dbTSLayout)
+		for next < len(since) && since[next].TransactionDate < endOfDay {
+			balance += since[next].SignedAmount()
+			next++
+		}
+		accruals = append(accruals, domain.InterestAccrual{
+			AccountId:     a.AccountId,
+			AccrualDate:   day.Format(dateLayout),
+			Period:        day.Format(periodLayout),
+			Balance:       balance,
+			Rate:          product.InterestRate,
+			DayCount:      product.DayCount,
+			AccruedMicros: domain.DailyInterestMicros(balance, product.InterestRate, product.DayCount, day),
+		})
+		summary.DaysAccrued++
+
+		// at the end of the month the interest is posted, and from the next day on it earns interest too
+		if day.AddDate(0, 0, 1).Day() == 1 {
+			if err := s.repo.SaveAccruals(accruals); err != nil {
+				return err
+			}
+			accruals = accruals[:0]
+			posted, err := s.post(*account, day.Format(periodLayout), day, summary)
+			if err != nil {
+				return err
+			}
+			balance += posted
+		}
+	}
+	if len(accruals) > 0 {
+		return s.repo.SaveAccruals(accruals)
+	}
+	return nil
+}
+
+// post : Posts the interest accrued in the period as an interest transaction at the end of its last day
+func (s DefaultInterestService) post(a domain.Account, period string, monthEnd time.Time, summary *dto.InterestRunResponse) (money.Amount, *errs.AppError) {
+	micros, err := s.repo.SumAccruals(a.AccountId, period)
+	if err != nil {
+		return 0, err
+	}
+	amount := domain.MicrosToAmount(micros)
+	if amount <= 0 {
+		return 0, nil
+	}
+	t := domain.Transaction{
+		AccountId:        a.AccountId,
+		Amount:           amount,
+		TransactionType:  domain.INTEREST,
+		TransactionDate:  monthEnd.Add(24*time.Hour - time.Second).Format(dbTSLayout),
+		Currency:         a.Currency,
+		OriginalAmount:   amount,
+		OriginalCurrency: a.Currency,
+		FxRate:           money.OneToOne,
+		Reference:        sql.NullString{String: domain.InterestReference(period), Valid: true},
+	}
+	// a run overlapping this one may have posted the month in the meantime, its posting holds the same interest
+	if _, err := s.accounts.SaveTransaction(t); err != nil && err.ErrorCode == errs.ErrCodeAlreadyPosted {
+		return amount, nil
+	} else if err != nil {
+		return 0, err
+	}
+	summary.Postings++
+	summary.PostedByCurrency[a.Currency] += amount
+	return amount, nil
+}
+
+// firstDayToAccrue : The day after the last accrual, or the opening day for accounts never accrued
+func (s DefaultInterestService) firstDayToAccrue(a domain.Account) (time.Time, bool, *errs.AppError) {
+	last, err := s.repo.LastAccrualDate(a.AccountId)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if last != "" {
+		day, parseErr := parseDatePrefix(last)
+		if parseErr != nil {
+			return time.Time{}, false, errs.NewUnexpectedError("Invalid accrual date " + last)
+		}
+		return day.AddDate(0, 0, 1), true, nil
+	}
+	day, parseErr := parseDatePrefix(a.OpeningDate)
+	if parseErr != nil {
+		return time.Time{}, false, errs.NewUnexpectedError("Invalid opening date for account " + a.AccountId)
+	}
+	return day, false, nil
+}
+
+// parseDatePrefix : Parses the YYYY-MM-DD part of a date or timestamp
+func parseDatePrefix(value string) (time.Time, error) {
+	if len(value) > len(dateLayout) {
+		value = value[:len(dateLayout)]
+	}
+	return time.Parse(dateLayout, value)
+}
+
+// lastCompletePeriod : The last month that is fully over on the day
+func lastCompletePeriod(day time.Time) string {
+	if day.AddDate(0, 0, 1).Day() == 1 {
+		return day.Format(periodLayout)
+	}
+	return time.Date(day.Year(), day.Month(), 0, 0, 0, 0, 0, time.UTC).Format(periodLayout)
+}
+
+func minDate(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func NewInterestService(accounts domain.AccountRepository, repo domain.InterestRepository, products domain.Products) DefaultInterestService {
+	return DefaultInterestService{accounts, repo, products}
+}
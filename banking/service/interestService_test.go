@@ -0,0 +1,73 @@
+package service
+
+import (
+	realdomain "banking/domain"
+	"banking/errs"
+	"banking/mocks/domain"
+	"banking/money"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_should_accrue_daily_and_post_the_interest_of_a_completed_month(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	accounts := domain.NewMockAccountRepository(ctrl)
+	interest := domain.NewMockInterestRepository(ctrl)
+	service := NewInterestService(accounts, interest, realdomain.DefaultProducts())
+
+	account := realdomain.Account{AccountId: "95470", AccountType: "savings", Currency: "USD",
+		OpeningDate: "2021-01-30T10:00:00", Amount: money.FromUnits(100000)}
+	interest.EXPECT().FindAccountsByType([]string{"savings"}).Return([]realdomain.Account{account}, nil)
+	interest.EXPECT().LastAccrualDate("95470").Return("", nil)
+	accounts.EXPECT().FindBy("95470").Return(&account, nil)
+	accounts.EXPECT().FindTransactionsBetween("95470", "2021-01-30 00:00:00", "").Return(nil, nil)
+	interest.EXPECT().SaveAccruals(gomock.Len(2)).Return(nil)
+	interest.EXPECT().SumAccruals("95470", "2021-01").Return(int64(2*5479452), nil)
+	accounts.EXPECT().SaveTransaction(gomock.Any()).DoAndReturn(func(tr realdomain.Transaction) (*realdomain.Transaction, *errs.AppError) {
+		if tr.TransactionType != realdomain.INTEREST || tr.Amount != money.MustParse("10.96") {
+			t.Errorf("Unexpected interest transaction %v %v", tr.TransactionType, tr.Amount)
+		}
+		if tr.Reference.String != "interest:2021-01" || tr.TransactionDate != "2021-01-31 23:59:59" {
+			t.Errorf("Unexpected interest posting %v %v", tr.Reference.String, tr.TransactionDate)
+		}
+		return &tr, nil
+	})
+	interest.EXPECT().SaveAccruals(gomock.Len(1)).Return(nil)
+
+	// Act
+	summary, appError := service.Run(time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	if appError != nil {
+		t.Fatal("Test failed while running interest")
+	}
+	if summary.DaysAccrued != 3 || summary.Postings != 1 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+}
+
+func Test_should_not_accrue_or_post_again_when_re_run_for_the_same_date(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	accounts := domain.NewMockAccountRepository(ctrl)
+	interest := domain.NewMockInterestRepository(ctrl)
+	service := NewInterestService(accounts, interest, realdomain.DefaultProducts())
+
+	account := realdomain.Account{AccountId: "95470", AccountType: "savings", Currency: "USD", OpeningDate: "2021-01-30T10:00:00"}
+	interest.EXPECT().FindAccountsByType(gomock.Any()).Return([]realdomain.Account{account}, nil)
+	interest.EXPECT().LastAccrualDate("95470").Return("2021-02-01", nil)
+	interest.EXPECT().UnpostedPeriods("95470", "2021-01").Return([]string{}, nil)
+
+	// Act
+	summary, appError := service.Run(time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	if appError != nil || summary.DaysAccrued != 0 || summary.Postings != 0 {
+		t.Error("A re-run for the same date should not accrue or post anything")
+	}
+}
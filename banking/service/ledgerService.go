@@ -0,0 +1,62 @@
+package service
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"banking/errs"
+)
+
+type LedgerService interface {
+	GetTrialBalance() (*dto.TrialBalanceResponse, *errs.AppError)
+}
+
+type DefaultLedgerService struct {
+	repo domain.LedgerRepository
+}
+
+// GetTrialBalance : Proves the books add up, every currency nets to zero and every account balance
+// is the sum of its journal lines
+func (s DefaultLedgerService) GetTrialBalance() (*dto.TrialBalanceResponse, *errs.AppError) {
+	balances, err := s.repo.TrialBalance()
+	if err != nil {
+		return nil, err
+	}
+	mismatches, err := s.repo.FindMismatches()
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.TrialBalanceResponse{
+		Accounts:   make([]dto.LedgerBalanceResponse, 0, len(balances)),
+		Mismatches: make([]dto.BalanceMismatchResponse, 0, len(mismatches)),
+	}
+	totals := make(map[string]int64)
+	for _, b := range balances {
+		response.Accounts = append(response.Accounts, dto.LedgerBalanceResponse{
+			LedgerAccount: b.LedgerAccount,
+			Currency:      b.Currency,
+			Debits:        b.Debits,
+			Credits:       b.Credits,
+			Balance:       b.Balance(),
+		})
+		totals[b.Currency] += int64(b.Balance())
+	}
+	for _, m := range mismatches {
+		response.Mismatches = append(response.Mismatches, dto.BalanceMismatchResponse{
+			AccountId:     m.AccountId,
+			StoredBalance: m.StoredBalance,
+			LedgerBalance: m.LedgerBalance,
+		})
+	}
+	response.Balanced = len(mismatches) == 0
+	for _, total := range totals {
+		if total != 0 {
+			response.Balanced = false
+		}
+	}
+	return &response, nil
+}
+
+func NewLedgerService(repo domain.LedgerRepository) DefaultLedgerService {
+	return DefaultLedgerService{repo}
+}
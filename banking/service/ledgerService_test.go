@@ -0,0 +1,35 @@
+package service
+
+import (
+	realdomain "banking/domain"
+	"banking/mocks/domain"
+	"banking/money"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_should_report_the_books_as_balanced_when_every_currency_nets_to_zero(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := domain.NewMockLedgerRepository(ctrl)
+	service := NewLedgerService(repo)
+
+	repo.EXPECT().TrialBalance().Return([]realdomain.LedgerBalance{
+		{LedgerAccount: realdomain.CustomerLedgerAccount("95470"), Currency: "USD", Debits: money.FromUnits(50), Credits: money.FromUnits(150)},
+		{LedgerAccount: realdomain.LedgerCash, Currency: "USD", Debits: money.FromUnits(150), Credits: money.FromUnits(50)},
+	}, nil)
+	repo.EXPECT().FindMismatches().Return([]realdomain.BalanceMismatch{}, nil)
+
+	// Act
+	response, appError := service.GetTrialBalance()
+
+	// Assert
+	if appError != nil || !response.Balanced || len(response.Accounts) != 2 {
+		t.Error("Failed while checking a balanced trial balance")
+	}
+	if response.Accounts[0].Balance != money.FromUnits(100) {
+		t.Errorf("Failed while matching the customer ledger balance, got %v", response.Accounts[0].Balance)
+	}
+}
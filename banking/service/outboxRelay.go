@@ -0,0 +1,76 @@
+package service
+
+import (
+	"banking/domain"
+	"banking/errs"
+	"banking/logger"
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// outboxBatchSize : Events read from the outbox per run
+const outboxBatchSize = 100
+
+// OutboxRelay : Delivers the events written to the outbox to a publisher
+type OutboxRelay struct {
+	repo        domain.OutboxRepository
+	publisher   domain.EventPublisher
+	maxAttempts int
+}
+
+// RunOnce : Publishes the pending events in order and returns how many were delivered. A failed delivery is
+// recorded and the rest of the batch goes on, only the later events of the same account wait for the next run so
+// subscribers never see an account's event before the ones written earlier. An event that fails maxAttempts times
+// is dead lettered and no longer holds its account back.
+func (r OutboxRelay) RunOnce() (int, *errs.AppError) {
+	events, err := r.repo.FindUnpublished(outboxBatchSize, r.maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+	published := 0
+	blocked := make(map[string]bool)
+	for _, e := range events {
+		if blocked[e.AggregateId] {
+			continue
+		}
+		if pubErr := r.publisher.Publish(e); pubErr != nil {
+			blocked[e.AggregateId] = true
+			logger.Error("Error while publishing event "+e.EventId+": "+pubErr.Error(),
+				zap.String("event_type", e.EventType), zap.Int("attempt", e.Attempts+1))
+			if err := r.repo.RecordFailure(e.EventId, pubErr.Error()); err != nil {
+				return published, err
+			}
+			if e.Attempts+1 >= r.maxAttempts {
+				logger.Error("Event "+e.EventId+" dead lettered after "+strconv.Itoa(r.maxAttempts)+" attempts",
+					zap.String("event_type", e.EventType), zap.String("aggregate_id", e.AggregateId))
+			}
+			continue
+		}
+		if err := r.repo.MarkPublished(e.EventId, time.Now().Format(dbTSLayout)); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// Run : Runs the relay every interval until the context is cancelled, a run in progress is finished first
+func (r OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.RunOnce()
+		}
+	}
+}
+
+func NewOutboxRelay(repo domain.OutboxRepository, publisher domain.EventPublisher, maxAttempts int) OutboxRelay {
+	return OutboxRelay{repo, publisher, maxAttempts}
+}
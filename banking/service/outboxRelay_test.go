@@ -0,0 +1,85 @@
+package service
+
+import (
+	realdomain "banking/domain"
+	"banking/mocks/domain"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_should_publish_the_outbox_events_in_order_and_mark_them(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOutbox := domain.NewMockOutboxRepository(ctrl)
+	publisher := &realdomain.InMemoryPublisher{}
+	relay := NewOutboxRelay(mockOutbox, publisher, 3)
+
+	events := []realdomain.Event{
+		{EventId: "1", EventType: realdomain.EventAccountOpened, AggregateId: "2000"},
+		{EventId: "2", EventType: realdomain.EventTransactionPosted, AggregateId: "2000"},
+	}
+	mockOutbox.EXPECT().FindUnpublished(gomock.Any(), 3).Return(events, nil)
+	gomock.InOrder(
+		mockOutbox.EXPECT().MarkPublished("1", gomock.Any()).Return(nil),
+		mockOutbox.EXPECT().MarkPublished("2", gomock.Any()).Return(nil),
+	)
+	// Act
+	published, appError := relay.RunOnce()
+
+	// Assert
+	delivered := publisher.Events()
+	if appError != nil || published != 2 || len(delivered) != 2 || delivered[0].EventId != "1" || delivered[1].EventId != "2" {
+		t.Error("Test failed while relaying the outbox events")
+	}
+}
+
+func Test_should_go_on_with_the_batch_past_an_event_that_cannot_be_published(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOutbox := domain.NewMockOutboxRepository(ctrl)
+	mockPublisher := domain.NewMockEventPublisher(ctrl)
+	relay := NewOutboxRelay(mockOutbox, mockPublisher, 3)
+
+	events := []realdomain.Event{{EventId: "1", AggregateId: "2000"}, {EventId: "2", AggregateId: "2000"}, {EventId: "3", AggregateId: "2001"}}
+	mockOutbox.EXPECT().FindUnpublished(gomock.Any(), 3).Return(events, nil)
+	mockPublisher.EXPECT().Publish(events[0]).Return(errors.New("broker unavailable"))
+	mockOutbox.EXPECT().RecordFailure("1", "broker unavailable").Return(nil)
+	mockPublisher.EXPECT().Publish(events[2]).Return(nil)
+	mockOutbox.EXPECT().MarkPublished("3", gomock.Any()).Return(nil)
+	// Act
+	published, appError := relay.RunOnce()
+
+	// Assert
+	if appError != nil || published != 1 {
+		t.Error("Test failed while relaying past a failed delivery")
+	}
+}
+
+func Test_should_stop_retrying_an_event_after_the_max_attempts(t *testing.T) {
+	// Arrange
+	store := realdomain.NewMemoryStore()
+	accounts := realdomain.NewAccountRepositoryStub(store)
+	outbox := realdomain.NewOutboxRepositoryStub(store)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPublisher := domain.NewMockEventPublisher(ctrl)
+	relay := NewOutboxRelay(outbox, mockPublisher, 2)
+	if _, appError := accounts.Save(realdomain.Account{CustomerId: "2000", AccountType: "saving", Currency: "USD"}); appError != nil {
+		t.Fatal(appError.Message)
+	}
+	mockPublisher.EXPECT().Publish(gomock.Any()).Return(errors.New("rejected by the broker")).Times(2)
+	// Act
+	relay.RunOnce()
+	relay.RunOnce()
+	published, appError := relay.RunOnce()
+
+	// Assert
+	pending, _ := outbox.FindUnpublished(10, 2)
+	if appError != nil || published != 0 || len(pending) != 0 {
+		t.Error("Test failed while dead lettering an event")
+	}
+}
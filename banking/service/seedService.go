@@ -0,0 +1,192 @@
+package service
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"banking/errs"
+	"banking/money"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultSeedPassword : Password of every generated login when none is given
+const DefaultSeedPassword = "abc123"
+
+var seedFirstNames = []string{
+	"Aisha", "Akira", "Amara", "Anders", "Beatriz", "Carlos", "Chen", "Chloe", "Dmitri", "Elena", "Emeka", "Farah",
+	"Giulia", "Hana", "Hugo", "Ines", "Jonas", "Kavya", "Lars", "Leila", "Lucas", "Maya", "Mateo", "Nadia",
+	"Noah", "Olga", "Omar", "Priya", "Rafael", "Sofia", "Tariq", "Yuki",
+}
+
+var seedLastNames = []string{
+	"Almeida", "Andersen", "Brown", "Costa", "Dubois", "Fischer", "Garcia", "Hansen", "Ivanova", "Jensen", "Kim",
+	"Kowalski", "Lopez", "Martin", "Mensah", "Moreau", "Nakamura", "Novak", "Okafor", "Patel", "Rossi", "Schmidt",
+	"Silva", "Smith", "Tanaka", "Wang",
+}
+
+// seedCity : A city and the first digits of its zipcodes
+type seedCity struct {
+	name          string
+	zipcodePrefix string
+}
+
+var seedCities = []seedCity{
+	{"Austin", "787"}, {"Boston", "021"}, {"Chicago", "606"}, {"Denver", "802"}, {"Houston", "770"},
+	{"Miami", "331"}, {"New York", "100"}, {"Portland", "972"}, {"San Diego", "921"}, {"Seattle", "981"},
+}
+
+// SeedOptions : What the generator creates, the same options and seed always generate the same data
+type SeedOptions struct {
+	Seed      int64
+	Customers int
+	// Savings and Checking : Accounts of each type every customer gets
+	Savings  int
+	Checking int
+	// Transactions : Deposits and withdrawals per account, dated between the opening and To
+	Transactions int
+	From         time.Time
+	To           time.Time
+	// Password : Of the login created for every customer
+	Password string
+}
+
+func (o SeedOptions) validate() *errs.AppError {
+	if o.Customers < 1 || o.Savings < 0 || o.Checking < 0 || o.Transactions < 0 {
+		return errs.NewValidationError("Customers should be at least 1 and the account and transaction counts at least 0")
+	}
+	if o.To.Sub(o.From) < 24*time.Hour {
+		return errs.NewValidationError("The date range should span at least a day")
+	}
+	return nil
+}
+
+// SeedService : Fills the storage with synthetic customers, accounts, transaction history and logins
+type SeedService interface {
+	Run(options SeedOptions) (*dto.SeedResponse, *errs.AppError)
+}
+
+// DefaultSeedService : Writes through the repositories, so it seeds any storage adapter
+type DefaultSeedService struct {
+	customers domain.CustomerRepository
+	accounts  domain.AccountRepository
+	users     domain.UserRepository
+	products  domain.Products
+}
+
+func (s DefaultSeedService) Run(o SeedOptions) (*dto.SeedResponse, *errs.AppError) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	if o.Password == "" {
+		o.Password = DefaultSeedPassword
+	}
+	// every random choice comes from this source, in a fixed order
+	rng := rand.New(rand.NewSource(o.Seed))
+	summary := dto.SeedResponse{Seed: o.Seed}
+
+	for i := 0; i < o.Customers; i++ {
+		first := seedFirstNames[rng.Intn(len(seedFirstNames))]
+		city := seedCities[rng.Intn(len(seedCities))]
+		c, err := s.customers.Save(domain.Customer{
+			Name:        first + " " + seedLastNames[rng.Intn(len(seedLastNames))],
+			City:        city.name,
+			Zipcode:     fmt.Sprintf("%s%02d", city.zipcodePrefix, rng.Intn(100)),
+			DateofBirth: time.Date(1940+rng.Intn(65), time.Month(1+rng.Intn(12)), 1+rng.Intn(28), 0, 0, 0, 0, time.UTC).Format(dateLayout),
+			Status:      domain.CustomerStatusActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary.Customers++
+
+		accountTypes := make([]string, 0, o.Savings+o.Checking)
+		for j := 0; j < o.Savings; j++ {
+			accountTypes = append(accountTypes, "savings")
+		}
+		for j := 0; j < o.Checking; j++ {
+			accountTypes = append(accountTypes, "checking")
+		}
+		for _, accountType := range accountTypes {
+			transactions, err := s.seedAccount(rng, c.ID, accountType, o)
+			if err != nil {
+				return nil, err
+			}
+			summary.Accounts++
+			summary.Transactions += transactions
+		}
+
+		err = s.users.Save(domain.User{
+			Username:   strings.ToLower(first) + c.ID,
+			Password:   o.Password,
+			Role:       domain.RoleUser,
+			CustomerId: sql.NullString{String: c.ID, Valid: true},
+			CreatedOn:  o.From.Format(dbTSLayout),
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary.Users++
+	}
+	return &summary, nil
+}
+
+// seedAccount : Opens the account early in the range and posts its history in date order. Withdrawals never take
+// more than the balance, so none of them is refused.
+func (s DefaultSeedService) seedAccount(rng *rand.Rand, customerId string, accountType string, o SeedOptions) (int, *errs.AppError) {
+	span := int64(o.To.Sub(o.From) / time.Second)
+	opening := o.From.Add(time.Duration(rng.Int63n(span/10+1)) * time.Second)
+	balance := money.Amount(10000 + rng.Int63n(990000))
+	a, err := s.accounts.Save(domain.Account{
+		CustomerId:     customerId,
+		OpeningDate:    opening.Format(dbTSLayout),
+		AccountType:    accountType,
+		Currency:       money.DefaultCurrency,
+		Amount:         balance,
+		Status:         domain.AccountStatusActive,
+		OverdraftLimit: s.products.For(accountType).OverdraftLimit,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := int64(o.To.Sub(opening) / time.Second)
+	offsets := make([]int64, o.Transactions)
+	for i := range offsets {
+		offsets[i] = 1 + rng.Int63n(remaining)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	for _, offset := range offsets {
+		t := domain.Transaction{
+			AccountId:       a.AccountId,
+			TransactionType: domain.DEPOSIT,
+			TransactionDate: opening.Add(time.Duration(offset) * time.Second).Format(dbTSLayout),
+			Currency:        a.Currency,
+			FxRate:          money.OneToOne,
+		}
+		if balance > 0 && rng.Intn(100) < 45 {
+			t.TransactionType = domain.WITHDRAWAL
+			t.Amount = money.Amount(1 + rng.Int63n(int64(balance)))
+			balance -= t.Amount
+		} else {
+			t.Amount = money.Amount(100 + rng.Int63n(200000))
+			balance += t.Amount
+		}
+		t.OriginalAmount = t.Amount
+		t.OriginalCurrency = t.Currency
+		if _, err := s.accounts.SaveTransaction(t); err != nil {
+			return 0, err
+		}
+	}
+	return len(offsets), nil
+}
+
+// NewSeedService : Returns the generator writing to the given repositories
+func NewSeedService(customers domain.CustomerRepository, accounts domain.AccountRepository, users domain.UserRepository,
+	products domain.Products) DefaultSeedService {
+	return DefaultSeedService{customers, accounts, users, products}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	realdomain "banking/domain"
+	"banking/errs"
+	"banking/mocks/domain"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func seedOptions(seed int64) SeedOptions {
+	return SeedOptions{Seed: seed, Customers: 3, Savings: 1, Checking: 1, Transactions: 15,
+		From: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC)}
+}
+
+// recordSeed : Runs the generator against mocks and returns everything it saved, in order
+func recordSeed(t *testing.T, options SeedOptions) []interface{} {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	customers := domain.NewMockCustomerRepository(ctrl)
+	accounts := domain.NewMockAccountRepository(ctrl)
+	users := domain.NewMockUserRepository(ctrl)
+	saved := make([]interface{}, 0)
+	customers.EXPECT().Save(gomock.Any()).AnyTimes().DoAndReturn(func(c realdomain.Customer) (*realdomain.Customer, *errs.AppError) {
+		saved = append(saved, c)
+		c.ID = strconv.Itoa(2000 + len(saved))
+		return &c, nil
+	})
+	accounts.EXPECT().Save(gomock.Any()).AnyTimes().DoAndReturn(func(a realdomain.Account) (*realdomain.Account, *errs.AppError) {
+		saved = append(saved, a)
+		a.AccountId = strconv.Itoa(95470 + len(saved))
+		return &a, nil
+	})
+	accounts.EXPECT().SaveTransaction(gomock.Any()).AnyTimes().DoAndReturn(func(tr realdomain.Transaction) (*realdomain.Transaction, *errs.AppError) {
+		saved = append(saved, tr)
+		return &tr, nil
+	})
+	users.EXPECT().Save(gomock.Any()).AnyTimes().DoAndReturn(func(u realdomain.User) *errs.AppError {
+		saved = append(saved, u)
+		return nil
+	})
+
+	if _, err := NewSeedService(customers, accounts, users, realdomain.DefaultProducts()).Run(options); err != nil {
+		t.Fatal(err.Message)
+	}
+	return saved
+}
+
+func Test_should_generate_the_same_data_for_the_same_seed(t *testing.T) {
+	first := recordSeed(t, seedOptions(42))
+	second := recordSeed(t, seedOptions(42))
+	other := recordSeed(t, seedOptions(43))
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Two runs with the same seed should save the same data")
+	}
+	if reflect.DeepEqual(first, other) {
+		t.Error("Another seed should generate other data")
+	}
+}
+
+func Test_should_seed_customers_accounts_history_and_logins(t *testing.T) {
+	store := realdomain.NewMemoryStore()
+	service := NewSeedService(realdomain.NewCustomerRepositoryStub(store), realdomain.NewAccountRepositoryStub(store),
+		realdomain.NewUserRepositoryStub(store), realdomain.DefaultProducts())
+
+	summary, err := service.Run(seedOptions(7))
+
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if summary.Customers != 3 || summary.Accounts != 6 || summary.Transactions != 90 || summary.Users != 3 {
+		t.Error("Invalid seed summary: ", summary)
+	}
+	mismatches, _ := realdomain.NewLedgerRepositoryStub(store).FindMismatches()
+	if len(mismatches) != 0 {
+		t.Error("Seeded balances should match the ledger: ", mismatches)
+	}
+}
+
+func Test_should_refuse_an_empty_date_range(t *testing.T) {
+	options := seedOptions(1)
+	options.To = options.From
+
+	_, err := NewSeedService(nil, nil, nil, realdomain.DefaultProducts()).Run(options)
+
+	if err == nil || err.Code != 422 {
+		t.Error("An empty date range should be refused")
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"banking/errs"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errNonPublicAddress : The webhook host resolved to an address of our own network
+var errNonPublicAddress = errors.New("webhook host is not a public address")
+
+// nonPublicNetworks : Ranges a webhook may not reach besides loopback, link-local, multicast and unspecified,
+// the private ranges of RFC 1918 and RFC 4193 plus carrier-grade NAT and the benchmarking range
+var nonPublicNetworks = parseNetworks("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "198.18.0.0/15", "fc00::/7")
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// publicIP : Whether the address is outside of the loopback, private, link-local and unspecified ranges
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateWebhookHost : Refuses the hosts that obviously point at our own network when the webhook is created, names
+// resolving to one are only caught by the client when it connects
+func validateWebhookHost(rawUrl string) *errs.AppError {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return errs.NewValidationError("Webhook url should be an absolute https url")
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errs.NewValidationError("Webhook url should point to a public host")
+	}
+	if ip := net.ParseIP(host); ip != nil && !publicIP(ip) {
+		return errs.NewValidationError("Webhook url should point to a public host")
+	}
+	return nil
+}
+
+// NewWebhookClient : HTTP client of the webhook dispatcher. The address is checked when the connection is dialed,
+// after DNS resolution, so a host cannot be pointed at our own network once the webhook exists. Proxies from the
+// environment are ignored for the same reason and redirects are answered as failed deliveries.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return errNonPublicAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// deliveryError : What the customer sees of a failed delivery in the delivery log, the transport error itself can
+// name our own hosts and addresses so it is only logged
+func deliveryError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errNonPublicAddress):
+		return errNonPublicAddress
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return errors.New("receiver did not answer in time")
+	default:
+		return errors.New("receiver could not be reached")
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_should_only_count_public_addresses_as_public(t *testing.T) {
+	for address, expected := range map[string]bool{
+		"93.184.216.34": true, "2606:2800:220:1::1": true,
+		"127.0.0.1": false, "10.1.2.3": false, "172.16.0.1": false, "192.168.1.1": false, "169.254.169.254": false,
+		"100.64.0.1": false, "0.0.0.0": false, "::1": false, "fe80::1": false, "fd00::1": false, "::ffff:127.0.0.1": false,
+	} {
+		if publicIP(net.ParseIP(address)) != expected {
+			t.Errorf("publicIP(%s) should be %v", address, expected)
+		}
+	}
+}
+
+func Test_should_not_connect_the_webhook_client_to_our_own_network(t *testing.T) {
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("The receiver on the loopback address should not be reached")
+	}))
+	defer receiver.Close()
+
+	_, err := NewWebhookClient(time.Second).Post(receiver.URL, "application/json", nil)
+
+	if err == nil || deliveryError(err) != errNonPublicAddress {
+		t.Errorf("Expected the loopback address to be refused, got %v", err)
+	}
+	if strings.Contains(deliveryError(err).Error(), "127.0.0.1") {
+		t.Error("The delivery error shown to the customer should not name the address")
+	}
+}
+
+func Test_should_not_follow_redirects_of_the_receiver(t *testing.T) {
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hooks" {
+			t.Error("The redirect should not be followed")
+		}
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer receiver.Close()
+	client := NewWebhookClient(time.Second)
+	client.Transport = receiver.Client().Transport
+
+	resp, err := client.Post(receiver.URL+"/hooks", "application/json", nil)
+
+	if err != nil || resp.StatusCode != http.StatusFound {
+		t.Errorf("Expected the redirect to be answered as is, got %v", err)
+	}
+}
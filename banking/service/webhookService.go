@@ -0,0 +1,228 @@
+package service
+
+import (
+	"banking/domain"
+	"banking/dto"
+	"banking/errs"
+	"banking/logger"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// webhookBatchSize : Deliveries attempted per dispatcher run
+const webhookBatchSize = 50
+
+//go:generate mockgen -destination=../mocks/service/mockWebhookService.go -package=service banking/service WebhookService
+type WebhookService interface {
+	NewWebhook(request dto.WebhookRequest) (*dto.WebhookResponse, *errs.AppError)
+	GetWebhooks(customerId string) ([]dto.WebhookResponse, *errs.AppError)
+	DeleteWebhook(customerId string, webhookId string) *errs.AppError
+	// GetDeliveries : Delivery log of a webhook, newest first
+	GetDeliveries(customerId string, webhookId string) ([]dto.WebhookDeliveryResponse, *errs.AppError)
+}
+
+type DefaultWebhookService struct {
+	repo domain.WebhookRepository
+}
+
+func (s DefaultWebhookService) NewWebhook(req dto.WebhookRequest) (*dto.WebhookResponse, *errs.AppError) {
+	if err := req.Validate(domain.WebhookEventTypes); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookHost(req.Url); err != nil {
+		return nil, err
+	}
+	secret, err := domain.NewWebhookSecret()
+	if err != nil {
+		logger.Error("Error while generating webhook secret: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected error while creating the webhook")
+	}
+	subscription, appErr := s.repo.SaveSubscription(domain.WebhookSubscription{
+		CustomerId: req.CustomerId,
+		Url:        req.Url,
+		EventTypes: strings.Join(req.EventTypes, ","),
+		Secret:     secret,
+		CreatedAt:  time.Now().Format(dbTSLayout),
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	response := subscription.ToDto()
+	response.Secret = subscription.Secret
+	return &response, nil
+}
+
+func (s DefaultWebhookService) GetWebhooks(customerId string) ([]dto.WebhookResponse, *errs.AppError) {
+	subscriptions, err := s.repo.FindSubscriptions(customerId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		response = append(response, subscription.ToDto())
+	}
+	return response, nil
+}
+
+func (s DefaultWebhookService) DeleteWebhook(customerId string, webhookId string) *errs.AppError {
+	if _, err := s.findCustomerWebhook(customerId, webhookId); err != nil {
+		return err
+	}
+	return s.repo.DeleteSubscription(webhookId)
+}
+
+func (s DefaultWebhookService) GetDeliveries(customerId string, webhookId string) ([]dto.WebhookDeliveryResponse, *errs.AppError) {
+	if _, err := s.findCustomerWebhook(customerId, webhookId); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.repo.FindDeliveries(webhookId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		response = append(response, d.ToDto())
+	}
+	return response, nil
+}
+
+// findCustomerWebhook : Webhooks of other customers are reported as not found
+func (s DefaultWebhookService) findCustomerWebhook(customerId string, webhookId string) (*domain.WebhookSubscription, *errs.AppError) {
+	subscription, err := s.repo.FindSubscription(webhookId)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.CustomerId != customerId {
+		return nil, errs.NewNotFoundError("Webhook not found")
+	}
+	return subscription, nil
+}
+
+func NewWebhookService(repo domain.WebhookRepository) DefaultWebhookService {
+	return DefaultWebhookService{repo}
+}
+
+// WebhookPublisher : Outbox publisher queueing a delivery of each event for every webhook of the account owner
+type WebhookPublisher struct {
+	accounts domain.AccountRepository
+	repo     domain.WebhookRepository
+}
+
+func (p WebhookPublisher) Publish(e domain.Event) error {
+	account, appErr := p.accounts.FindBy(e.AggregateId)
+	if appErr != nil {
+		return errors.New(appErr.Message)
+	}
+	subscribers, appErr := p.repo.FindSubscribers(account.CustomerId, e.EventType)
+	if appErr != nil {
+		return errors.New(appErr.Message)
+	}
+	if len(subscribers) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(e.ToDto())
+	if err != nil {
+		return err
+	}
+	now := time.Now().Format(dbTSLayout)
+	deliveries := make([]domain.WebhookDelivery, 0, len(subscribers))
+	for _, s := range subscribers {
+		deliveries = append(deliveries, s.NewDelivery(e, payload, now))
+	}
+	if appErr = p.repo.SaveDeliveries(deliveries); appErr != nil {
+		return errors.New(appErr.Message)
+	}
+	return nil
+}
+
+func NewWebhookPublisher(accounts domain.AccountRepository, repo domain.WebhookRepository) WebhookPublisher {
+	return WebhookPublisher{accounts, repo}
+}
+
+// WebhookDispatcher : Sends the due deliveries, retrying failures with exponential backoff until they are delivered
+// or dead lettered after maxAttempts
+type WebhookDispatcher struct {
+	repo        domain.WebhookRepository
+	client      *http.Client
+	backoff     time.Duration
+	maxAttempts int
+}
+
+// RunOnce : Attempts every due delivery once and returns how many were delivered
+func (d WebhookDispatcher) RunOnce() (int, *errs.AppError) {
+	deliveries, err := d.repo.FindDueDeliveries(time.Now().Format(dbTSLayout), webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	for _, w := range deliveries {
+		statusCode, sendErr := d.send(w)
+		now := time.Now()
+		if sendErr != nil {
+			w.Failed(statusCode, sendErr.Error(), now, dbTSLayout, d.backoff, d.maxAttempts)
+			if w.Status == domain.DeliveryStatusDead {
+				logger.Error("Webhook delivery " + w.DeliveryId + " dead lettered after " + strconv.Itoa(w.Attempts) + " attempts: " + sendErr.Error())
+			}
+		} else {
+			w.Delivered(statusCode, now, dbTSLayout)
+			delivered++
+		}
+		if err := d.repo.UpdateDelivery(w); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// send : Posts the event to the webhook url, any status other than 2xx is a failed attempt. The error is shown to
+// the customer in the delivery log.
+func (d WebhookDispatcher) send(w domain.WebhookDelivery) (int, error) {
+	if !strings.HasPrefix(w.Url, "https://") {
+		return 0, errors.New("webhook url is not https")
+	}
+	req, err := http.NewRequest(http.MethodPost, w.Url, bytes.NewReader(w.Payload))
+	if err != nil {
+		return 0, errors.New("webhook url is invalid")
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", w.SubscriptionId)
+	req.Header.Set("X-Webhook-Delivery", w.DeliveryId)
+	req.Header.Set("X-Webhook-Event", w.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", w.Signature(timestamp))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		logger.Info("Webhook delivery " + w.DeliveryId + " failed: " + err.Error())
+		return 0, deliveryError(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.New("receiver answered " + strconv.Itoa(resp.StatusCode))
+	}
+	return resp.StatusCode, nil
+}
+
+// Run : Runs the dispatcher every interval until the context is cancelled, a run in progress is finished first
+func (d WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.RunOnce()
+		}
+	}
+}
+
+func NewWebhookDispatcher(repo domain.WebhookRepository, client *http.Client, backoff time.Duration, maxAttempts int) WebhookDispatcher {
+	return WebhookDispatcher{repo, client, backoff, maxAttempts}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	realdomain "banking/domain"
+	"banking/dto"
+	"banking/errs"
+	"banking/mocks/domain"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_should_deliver_a_signed_event_to_the_customer_webhook(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAccounts := domain.NewMockAccountRepository(ctrl)
+	mockWebhooks := domain.NewMockWebhookRepository(ctrl)
+
+	received := make(chan *http.Request, 1)
+	var body []byte
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	subscription := realdomain.WebhookSubscription{SubscriptionId: "3", CustomerId: "100", Url: receiver.URL,
+		EventTypes: realdomain.EventTransactionPosted, Secret: "s3cret"}
+	event := realdomain.Event{EventId: "77", EventType: realdomain.EventTransactionPosted, AggregateId: "2000",
+		Payload: json.RawMessage(`{"transaction_id":"512"}`), OccurredAt: "2021-01-31 10:00:00"}
+
+	var queued []realdomain.WebhookDelivery
+	mockAccounts.EXPECT().FindBy("2000").Return(&realdomain.Account{AccountId: "2000", CustomerId: "100"}, nil)
+	mockWebhooks.EXPECT().FindSubscribers("100", realdomain.EventTransactionPosted).Return([]realdomain.WebhookSubscription{subscription}, nil)
+	mockWebhooks.EXPECT().SaveDeliveries(gomock.Any()).DoAndReturn(func(d []realdomain.WebhookDelivery) *errs.AppError {
+		queued = d
+		return nil
+	})
+	mockWebhooks.EXPECT().FindDueDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(func(now string, limit int) ([]realdomain.WebhookDelivery, *errs.AppError) {
+		due := queued[0]
+		due.DeliveryId, due.Url, due.Secret = "9", subscription.Url, subscription.Secret
+		return []realdomain.WebhookDelivery{due}, nil
+	})
+	var updated realdomain.WebhookDelivery
+	mockWebhooks.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d realdomain.WebhookDelivery) *errs.AppError {
+		updated = d
+		return nil
+	})
+
+	// Act
+	if err := NewWebhookPublisher(mockAccounts, mockWebhooks).Publish(event); err != nil {
+		t.Fatal(err)
+	}
+	delivered, appError := NewWebhookDispatcher(mockWebhooks, receiver.Client(), time.Second, 3).RunOnce()
+
+	// Assert
+	if appError != nil || delivered != 1 || updated.Status != realdomain.DeliveryStatusDelivered {
+		t.Fatalf("delivered = %d, status = %s", delivered, updated.Status)
+	}
+	r := <-received
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(r.Header.Get("X-Webhook-Timestamp") + "."))
+	mac.Write(body)
+	if r.Header.Get("X-Webhook-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+		t.Error("Test failed while verifying the webhook signature")
+	}
+	var message dto.EventMessage
+	if err := json.Unmarshal(body, &message); err != nil || message.EventId != "77" || r.Header.Get("X-Webhook-Event") != realdomain.EventTransactionPosted {
+		t.Errorf("unexpected webhook body %s", body)
+	}
+}
+
+func Test_should_dead_letter_a_delivery_after_the_last_failed_attempt(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhooks := domain.NewMockWebhookRepository(ctrl)
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	due := realdomain.WebhookDelivery{DeliveryId: "9", Status: realdomain.DeliveryStatusPending, Attempts: 2, Url: receiver.URL,
+		Payload: []byte(`{}`)}
+	mockWebhooks.EXPECT().FindDueDeliveries(gomock.Any(), gomock.Any()).Return([]realdomain.WebhookDelivery{due}, nil)
+	var updated realdomain.WebhookDelivery
+	mockWebhooks.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d realdomain.WebhookDelivery) *errs.AppError {
+		updated = d
+		return nil
+	})
+	// Act
+	delivered, appError := NewWebhookDispatcher(mockWebhooks, receiver.Client(), time.Second, 3).RunOnce()
+
+	// Assert
+	if appError != nil || delivered != 0 || updated.Status != realdomain.DeliveryStatusDead || updated.LastStatusCode.Int64 != 503 {
+		t.Error("Test failed while dead lettering a webhook delivery")
+	}
+}
+
+func Test_should_reject_a_webhook_for_an_unknown_event_type(t *testing.T) {
+	service := NewWebhookService(nil)
+
+	_, appError := service.NewWebhook(dto.WebhookRequest{CustomerId: "100", Url: "https://partner.example/hooks",
+		EventTypes: []string{"CustomerDeleted"}})
+
+	if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+		t.Error("Test failed while validating the webhook event types")
+	}
+}
+
+func Test_should_reject_a_webhook_that_is_not_https_or_points_to_our_network(t *testing.T) {
+	service := NewWebhookService(nil)
+
+	for _, url := range []string{"http://partner.example/hooks", "https://localhost:8000/hooks", "https://127.0.0.1/hooks",
+		"https://10.0.0.7/hooks", "https://169.254.169.254/latest/meta-data", "https://[::1]/hooks", "https://0.0.0.0/hooks"} {
+		_, appError := service.NewWebhook(dto.WebhookRequest{CustomerId: "100", Url: url,
+			EventTypes: []string{realdomain.EventTransactionPosted}})
+
+		if appError == nil || appError.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Test failed while rejecting the webhook url %s", url)
+		}
+	}
+}
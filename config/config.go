@@ -0,0 +1,206 @@
+// Package config loads the settings of the banking services into a typed struct. Every setting is layered, a later
+// source overrides an earlier one:
+//
+//	defaults -> YAML file (-config or CONFIG_FILE) -> environment (and .env) -> command line flags
+//
+// The struct fields say where they come from with tags:
+//
+//	Port string `yaml:"port" env:"SERVER_PORT" flag:"port" usage:"port the server listens on"`
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v2"
+)
+
+// EnvFile : Loaded into the environment when it exists, variables already set win
+const EnvFile = ".env"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setting : A tagged field of the config struct
+type setting struct {
+	value reflect.Value
+	env   string
+	flag  string
+	usage string
+}
+
+// Loader : Fills a config struct, the flags are registered when it is created so the caller can parse its own
+// flags in the same flag set
+type Loader struct {
+	cfg        interface{}
+	flags      *flag.FlagSet
+	settings   []setting
+	configFile *string
+}
+
+// Load : Reads the file, the environment and the flags that were set, over the defaults already in the struct
+func (l Loader) Load() error {
+	if err := godotenv.Load(EnvFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s: %v", EnvFile, err)
+	}
+
+	path := *l.configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("config file: %v", err)
+		}
+		if err = yaml.UnmarshalStrict(content, l.cfg); err != nil {
+			return fmt.Errorf("config file %s: %v", path, err)
+		}
+	}
+
+	for _, s := range l.settings {
+		if s.env == "" {
+			continue
+		}
+		if value := os.Getenv(s.env); value != "" {
+			if err := set(s.value, value); err != nil {
+				return fmt.Errorf("%s: %v", s.env, err)
+			}
+		}
+	}
+
+	var err error
+	l.flags.Visit(func(f *flag.Flag) {
+		for _, s := range l.settings {
+			if s.flag == f.Name && err == nil {
+				if e := set(s.value, f.Value.String()); e != nil {
+					err = fmt.Errorf("-%s: %v", s.flag, e)
+				}
+			}
+		}
+	})
+	return err
+}
+
+// set : Parses the text into the field
+func set(field reflect.Value, text string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration like 30s or 24h", text)
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(text)
+	case field.Kind() == reflect.Int:
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", text)
+		}
+		field.SetInt(int64(n))
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", text)
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+	return nil
+}
+
+// collect : The tagged fields of the struct and of the structs nested in it
+func collect(v reflect.Value) []setting {
+	settings := make([]setting, 0)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		tag := v.Type().Field(i).Tag
+		if field.Kind() == reflect.Struct && field.Type() != durationType {
+			settings = append(settings, collect(field)...)
+			continue
+		}
+		if tag.Get("env") != "" || tag.Get("flag") != "" {
+			settings = append(settings, setting{field, tag.Get("env"), tag.Get("flag"), tag.Get("usage")})
+		}
+	}
+	return settings
+}
+
+// NewLoader : Registers a flag for every tagged field of cfg, a pointer to a struct holding the defaults, and the
+// -config flag naming the YAML file
+func NewLoader(cfg interface{}, flags *flag.FlagSet) Loader {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		panic("config should be a pointer to a struct")
+	}
+	settings := collect(v.Elem())
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		usage := s.usage
+		if s.env != "" {
+			usage += " (" + s.env + ")"
+		}
+		flags.String(s.flag, fmt.Sprint(s.value.Interface()), usage)
+	}
+	configFile := flags.String("config", "", "YAML config file (CONFIG_FILE)")
+	return Loader{cfg, flags, settings, configFile}
+}
+
+// Validator : A config struct that checks its settings once they are loaded
+type Validator interface {
+	Validate(p *Problems)
+}
+
+// Load : Parses the command's own flags together with the config flags, loads cfg, a pointer to a struct holding
+// the defaults, and validates it. It returns the positional arguments, which may come before or between the flags.
+func Load(cfg Validator, flags *flag.FlagSet, args []string) ([]string, error) {
+	loader := NewLoader(cfg, flags)
+	positional := make([]string, 0)
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+
+	if err := loader.Load(); err != nil {
+		return nil, errors.New("invalid configuration: " + err.Error())
+	}
+	var problems Problems
+	cfg.Validate(&problems)
+	if err := problems.Err(); err != nil {
+		return nil, err
+	}
+	return positional, nil
+}
+
+// Problems : Every invalid setting, so a single run reports all of them
+type Problems []string
+
+// Add : Records a problem, the message should name the setting
+func (p *Problems) Add(format string, args ...interface{}) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+// Err : nil when there are no problems
+func (p Problems) Err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return errors.New("invalid configuration: " + strings.Join(p, "; "))
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Server  Server        `yaml:"server"`
+	DB      DB            `yaml:"db"`
+	TTL     time.Duration `yaml:"ttl" env:"TEST_TTL" flag:"ttl"`
+	Retries int           `yaml:"retries" env:"TEST_RETRIES" flag:"retries"`
+}
+
+func defaultTestConfig() testConfig {
+	return testConfig{Server: Server{Address: "localhost", Port: "8000"}, DB: DefaultDB(), TTL: time.Hour, Retries: 3}
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func load(t *testing.T, cfg *testConfig, args ...string) error {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := NewLoader(cfg, flags)
+	if err := flags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return loader.Load()
+}
+
+func Test_should_layer_defaults_file_environment_and_flags(t *testing.T) {
+	path := writeFile(t, "server:\n  port: \"9000\"\ndb:\n  driver: postgres\n  name: bank\nttl: 2h\nretries: 5\n")
+	os.Setenv("TEST_TTL", "3h")
+	os.Setenv("DB_NAME", "from_env")
+	defer os.Unsetenv("TEST_TTL")
+	defer os.Unsetenv("DB_NAME")
+	cfg := defaultTestConfig()
+
+	err := load(t, &cfg, "-config", path, "-db-name", "from_flag")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Address != "localhost" || cfg.DB.MaxOpenConns != 10 {
+		t.Error("Settings nobody overrides should keep the default: ", cfg)
+	}
+	if cfg.Server.Port != "9000" || cfg.Retries != 5 || cfg.DB.Driver != "postgres" {
+		t.Error("The file should override the defaults: ", cfg)
+	}
+	if cfg.TTL != 3*time.Hour {
+		t.Error("The environment should override the file: ", cfg.TTL)
+	}
+	if cfg.DB.Name != "from_flag" {
+		t.Error("A flag should override the environment: ", cfg.DB.Name)
+	}
+}
+
+func Test_should_name_the_setting_that_cannot_be_parsed(t *testing.T) {
+	os.Setenv("TEST_RETRIES", "many")
+	defer os.Unsetenv("TEST_RETRIES")
+	cfg := defaultTestConfig()
+
+	err := load(t, &cfg)
+
+	if err == nil || !strings.Contains(err.Error(), "TEST_RETRIES") {
+		t.Error("The error should name the variable: ", err)
+	}
+	os.Unsetenv("TEST_RETRIES")
+	if err := load(t, &cfg, "-ttl", "soon"); err == nil || !strings.HasPrefix(err.Error(), "-ttl") {
+		t.Error("The error should name the flag: ", err)
+	}
+}
+
+func Test_should_refuse_unknown_keys_in_the_file(t *testing.T) {
+	cfg := defaultTestConfig()
+
+	err := load(t, &cfg, "-config", writeFile(t, "db:\n  pasword: secret\n"))
+
+	if err == nil || !strings.Contains(err.Error(), "pasword") {
+		t.Error("A misspelled key should be reported: ", err)
+	}
+}
+
+func Test_should_report_every_missing_database_setting(t *testing.T) {
+	var problems Problems
+	DB{Driver: "mysql", Name: "banking", MaxOpenConns: 10, MaxIdleConns: 10}.Validate(&problems)
+
+	if len(problems) != 4 || !strings.Contains(problems.Err().Error(), "db.password (DB_PASSWORD) is required for mysql") {
+		t.Error("Invalid problems: ", problems)
+	}
+	problems = nil
+	DB{Driver: "sqlite", Name: "banking.db", MaxOpenConns: 1}.Validate(&problems)
+	if problems.Err() != nil {
+		t.Error("SQLite only needs the file: ", problems)
+	}
+}
+
+// Validate : The test config only checks its retries
+func (c testConfig) Validate(p *Problems) {
+	if c.Retries <= 0 {
+		p.Add("retries (TEST_RETRIES) should be a positive number")
+	}
+}
+
+func Test_should_return_the_arguments_between_the_flags(t *testing.T) {
+	cfg := defaultTestConfig()
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	steps := flags.Int("steps", 1, "")
+
+	positional, err := Load(&cfg, flags, []string{"down", "-steps", "2", "extra", "-retries", "5"})
+
+	if err != nil || len(positional) != 2 || positional[0] != "down" || positional[1] != "extra" {
+		t.Fatal("Invalid positional arguments: ", positional, err)
+	}
+	if *steps != 2 || cfg.Retries != 5 {
+		t.Errorf("steps = %d, retries = %d", *steps, cfg.Retries)
+	}
+}
+
+func Test_should_refuse_a_config_that_does_not_validate(t *testing.T) {
+	cfg := defaultTestConfig()
+
+	_, err := Load(&cfg, flag.NewFlagSet("test", flag.ContinueOnError), []string{"-retries", "0"})
+
+	if err == nil || !strings.Contains(err.Error(), "TEST_RETRIES") {
+		t.Error("The invalid retries should be reported: ", err)
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Database drivers by the name sqlx knows them
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+)
+
+// DB : Connection and pool settings of the database, the same for both services
+type DB struct {
+	Driver          string        `yaml:"driver" env:"DB_DRIVER" flag:"db-driver" usage:"database: mysql, postgres or sqlite"`
+	User            string        `yaml:"user" env:"DB_USER" flag:"db-user" usage:"database user"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" flag:"db-password" usage:"database password"`
+	Address         string        `yaml:"address" env:"DB_ADDRESS" flag:"db-address" usage:"database host"`
+	Port            string        `yaml:"port" env:"DB_PORT" flag:"db-port" usage:"database port"`
+	Name            string        `yaml:"name" env:"DB_NAME" flag:"db-name" usage:"database name, the file path for sqlite"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE" flag:"db-sslmode" usage:"postgres sslmode"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" flag:"db-max-open-conns" usage:"most open connections in the pool"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" flag:"db-max-idle-conns" usage:"most idle connections in the pool"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" flag:"db-conn-max-lifetime" usage:"how long a connection is reused"`
+}
+
+// DefaultDB : MySQL with the pool sizes the services always used
+func DefaultDB() DB {
+	return DB{Driver: "mysql", SSLMode: "disable", MaxOpenConns: 10, MaxIdleConns: 10, ConnMaxLifetime: 3 * time.Minute}
+}
+
+// DriverName : The sqlx driver of Driver, which also accepts postgresql and sqlite
+func (d DB) DriverName() string {
+	switch d.Driver {
+	case "mysql":
+		return DriverMySQL
+	case "postgres", "postgresql":
+		return DriverPostgres
+	case "sqlite", "sqlite3":
+		return DriverSQLite
+	}
+	return ""
+}
+
+// DataSource : The connection string of the driver
+func (d DB) DataSource() string {
+	switch d.DriverName() {
+	case DriverPostgres:
+		return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", d.Address, d.Port, d.User, d.Password, d.Name, d.SSLMode)
+	case DriverSQLite:
+		return d.Name + "?_busy_timeout=5000&_foreign_keys=1"
+	}
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", d.User, d.Password, d.Address, d.Port, d.Name)
+}
+
+// Validate : SQLite only needs the file, the server databases need every connection setting
+func (d DB) Validate(p *Problems) {
+	driver := d.DriverName()
+	if driver == "" {
+		p.Add("db.driver (DB_DRIVER) should be mysql, postgres or sqlite, not %q", d.Driver)
+		return
+	}
+	if d.Name == "" {
+		p.Add("db.name (DB_NAME) is required")
+	}
+	if driver != DriverSQLite {
+		for _, s := range []struct{ value, name string }{
+			{d.User, "db.user (DB_USER)"},
+			{d.Password, "db.password (DB_PASSWORD)"},
+			{d.Address, "db.address (DB_ADDRESS)"},
+			{d.Port, "db.port (DB_PORT)"},
+		} {
+			if s.value == "" {
+				p.Add("%s is required for %s", s.name, d.Driver)
+			}
+		}
+	}
+	if d.MaxOpenConns < 1 {
+		p.Add("db.max_open_conns (DB_MAX_OPEN_CONNS) should be at least 1, not %d", d.MaxOpenConns)
+	}
+	// database/sql keeps at most max_open_conns idle connections, a larger value is not an error
+	if d.MaxIdleConns < 0 {
+		p.Add("db.max_idle_conns (DB_MAX_IDLE_CONNS) should not be negative, not %d", d.MaxIdleConns)
+	}
+	if d.ConnMaxLifetime < 0 {
+		p.Add("db.conn_max_lifetime (DB_CONN_MAX_LIFETIME) should not be negative")
+	}
+}
@@ -0,0 +1,8 @@
+module config
+
+go 1.16
+
+require (
+	github.com/joho/godotenv v1.3.0
+	gopkg.in/yaml.v2 v2.4.0
+)
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+// Server : Where a service listens
+type Server struct {
+	Address         string        `yaml:"address" env:"SERVER_ADDRESS" flag:"address" usage:"host the server listens on, empty for every interface"`
+	Port            string        `yaml:"port" env:"SERVER_PORT" flag:"port" usage:"port the server listens on"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"how long the requests in flight may take after SIGTERM"`
+}
+
+// ListenAddress : host:port for http.ListenAndServe
+func (s Server) ListenAddress() string {
+	return net.JoinHostPort(s.Address, s.Port)
+}
+
+// Validate : The address may be empty, the port and the timeout may not
+func (s Server) Validate(p *Problems) {
+	if port, err := strconv.Atoi(s.Port); err != nil || port < 1 || port > 65535 {
+		p.Add("server.port (SERVER_PORT) should be a port number between 1 and 65535, not %q", s.Port)
+	}
+	if s.ShutdownTimeout <= 0 {
+		p.Add("server.shutdown_timeout (SERVER_SHUTDOWN_TIMEOUT) should be a positive duration like 30s")
+	}
+}
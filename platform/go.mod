@@ -0,0 +1,10 @@
+module platform
+
+go 1.16
+
+require (
+	github.com/gorilla/mux v1.8.0
+	github.com/jmoiron/sqlx v1.2.0
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/prometheus/client_golang v1.12.2
+)
@@ -0,0 +1,85 @@
+// Package metrics holds the Prometheus collectors every banking server exports: the Go runtime, the process, the
+// HTTP requests by route name and the connection pool. Each server adds its own business collectors on top.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server : Collectors exposed on /metrics, each server has its own registry so tests can build as many as they need
+type Server struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+}
+
+// Handler : Serves the registry in the Prometheus text format
+func (m Server) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware : Counts and times every request by its route name, the router only calls it for matched routes
+func (m Server) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		route := mux.CurrentRoute(r).GetName()
+		m.requests.WithLabelValues(route, r.Method, strconv.Itoa(recorder.code)).Inc()
+		m.requestDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// RegisterDB : Exports the stats of the connection pool
+func (m Server) RegisterDB(client *sqlx.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(client.DB, name))
+}
+
+// MustRegister : Adds the business collectors of the server to its registry
+func (m Server) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// NewServer : Registers the HTTP collectors, named namespace_http_..., with the Go runtime and process collectors
+func NewServer(namespace string) Server {
+	m := Server{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route name, method and status code.",
+		}, []string{"route", "method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests by route name and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests, m.requestDuration,
+	)
+	return m
+}
+
+// statusRecorder : Keeps the status code written through it
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,244 @@
+// Package migrate applies the numbered schema migrations a service embeds, one directory per dialect, and records
+// them in a version table of the service.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/**
+ * Every dialect has its own directory of numbered files, NNNN_name.up.sql creates and NNNN_name.down.sql drops.
+ * A new feature adds the next number to all the directories, an applied file is never edited again.
+ */
+
+const appliedAtLayout = "2006-01-02 15:04:05"
+
+// Migration : One numbered schema change and the statements that undo it
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus : A migration and when it was applied, AppliedAt is empty while it is pending
+type MigrationStatus struct {
+	Migration
+	AppliedAt string
+}
+
+// Migrator : Applies the migrations of the client's dialect and records them in the version table
+type Migrator struct {
+	client     *sqlx.DB
+	table      string
+	migrations []Migration
+}
+
+// Status : Every known migration in version order, with the time it was applied
+func (m Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	status := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status = append(status, MigrationStatus{migration, applied[migration.Version]})
+	}
+	return status, nil
+}
+
+// Up : Applies the pending migrations in version order and returns them, it stops at the first one that fails
+func (m Migrator) Up() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	done := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		if applied[migration.Version] != "" {
+			continue
+		}
+		err = m.run(migration.Up, `INSERT INTO `+m.table+` (version, name, applied_at) VALUES (?, ?, ?)`,
+			migration.Version, migration.Name, time.Now().Format(appliedAtLayout))
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down : Reverts the last steps applied migrations, newest first, and returns them
+func (m Migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	done := make([]Migration, 0)
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if applied[migration.Version] == "" {
+			continue
+		}
+		err = m.run(migration.Down, `DELETE FROM `+m.table+` WHERE version = ?`, migration.Version)
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Baseline : Records the migrations up to version as applied without running them and returns them. A database
+// created before the migrations existed already has those tables, Up would fail on the first CREATE TABLE.
+func (m Migrator) Baseline(version int) ([]Migration, error) {
+	known := false
+	for _, migration := range m.migrations {
+		known = known || migration.Version == version
+	}
+	if !known {
+		return nil, fmt.Errorf("there is no migration %04d", version)
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	done := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		if migration.Version > version || applied[migration.Version] != "" {
+			continue
+		}
+		err = m.run("", `INSERT INTO `+m.table+` (version, name, applied_at) VALUES (?, ?, ?)`,
+			migration.Version, migration.Name, time.Now().Format(appliedAtLayout))
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// run : Executes the statements of a migration and records it, in one database transaction. MySQL commits
+// every DDL statement on its own, a failed MySQL migration may have to be cleaned up by hand before it is retried.
+func (m Migrator) run(script string, record string, args ...interface{}) error {
+	tx, err := m.client.Beginx()
+	if err != nil {
+		return err
+	}
+	for _, statement := range splitStatements(script) {
+		if _, err = tx.Exec(statement); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err = tx.Exec(tx.Rebind(record), args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// applied : Applied versions and when they were applied, the version table is created on first use
+func (m Migrator) applied() (map[int]string, error) {
+	_, err := m.client.Exec(`CREATE TABLE IF NOT EXISTS ` + m.table + ` (version INTEGER NOT NULL PRIMARY KEY,
+								name VARCHAR(255) NOT NULL, applied_at VARCHAR(19) NOT NULL)`)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]struct {
+		Version   int    `db:"version"`
+		AppliedAt string `db:"applied_at"`
+	}, 0)
+	if err = m.client.Select(&rows, `SELECT version, applied_at FROM `+m.table); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]string)
+	for _, r := range rows {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// splitStatements : The drivers run one statement per call, statements end with a semicolon at the end of a line
+func splitStatements(script string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		current.WriteString(line + "\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			if s := strings.TrimSpace(current.String()); s != ";" {
+				statements = append(statements, s)
+			}
+			current.Reset()
+		}
+	}
+	if s := strings.TrimSpace(current.String()); s != "" {
+		statements = append(statements, s)
+	}
+	return statements
+}
+
+// Load : Reads the up and down files of a dialect directory, every version needs both
+func Load(fsys fs.FS, dialect string) ([]Migration, error) {
+	names, err := fs.Glob(fsys, dialect+"/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no migrations for " + dialect)
+	}
+	byVersion := make(map[int]*Migration)
+	for _, name := range names {
+		base := path.Base(name)
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, errors.New("migration file should be named NNNN_name.up.sql or NNNN_name.down.sql: " + base)
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version}
+			byVersion[version] = migration
+		}
+		switch {
+		case strings.HasSuffix(parts[1], ".up.sql"):
+			migration.Name = strings.TrimSuffix(parts[1], ".up.sql")
+			migration.Up = string(content)
+		case strings.HasSuffix(parts[1], ".down.sql"):
+			migration.Down = string(content)
+		default:
+			return nil, errors.New("migration file should end with .up.sql or .down.sql: " + base)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d of %s needs an up and a down file", migration.Version, dialect)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// NewMigrator : Returns a migrator with the migrations of the client's driver in fsys, recorded in table
+func NewMigrator(client *sqlx.DB, fsys fs.FS, table string) (*Migrator, error) {
+	migrations, err := Load(fsys, client.DriverName())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{client, table, migrations}, nil
+}
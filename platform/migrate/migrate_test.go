@@ -0,0 +1,118 @@
+package migrate
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// files : Three migrations of a made up schema
+var files = fstest.MapFS{
+	"sqlite3/0001_create_customers.up.sql":   {Data: []byte("CREATE TABLE customers (customer_id INTEGER PRIMARY KEY, name TEXT);\n")},
+	"sqlite3/0001_create_customers.down.sql": {Data: []byte("DROP TABLE customers;\n")},
+	"sqlite3/0002_create_accounts.up.sql":    {Data: []byte("CREATE TABLE accounts (account_id INTEGER PRIMARY KEY);\n")},
+	"sqlite3/0002_create_accounts.down.sql":  {Data: []byte("DROP TABLE accounts;\n")},
+	"sqlite3/0003_create_webhooks.up.sql":    {Data: []byte("CREATE TABLE webhooks (webhook_id INTEGER PRIMARY KEY);\nCREATE INDEX webhooks_id ON webhooks (webhook_id);\n")},
+	"sqlite3/0003_create_webhooks.down.sql":  {Data: []byte("DROP TABLE webhooks;\n")},
+}
+
+func newSQLiteMigrator(t *testing.T) (*Migrator, *sqlx.DB) {
+	client, err := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	migrator, err := NewMigrator(client, files, "schema_migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return migrator, client
+}
+
+func tableExists(client *sqlx.DB, table string) bool {
+	var count int
+	return client.Get(&count, `SELECT COUNT(*) FROM `+table) == nil
+}
+
+func Test_should_apply_every_migration_once(t *testing.T) {
+	migrator, client := newSQLiteMigrator(t)
+
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := migrator.Up()
+
+	if err != nil || len(again) != 0 {
+		t.Error("A second run should apply nothing: ", again, err)
+	}
+	if len(applied) != 3 || !tableExists(client, "webhooks") {
+		t.Error("Every migration should be applied: ", applied)
+	}
+}
+
+func Test_should_revert_the_last_migrations(t *testing.T) {
+	migrator, client := newSQLiteMigrator(t)
+	migrator.Up()
+
+	reverted, err := migrator.Down(2)
+	if err != nil || len(reverted) != 2 || reverted[0].Version != 3 || reverted[1].Version != 2 {
+		t.Fatal("Only the last two migrations should be reverted: ", reverted, err)
+	}
+	status, _ := migrator.Status()
+
+	if status[0].AppliedAt == "" || status[1].AppliedAt != "" || tableExists(client, "webhooks") {
+		t.Error("Invalid status after down: ", status)
+	}
+	if _, err := migrator.Down(10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Error("The schema should be created again after a full down: ", err)
+	}
+}
+
+func Test_should_adopt_the_tables_of_an_existing_database_with_a_baseline(t *testing.T) {
+	migrator, client := newSQLiteMigrator(t)
+	client.MustExec(`CREATE TABLE customers (customer_id INTEGER PRIMARY KEY, name TEXT)`)
+	client.MustExec(`INSERT INTO customers (customer_id, name) VALUES (2000, 'Jotaro')`)
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("Creating a table that exists should fail")
+	}
+
+	baselined, err := migrator.Baseline(1)
+	if err != nil || len(baselined) != 1 || baselined[0].Version != 1 {
+		t.Fatal("Only the first migration should be recorded: ", baselined, err)
+	}
+	applied, err := migrator.Up()
+
+	if err != nil || len(applied) != 2 || !tableExists(client, "webhooks") {
+		t.Error("The later migrations should be applied after the baseline: ", applied, err)
+	}
+	var name string
+	if err := client.Get(&name, `SELECT name FROM customers WHERE customer_id = 2000`); err != nil || name != "Jotaro" {
+		t.Error("The existing rows should be kept: ", err)
+	}
+	if _, err := migrator.Baseline(7); err == nil {
+		t.Error("A baseline at an unknown version should be refused")
+	}
+}
+
+func Test_should_split_statements_at_the_end_of_a_line(t *testing.T) {
+	statements := splitStatements("CREATE TABLE a (\n  b TEXT DEFAULT ';'\n);\n\n-- comment\nDROP TABLE c;\n")
+
+	if len(statements) != 2 || statements[1] != "-- comment\nDROP TABLE c;" {
+		t.Error("Invalid statements: ", statements)
+	}
+}
+
+func Test_should_refuse_a_migration_without_its_down_file(t *testing.T) {
+	incomplete := fstest.MapFS{"sqlite3/0001_create_customers.up.sql": {Data: []byte("CREATE TABLE customers (id INTEGER);\n")}}
+
+	if _, err := Load(incomplete, "sqlite3"); err == nil {
+		t.Error("A migration needs an up and a down file")
+	}
+}
@@ -0,0 +1,42 @@
+// Package roles holds the routes each role of a token may call. banking-auth verifies the tokens against it and the
+// in-memory auth adapter of the banking service checks the same list, so the two cannot drift apart.
+package roles
+
+import (
+	"strings"
+)
+
+// Roles of the users, admins may call every route and users only the ones about their own customer and accounts
+const (
+	Admin = "admin"
+	User  = "user"
+)
+
+type Permissions struct {
+	rolePermissions map[string][]string
+}
+
+// IsAuthorizedFor : Whether the role may call the named route
+func (p Permissions) IsAuthorizedFor(role string, routeName string) bool {
+	perms := p.rolePermissions[role]
+	// Loop through all the allowed routes to match which the current route
+	for _, r := range perms {
+		if r == strings.TrimSpace(routeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Default : The route names of the banking service for each role
+func Default() Permissions {
+	return Permissions{map[string][]string{
+		Admin: {"GetAllCustomers", "GetCustomer", "NewAccount", "NewTransaction", "GetTransactions", "NewTransfer",
+			"LoadFxRates", "GetFxRates", "GetStatement", "GetAccount", "FreezeAccount", "UnfreezeAccount", "CloseAccount",
+			"NewCustomer", "UpdateCustomer", "PatchCustomer", "DeactivateCustomer", "GetTrialBalance",
+			"ReverseTransaction", "SetOverdraftLimit", "GetPendingTransactions", "ApprovePendingTransaction",
+			"RejectPendingTransaction", "NewWebhook", "GetWebhooks", "DeleteWebhook", "GetWebhookDeliveries"},
+		User: {"GetCustomer", "NewTransaction", "GetTransactions", "NewTransfer", "GetStatement", "GetAccount",
+			"NewWebhook", "GetWebhooks", "DeleteWebhook", "GetWebhookDeliveries"},
+	}}
+}